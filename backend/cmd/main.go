@@ -7,7 +7,9 @@ import (
 	"auction-app/backend/internal/middleware"
 	"auction-app/backend/internal/services"
 	"auction-app/backend/internal/store"
+	"context"
 	"log"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -27,14 +29,62 @@ func main() {
 	auctionStore := store.NewGormAuctionStore(db)
 	lotStore := store.NewGormLotStore(db)
 	bidStore := store.NewGormBidStore(db)
+	blocklistStore := store.NewGormBlocklistStore(db)
+	creditLimitStore := store.NewGormCreditLimitStore(db)
+	lotImageStore := store.NewGormLotImageStore(db)
+	attributeSchemaStore := store.NewGormAttributeSchemaStore(db)
+	categoryStore := store.NewGormCategoryStore(db)
+	consignmentStore := store.NewGormConsignmentStore(db)
+	watchlistStore := store.NewGormWatchlistStore(db)
+	notificationStore := store.NewGormNotificationStore(db)
+	savedSearchStore := store.NewGormSavedSearchStore(db)
+	webhookStore := store.NewGormWebhookStore(db)
+	outboxStore := store.NewGormOutboxStore(db)
+	auditStore := store.NewGormAuditStore(db)
+	sessionStore := store.NewGormSessionStore(db)
+	blobStore, err := store.NewLocalBlobStore(cfg.UploadDir)
+	if err != nil {
+		log.Fatalf("Ошибка инициализации хранилища файлов: %v", err)
+	}
 	store.SeedSystemAdmin(db)
+	store.SeedAttributeSchemas(db)
+	store.SeedCategories(db)
+
+	var notificationChannels []services.NotificationChannel
+	if cfg.SMTPHost != "" {
+		smtpChannel, err := services.NewSMTPChannel(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+		if err != nil {
+			log.Fatalf("Ошибка настройки SMTP-канала уведомлений: %v", err)
+		}
+		notificationChannels = append(notificationChannels, smtpChannel)
+	}
+	notificationService := services.NewNotificationService(notificationStore, userStore, notificationChannels...)
+	eventHub := services.NewEventHub(store.NewPostgresEventBus(db, cfg))
+	eventHub.Start(context.Background())
+	webhookService := services.NewWebhookService(webhookStore)
+	auditService := services.NewAuditService(auditStore)
+	timelineService := services.NewTimelineService(auctionStore, bidStore, outboxStore)
+	outboxRelay := services.NewOutboxRelay(outboxStore,
+		services.NewNotificationSubscriber(notificationService, watchlistStore),
+		services.NewRealtimeSubscriber(eventHub),
+		services.NewWebhookSubscriber(webhookService),
+	)
 
-	authService := services.NewAuthService(userStore, cfg)
-	auctionService := services.NewAuctionService(auctionStore, lotStore)
-	lotService := services.NewLotService(lotStore, auctionStore, bidStore)
-	userActivityService := services.NewUserActivityService(lotStore, auctionStore)
-	reportService := services.NewReportService(auctionStore, lotStore, userStore)
-	userService := services.NewUserService(userStore)
+	authService := services.NewAuthService(userStore, sessionStore, cfg)
+	auctionService := services.NewAuctionService(auctionStore, lotStore, bidStore, attributeSchemaStore, categoryStore, outboxRelay)
+	lotService := services.NewLotService(lotStore, auctionStore, bidStore, blocklistStore, creditLimitStore, categoryStore, watchlistStore, notificationService, outboxRelay, cfg.BannedWords)
+	userActivityService := services.NewUserActivityService(lotStore, auctionStore, watchlistStore)
+	reportService := services.NewReportService(auctionStore, lotStore, userStore, categoryStore)
+	userService := services.NewUserService(userStore, sessionStore, notificationService)
+	blocklistService := services.NewBlocklistService(blocklistStore, userStore)
+	creditLimitService := services.NewCreditLimitService(creditLimitStore, auctionStore, userStore, notificationService)
+	attributeSchemaService := services.NewAttributeSchemaService(attributeSchemaStore)
+	categoryService := services.NewCategoryService(categoryStore, attributeSchemaStore)
+	catalogueService := services.NewCatalogueService(auctionStore, lotImageStore, blobStore)
+	consignmentService := services.NewConsignmentService(consignmentStore, auctionStore, lotImageStore, blobStore, lotService, notificationService, int64(cfg.MaxUploadSizeMB)*1024*1024, int64(cfg.MaxImageMegapixels)*1000*1000)
+	watchlistService := services.NewWatchlistService(watchlistStore, lotStore, auctionStore)
+	savedSearchService := services.NewSavedSearchService(savedSearchStore, lotStore, notificationService)
+	lotImageService := services.NewLotImageService(lotStore, auctionStore, lotImageStore, blobStore, int64(cfg.MaxUploadSizeMB)*1024*1024, int64(cfg.MaxImageMegapixels)*1000*1000)
 
 	authHandler := api.NewAuthHandler(authService)
 	auctionHandler := api.NewAuctionHandler(auctionService)
@@ -42,8 +92,29 @@ func main() {
 	userActivityHandler := api.NewUserActivityHandler(userActivityService)
 	reportHandler := api.NewReportHandler(reportService)
 	adminHandler := api.NewAdminHandler(userService)
+	blocklistHandler := api.NewBlocklistHandler(blocklistService)
+	creditLimitHandler := api.NewCreditLimitHandler(creditLimitService)
+	lotImageHandler := api.NewLotImageHandler(lotImageService)
+	attributeSchemaHandler := api.NewAttributeSchemaHandler(attributeSchemaService)
+	categoryHandler := api.NewCategoryHandler(categoryService)
+	catalogueHandler := api.NewCatalogueHandler(catalogueService)
+	consignmentHandler := api.NewConsignmentHandler(consignmentService)
+	watchlistHandler := api.NewWatchlistHandler(watchlistService)
+	savedSearchHandler := api.NewSavedSearchHandler(savedSearchService)
+	notificationHandler := api.NewNotificationHandler(notificationService)
+	eventStreamHandler := api.NewEventStreamHandler(eventHub, auctionService, lotService)
+	webhookHandler := api.NewWebhookHandler(webhookService)
+	auditHandler := api.NewAuditHandler(auditService)
+	timelineHandler := api.NewTimelineHandler(timelineService)
+
+	authMiddleware := middleware.AuthMiddleware(cfg, authService)
+	optionalAuthMiddleware := middleware.OptionalAuthMiddleware(cfg, authService)
 
 	router := gin.Default()
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("Некорректный список доверенных прокси: %v", err)
+	}
+	router.MaxMultipartMemory = int64(cfg.MaxUploadSizeMB) * 1024 * 1024
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowOrigins = []string{"http://localhost:3000"}
 	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
@@ -58,7 +129,11 @@ func main() {
 		{
 			authRoutes.POST("/register", authHandler.Register)
 			authRoutes.POST("/login", authHandler.Login)
-			authRoutes.GET("/me", middleware.AuthMiddleware(cfg), authHandler.Me)
+			authRoutes.POST("/refresh", authHandler.Refresh)
+			authRoutes.POST("/switch-role", authMiddleware, authHandler.SwitchRole)
+			authRoutes.POST("/logout", authMiddleware, authHandler.Logout)
+			authRoutes.POST("/logout-all", authMiddleware, authHandler.LogoutAll)
+			authRoutes.GET("/me", authMiddleware, authHandler.Me)
 		}
 
 		// Общие маршруты для аукционов
@@ -66,29 +141,42 @@ func main() {
 		{
 			auctionsBaseRoutes.GET("", auctionHandler.GetAllAuctions)
 			auctionsBaseRoutes.GET("/search", auctionHandler.FindAuctionsBySpecificity)
-			auctionsBaseRoutes.POST("", middleware.AuthMiddleware(cfg), auctionHandler.CreateAuction)
+			auctionsBaseRoutes.POST("", authMiddleware, auctionHandler.CreateAuction)
 		}
 
 		// Маршруты для конкретного аукциона /auctions/:auctionId
 		auctionSpecificRoutes := v1.Group("/auctions/:auctionId")
 		{
 			auctionSpecificRoutes.GET("", auctionHandler.GetAuctionByID)
-			auctionSpecificRoutes.PUT("", middleware.AuthMiddleware(cfg), auctionHandler.UpdateAuction)
-			auctionSpecificRoutes.PATCH("/status", middleware.AuthMiddleware(cfg), auctionHandler.UpdateAuctionStatus)
-			auctionSpecificRoutes.DELETE("", middleware.AuthMiddleware(cfg), auctionHandler.DeleteAuction)
+			auctionSpecificRoutes.GET("/catalogue.pdf", catalogueHandler.GetAuctionCatalogue)
+			auctionSpecificRoutes.GET("/events", eventStreamHandler.StreamAuctionEvents)
+			auctionSpecificRoutes.GET("/timeline", authMiddleware, timelineHandler.GetAuctionTimeline)
+			auctionSpecificRoutes.PUT("", authMiddleware, auctionHandler.UpdateAuction)
+			auctionSpecificRoutes.PATCH("/status", authMiddleware, auctionHandler.UpdateAuctionStatus)
+			auctionSpecificRoutes.DELETE("", authMiddleware, auctionHandler.DeleteAuction)
 
 			// Вложенные маршруты для лотов этого аукциона
 			lotsForAuctionRoutes := auctionSpecificRoutes.Group("/lots")
 			{
 				lotsForAuctionRoutes.GET("", lotHandler.GetLotsByAuctionID)
-				lotsForAuctionRoutes.POST("", middleware.AuthMiddleware(cfg), lotHandler.CreateLot)
+				lotsForAuctionRoutes.POST("", authMiddleware, lotHandler.CreateLot)
+				lotsForAuctionRoutes.POST("/import", authMiddleware, lotHandler.ImportLots)
+				lotsForAuctionRoutes.PUT("/order", authMiddleware, lotHandler.ReorderLots)
 
 				// Маршруты для конкретного лота в рамках аукциона
 				specificLotRoutes := lotsForAuctionRoutes.Group("/:lotId")
 				{
-					specificLotRoutes.PUT("", middleware.AuthMiddleware(cfg), lotHandler.UpdateLotDetails)
-					specificLotRoutes.DELETE("", middleware.AuthMiddleware(cfg), lotHandler.DeleteLot)
-					specificLotRoutes.POST("/bids", middleware.AuthMiddleware(cfg), lotHandler.PlaceBid)
+					specificLotRoutes.GET("/events", eventStreamHandler.StreamLotEvents)
+					specificLotRoutes.PUT("", authMiddleware, lotHandler.UpdateLotDetails)
+					specificLotRoutes.DELETE("", authMiddleware, lotHandler.DeleteLot)
+					specificLotRoutes.POST("/bids", authMiddleware, lotHandler.PlaceBid)
+					specificLotRoutes.POST("/withdraw", authMiddleware, lotHandler.WithdrawLot)
+
+					// Фотографии лота
+					specificLotRoutes.POST("/images", authMiddleware, lotImageHandler.UploadLotImages)
+					specificLotRoutes.PUT("/images/order", authMiddleware, lotImageHandler.ReorderLotImages)
+					specificLotRoutes.PATCH("/images/:imageId/primary", authMiddleware, lotImageHandler.SetPrimaryLotImage)
+					specificLotRoutes.DELETE("/images/:imageId", authMiddleware, lotImageHandler.DeleteLotImage)
 				}
 			}
 		}
@@ -97,20 +185,69 @@ func main() {
 		individualLotRoutes := v1.Group("/lots")
 		{
 			individualLotRoutes.GET("", lotHandler.GetAllLots)
-			individualLotRoutes.GET("/:lotId", lotHandler.GetLotByID)
+			individualLotRoutes.GET("/:lotId", optionalAuthMiddleware, lotHandler.GetLotByID)
+			individualLotRoutes.GET("/:lotId/images", lotImageHandler.GetLotImages)
+			individualLotRoutes.GET("/:lotId/images/:imageId", lotImageHandler.GetLotImageFile)
+			individualLotRoutes.GET("/:lotId/images/:imageId/thumbnail", lotImageHandler.GetLotImageThumbnail)
+		}
+
+		// Схемы атрибутов лотов (для построения форм и фильтров)
+		attributeSchemaRoutes := v1.Group("/attribute-schemas")
+		{
+			attributeSchemaRoutes.GET("", attributeSchemaHandler.GetAllAttributeSchemas)
+			attributeSchemaRoutes.GET("/:schemaId", attributeSchemaHandler.GetAttributeSchemaByID)
+		}
+
+		// Рубрикатор
+		categoryRoutes := v1.Group("/categories")
+		{
+			categoryRoutes.GET("", categoryHandler.GetCategoryTree)
+			categoryRoutes.GET("/:categoryId", categoryHandler.GetCategoryByID)
 		}
 
 		// Маршруты для личной активности пользователя
 		myRoutes := v1.Group("/my")
-		myRoutes.Use(middleware.AuthMiddleware(cfg))
+		myRoutes.Use(authMiddleware)
 		{
 			myRoutes.GET("/activity", userActivityHandler.GetMyActivity)
 			myRoutes.GET("/listings", userActivityHandler.GetMyListings)
+			myRoutes.GET("/blocklist", blocklistHandler.GetMyBlocklist)
+			myRoutes.POST("/blocklist", blocklistHandler.BlockBidder)
+			myRoutes.DELETE("/blocklist/:userId", blocklistHandler.UnblockBidder)
+			myRoutes.GET("/watchlist", watchlistHandler.GetMyWatchlist)
+			myRoutes.POST("/watchlist", watchlistHandler.AddToWatchlist)
+			myRoutes.DELETE("/watchlist/lots/:lotId", watchlistHandler.RemoveWatchedLot)
+			myRoutes.DELETE("/watchlist/auctions/:auctionId", watchlistHandler.RemoveWatchedAuction)
+			myRoutes.GET("/saved-searches", savedSearchHandler.GetMySavedSearches)
+			myRoutes.POST("/saved-searches", savedSearchHandler.CreateSavedSearch)
+			myRoutes.PUT("/saved-searches/:searchId", savedSearchHandler.UpdateSavedSearch)
+			myRoutes.DELETE("/saved-searches/:searchId", savedSearchHandler.DeleteSavedSearch)
+			myRoutes.GET("/notifications", notificationHandler.GetMyNotifications)
+			myRoutes.PATCH("/notifications/:notificationId/read", notificationHandler.MarkRead)
+			myRoutes.POST("/notifications/read-all", notificationHandler.MarkAllRead)
+			myRoutes.GET("/webhooks", webhookHandler.GetMyWebhooks)
+			myRoutes.POST("/webhooks", webhookHandler.CreateWebhook)
+			myRoutes.PUT("/webhooks/:webhookId", webhookHandler.UpdateWebhook)
+			myRoutes.DELETE("/webhooks/:webhookId", webhookHandler.DeleteWebhook)
+			myRoutes.GET("/webhooks/:webhookId/deliveries", webhookHandler.GetWebhookDeliveries)
+			myRoutes.POST("/webhooks/:webhookId/deliveries/:deliveryId/redeliver", webhookHandler.RedeliverWebhook)
+		}
+
+		// Заявки продавцов на комиссию
+		consignmentRoutes := v1.Group("/consignments")
+		consignmentRoutes.Use(authMiddleware)
+		{
+			consignmentRoutes.POST("", consignmentHandler.CreateConsignment)
+			consignmentRoutes.GET("", consignmentHandler.GetConsignments)
+			consignmentRoutes.GET("/:consignmentId", consignmentHandler.GetConsignmentByID)
+			consignmentRoutes.POST("/:consignmentId/items/:itemId/images", consignmentHandler.UploadItemImages)
+			consignmentRoutes.GET("/:consignmentId/items/:itemId/images/:imageId", consignmentHandler.GetItemImageFile)
+			consignmentRoutes.GET("/:consignmentId/items/:itemId/images/:imageId/thumbnail", consignmentHandler.GetItemImageThumbnail)
 		}
 
 		// Маршруты для отчетов
 		reportRoutes := v1.Group("/reports")
-		reportRoutes.Use(middleware.AuthMiddleware(cfg))
+		reportRoutes.Use(authMiddleware)
 		{
 			reportRoutes.GET("/lot-max-price-diff", reportHandler.GetLotWithMaxPriceDifference)
 			reportRoutes.GET("/auction-most-sold", reportHandler.GetAuctionWithMostSoldLots)
@@ -120,18 +257,83 @@ func main() {
 			reportRoutes.GET("/items-for-sale", reportHandler.GetItemsForSaleByDateAndAuction)
 			reportRoutes.GET("/buyers-by-specificity", reportHandler.GetBuyersOfItemsWithSpecificity)
 			reportRoutes.GET("/sellers-sales-by-specificity", reportHandler.GetSellersReportBySpecificity)
+			reportRoutes.GET("/estimate-performance", reportHandler.GetEstimatePerformance)
 		}
 
 		// Маршруты для управления пользователями (Админ)
 		adminUserRoutes := v1.Group("/admin/users")
-		adminUserRoutes.Use(middleware.AuthMiddleware(cfg))
+		adminUserRoutes.Use(authMiddleware)
 		{
 			adminUserRoutes.GET("", adminHandler.GetAllUsers)
 			adminUserRoutes.PATCH("/:userId/status", adminHandler.UpdateUserStatus)
 			adminUserRoutes.PUT("/:userId/roles", adminHandler.UpdateUserRoles)
+			adminUserRoutes.POST("/:userId/sessions/revoke", adminHandler.RevokeUserSessions)
+		}
+
+		// Журнал аудита действий администраторов и организаторов (Админ)
+		adminAuditRoutes := v1.Group("/admin/audit")
+		adminAuditRoutes.Use(authMiddleware)
+		{
+			adminAuditRoutes.GET("", auditHandler.GetAuditLog)
+			adminAuditRoutes.GET("/export", auditHandler.ExportAuditLog)
+		}
+
+		// Маршруты для управления схемами атрибутов лотов (Админ)
+		adminAttributeSchemaRoutes := v1.Group("/admin/attribute-schemas")
+		adminAttributeSchemaRoutes.Use(authMiddleware)
+		{
+			adminAttributeSchemaRoutes.POST("", attributeSchemaHandler.CreateAttributeSchema)
+			adminAttributeSchemaRoutes.PUT("/:schemaId", attributeSchemaHandler.UpdateAttributeSchema)
+			adminAttributeSchemaRoutes.DELETE("/:schemaId", attributeSchemaHandler.DeleteAttributeSchema)
+		}
+
+		// Маршруты для управления рубрикатором (Админ)
+		adminCategoryRoutes := v1.Group("/admin/categories")
+		adminCategoryRoutes.Use(authMiddleware)
+		{
+			adminCategoryRoutes.POST("", categoryHandler.CreateCategory)
+			adminCategoryRoutes.PUT("/:categoryId", categoryHandler.UpdateCategory)
+			adminCategoryRoutes.DELETE("/:categoryId", categoryHandler.DeleteCategory)
+		}
+
+		// Маршруты для рассмотрения заявок на комиссию и назначения предметов на аукционы (Админ)
+		adminConsignmentRoutes := v1.Group("/admin/consignments")
+		adminConsignmentRoutes.Use(authMiddleware)
+		{
+			adminConsignmentRoutes.PATCH("/:consignmentId/items/:itemId/review", consignmentHandler.ReviewItem)
+			adminConsignmentRoutes.POST("/assign", consignmentHandler.AssignItems)
+		}
+
+		// Маршруты для модерации лотов продавцов (Админ)
+		adminModerationRoutes := v1.Group("/admin/moderation/lots")
+		adminModerationRoutes.Use(authMiddleware)
+		{
+			adminModerationRoutes.GET("", lotHandler.GetModerationQueue)
+			adminModerationRoutes.POST("/:lotId/approve", lotHandler.ApproveLot)
+			adminModerationRoutes.POST("/:lotId/reject", lotHandler.RejectLot)
+		}
+
+		// Маршруты для просмотра черных списков продавцов (Админ)
+		adminBlocklistRoutes := v1.Group("/admin/blocklists")
+		adminBlocklistRoutes.Use(authMiddleware)
+		{
+			adminBlocklistRoutes.GET("", blocklistHandler.GetAllBlocklists)
+		}
+
+		// Маршруты для кредитных лимитов участников аукциона (Админ)
+		adminCreditLimitRoutes := v1.Group("/admin/auctions/:auctionId/credit-limits")
+		adminCreditLimitRoutes.Use(authMiddleware)
+		{
+			adminCreditLimitRoutes.GET("", creditLimitHandler.GetCreditLimits)
+			adminCreditLimitRoutes.PUT("/:userId", creditLimitHandler.SetCreditLimit)
+			adminCreditLimitRoutes.DELETE("/:userId", creditLimitHandler.DeleteCreditLimit)
 		}
 	}
 
+	savedSearchService.StartAlertJob(time.Duration(cfg.SavedSearchIntervalMinutes) * time.Minute)
+	webhookService.StartDeliveryJob(10 * time.Second)
+	outboxRelay.Start(2 * time.Second)
+
 	serverAddr := ":" + cfg.ServerPort
 	log.Printf("Сервер запускается на http://localhost%s", serverAddr)
 	if err := router.Run(serverAddr); err != nil {
@@ -5,19 +5,43 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DBHost       string
-	DBPort       int
-	DBUser       string
-	DBPassword   string
-	DBName       string
-	ServerPort   string
-	JWTSecret    string
-	JWTExpiresIn int // в часах
+	DBHost     string
+	DBPort     int
+	DBUser     string
+	DBPassword string
+	DBName     string
+	ServerPort string
+	JWTSecret  string
+
+	AccessTokenTTLMinutes int // срок действия access-токена в минутах
+	RefreshTokenTTLHours  int // срок действия refresh-токена в часах; при каждом обновлении выдается новый
+
+	UploadDir       string // каталог локального хранилища файлов (фотографии лотов)
+	MaxUploadSizeMB int    // максимальный размер одного загружаемого файла в мегабайтах
+	// MaxImageMegapixels - предельное число пикселей (ширина × высота, в миллионах) загружаемого изображения.
+	// Сжатый файл небольшого размера может распаковываться в изображение, не помещающееся в память.
+	MaxImageMegapixels int
+
+	BannedWords []string // слова и фразы, с которыми лот не принимается на модерацию
+
+	SavedSearchIntervalMinutes int // как часто фоновая задача проверяет сохраненные поиски
+
+	// SMTP-канал доставки уведомлений; если SMTPHost пуст, уведомления остаются только во входящих
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	// TrustedProxies - адреса или подсети обратных прокси, которым доверяется заголовок X-Forwarded-For
+	// при определении IP клиента (например, для журнала аудита); по умолчанию IP берется из соединения
+	TrustedProxies []string
 }
 
 func LoadConfig() (*Config, error) {
@@ -31,20 +55,64 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("invalid DB_PORT: %w", err)
 	}
 
-	jwtExpiresIn, err := strconv.Atoi(getEnv("JWT_EXPIRES_IN_HOURS", "72"))
+	accessTokenTTL, err := strconv.Atoi(getEnv("ACCESS_TOKEN_TTL_MINUTES", "15"))
+	if err != nil || accessTokenTTL < 1 {
+		return nil, fmt.Errorf("invalid ACCESS_TOKEN_TTL_MINUTES: %s", getEnv("ACCESS_TOKEN_TTL_MINUTES", "15"))
+	}
+
+	refreshTokenTTL, err := strconv.Atoi(getEnv("REFRESH_TOKEN_TTL_HOURS", "720"))
+	if err != nil || refreshTokenTTL < 1 {
+		return nil, fmt.Errorf("invalid REFRESH_TOKEN_TTL_HOURS: %s", getEnv("REFRESH_TOKEN_TTL_HOURS", "720"))
+	}
+
+	maxUploadSizeMB, err := strconv.Atoi(getEnv("MAX_UPLOAD_SIZE_MB", "10"))
 	if err != nil {
-		return nil, fmt.Errorf("invalid JWT_EXPIRES_IN_HOURS: %w", err)
+		return nil, fmt.Errorf("invalid MAX_UPLOAD_SIZE_MB: %w", err)
+	}
+
+	maxImageMegapixels, err := strconv.Atoi(getEnv("MAX_IMAGE_MEGAPIXELS", "50"))
+	if err != nil || maxImageMegapixels < 1 {
+		return nil, fmt.Errorf("invalid MAX_IMAGE_MEGAPIXELS: %s", getEnv("MAX_IMAGE_MEGAPIXELS", "50"))
+	}
+
+	savedSearchInterval, err := strconv.Atoi(getEnv("SAVED_SEARCH_INTERVAL_MINUTES", "15"))
+	if err != nil || savedSearchInterval < 1 {
+		return nil, fmt.Errorf("invalid SAVED_SEARCH_INTERVAL_MINUTES: %s", getEnv("SAVED_SEARCH_INTERVAL_MINUTES", "15"))
+	}
+
+	smtpPort, err := strconv.Atoi(getEnv("SMTP_PORT", "25"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP_PORT: %w", err)
 	}
 
 	cfg := &Config{
-		DBHost:       getEnv("DB_HOST", "localhost"),
-		DBPort:       dbPort,
-		DBUser:       getEnv("DB_USER", "auction_user"),
-		DBPassword:   getEnv("DB_PASSWORD", "your_db_password"),
-		DBName:       getEnv("DB_NAME", "auction_db"),
-		ServerPort:   getEnv("SERVER_PORT", "8080"),
-		JWTSecret:    getEnv("JWT_SECRET", "your-very-secret-key-for-jwt"),
-		JWTExpiresIn: jwtExpiresIn,
+		DBHost:     getEnv("DB_HOST", "localhost"),
+		DBPort:     dbPort,
+		DBUser:     getEnv("DB_USER", "auction_user"),
+		DBPassword: getEnv("DB_PASSWORD", "your_db_password"),
+		DBName:     getEnv("DB_NAME", "auction_db"),
+		ServerPort: getEnv("SERVER_PORT", "8080"),
+		JWTSecret:  getEnv("JWT_SECRET", "your-very-secret-key-for-jwt"),
+
+		AccessTokenTTLMinutes: accessTokenTTL,
+		RefreshTokenTTLHours:  refreshTokenTTL,
+
+		UploadDir:       getEnv("UPLOAD_DIR", "uploads"),
+		MaxUploadSizeMB: maxUploadSizeMB,
+
+		MaxImageMegapixels: maxImageMegapixels,
+
+		BannedWords: parseList(getEnv("BANNED_WORDS", "")),
+
+		SavedSearchIntervalMinutes: savedSearchInterval,
+
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     smtpPort,
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:     getEnv("SMTP_FROM", "Auction <noreply@auction.local>"),
+
+		TrustedProxies: parseList(getEnv("TRUSTED_PROXIES", "")),
 	}
 
 	if cfg.JWTSecret == "your-very-secret-key-for-jwt" {
@@ -63,3 +131,14 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// parseList разбирает список значений, разделенных запятыми, пропуская пустые элементы
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
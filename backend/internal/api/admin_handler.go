@@ -69,7 +69,7 @@ func (h *AdminHandler) UpdateUserStatus(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.UpdateUserStatus(uint(targetUserID), input.IsActive, adminUserID, adminRole)
+	user, err := h.userService.UpdateUserStatus(uint(targetUserID), input.IsActive, adminUserID, adminRole, c.ClientIP())
 	if err != nil {
 		if strings.Contains(err.Error(), "не найден") {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -103,7 +103,7 @@ func (h *AdminHandler) UpdateUserRoles(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.UpdateUserAvailableRoles(uint(targetUserID), input.AvailableBusinessRoles, adminUserID, adminRole)
+	user, err := h.userService.UpdateUserAvailableRoles(uint(targetUserID), input.AvailableBusinessRoles, adminUserID, adminRole, c.ClientIP())
 	if err != nil {
 		if strings.Contains(err.Error(), "не найден") {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -116,3 +116,27 @@ func (h *AdminHandler) UpdateUserRoles(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, user)
 }
+
+// RevokeUserSessions завершает все сессии пользователя (для админа)
+func (h *AdminHandler) RevokeUserSessions(c *gin.Context) {
+	adminUserID, adminRole := currentUser(c)
+
+	targetUserID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID целевого пользователя"})
+		return
+	}
+
+	revoked, err := h.userService.RevokeUserSessions(uint(targetUserID), adminUserID, adminRole, c.ClientIP())
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "недостаточно прав") {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка завершения сессий пользователя: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Сессии пользователя завершены", "revoked": revoked})
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttributeSchemaHandler содержит методы-обработчики для схем атрибутов лотов
+type AttributeSchemaHandler struct {
+	schemaService *services.AttributeSchemaService
+}
+
+// NewAttributeSchemaHandler создает новый экземпляр AttributeSchemaHandler
+func NewAttributeSchemaHandler(ss *services.AttributeSchemaService) *AttributeSchemaHandler {
+	return &AttributeSchemaHandler{schemaService: ss}
+}
+
+// GetAllAttributeSchemas обрабатывает запрос на получение списка схем атрибутов
+func (h *AttributeSchemaHandler) GetAllAttributeSchemas(c *gin.Context) {
+	schemas, err := h.schemaService.GetAllAttributeSchemas()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения схем атрибутов: " + err.Error()})
+		return
+	}
+	if schemas == nil {
+		schemas = []models.AttributeSchema{}
+	}
+	c.JSON(http.StatusOK, schemas)
+}
+
+// GetAttributeSchemaByID обрабатывает запрос на получение одной схемы атрибутов
+func (h *AttributeSchemaHandler) GetAttributeSchemaByID(c *gin.Context) {
+	schemaID, err := strconv.ParseUint(c.Param("schemaId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID схемы атрибутов"})
+		return
+	}
+
+	schema, err := h.schemaService.GetAttributeSchemaByID(uint(schemaID))
+	if err != nil {
+		if strings.Contains(err.Error(), "не найдена") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения схемы атрибутов: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, schema)
+}
+
+// CreateAttributeSchema обрабатывает запрос на создание схемы атрибутов (для админа)
+func (h *AttributeSchemaHandler) CreateAttributeSchema(c *gin.Context) {
+	var input models.CreateAttributeSchemaInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	userRoleVal, _ := c.Get("userRole")
+	currentUserRole := models.UserRole(userRoleVal.(string))
+
+	schema, err := h.schemaService.CreateAttributeSchema(input, currentUserRole)
+	if err != nil {
+		if strings.Contains(err.Error(), "недостаточно прав") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Доступ запрещен"})
+		} else if strings.Contains(err.Error(), "уже существует") {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "атрибут") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка создания схемы атрибутов: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, schema)
+}
+
+// UpdateAttributeSchema обрабатывает запрос на обновление схемы атрибутов (для админа)
+func (h *AttributeSchemaHandler) UpdateAttributeSchema(c *gin.Context) {
+	schemaID, err := strconv.ParseUint(c.Param("schemaId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID схемы атрибутов"})
+		return
+	}
+
+	var input models.UpdateAttributeSchemaInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	userRoleVal, _ := c.Get("userRole")
+	currentUserRole := models.UserRole(userRoleVal.(string))
+
+	schema, err := h.schemaService.UpdateAttributeSchema(uint(schemaID), input, currentUserRole)
+	if err != nil {
+		if strings.Contains(err.Error(), "недостаточно прав") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Доступ запрещен"})
+		} else if strings.Contains(err.Error(), "не найдена") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "уже существует") {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "атрибут") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка обновления схемы атрибутов: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, schema)
+}
+
+// DeleteAttributeSchema обрабатывает запрос на удаление схемы атрибутов (для админа)
+func (h *AttributeSchemaHandler) DeleteAttributeSchema(c *gin.Context) {
+	schemaID, err := strconv.ParseUint(c.Param("schemaId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID схемы атрибутов"})
+		return
+	}
+
+	userRoleVal, _ := c.Get("userRole")
+	currentUserRole := models.UserRole(userRoleVal.(string))
+
+	if err := h.schemaService.DeleteAttributeSchema(uint(schemaID), currentUserRole); err != nil {
+		if strings.Contains(err.Error(), "недостаточно прав") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Доступ запрещен"})
+		} else if strings.Contains(err.Error(), "не найдена") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "не может быть удалена") {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка удаления схемы атрибутов: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Схема атрибутов успешно удалена"})
+}
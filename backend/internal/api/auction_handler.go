@@ -59,7 +59,8 @@ func (h *AuctionHandler) CreateAuction(c *gin.Context) {
 
 	auction, err := h.auctionService.CreateAuction(input, currentUserID)
 	if err != nil {
-		if strings.Contains(err.Error(), "некорректный формат") || strings.Contains(err.Error(), "дата аукциона не может быть в прошлом") {
+		if strings.Contains(err.Error(), "некорректный формат") || strings.Contains(err.Error(), "дата аукциона не может быть в прошлом") ||
+			strings.Contains(err.Error(), "схема атрибутов не найдена") || strings.Contains(err.Error(), "рубрика не найдена") {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка создания аукциона: " + err.Error()})
@@ -96,6 +97,9 @@ func (h *AuctionHandler) GetAllAuctions(c *gin.Context) {
 	if dateFromFilter != "" {
 		filters["dateFrom"] = dateFromFilter
 	}
+	if categoryID := c.Query("categoryId"); categoryID != "" {
+		filters["categoryId"] = categoryID
+	}
 
 	auctions, total, err := h.auctionService.GetAllAuctions(page, pageSize, filters)
 	if err != nil {
@@ -175,7 +179,7 @@ func (h *AuctionHandler) UpdateAuctionStatus(c *gin.Context) {
 	}
 	currentUserRole := models.UserRole(currentUserRoleStr)
 
-	updatedAuction, err := h.auctionService.UpdateAuctionStatus(uint(auctionID), input.Status, currentUserID, currentUserRole)
+	updatedAuction, err := h.auctionService.UpdateAuctionStatus(uint(auctionID), input.Status, currentUserID, currentUserRole, c.ClientIP())
 	if err != nil {
 		if strings.Contains(err.Error(), "не найден") {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -225,8 +229,9 @@ func (h *AuctionHandler) UpdateAuction(c *gin.Context) {
 	if err != nil {
 		if strings.Contains(err.Error(), "не найден") {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		} else if strings.Contains(err.Error(), "недостаточно прав") || strings.Contains(err.Error(), "только запланированные") || strings.Contains(err.Error(), "некорректный формат") {
-			if strings.Contains(err.Error(), "некорректный формат") {
+		} else if strings.Contains(err.Error(), "недостаточно прав") || strings.Contains(err.Error(), "только запланированные") || strings.Contains(err.Error(), "некорректный формат") || strings.Contains(err.Error(), "не поддерживаются") ||
+			strings.Contains(err.Error(), "нельзя сменить схему атрибутов") {
+			if !strings.Contains(err.Error(), "недостаточно прав") && !strings.Contains(err.Error(), "только запланированные") {
 				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			} else {
 				c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
@@ -263,7 +268,7 @@ func (h *AuctionHandler) DeleteAuction(c *gin.Context) {
 	}
 	currentUserRole := models.UserRole(currentUserRoleStr)
 
-	err = h.auctionService.DeleteAuction(uint(auctionID), currentUserID, currentUserRole)
+	err = h.auctionService.DeleteAuction(uint(auctionID), currentUserID, currentUserRole, c.ClientIP())
 	if err != nil {
 		if strings.Contains(err.Error(), "не найден") {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -284,8 +289,17 @@ func (h *AuctionHandler) DeleteAuction(c *gin.Context) {
 // FindAuctionsBySpecificity обрабатывает запрос на поиск аукционов по специфике
 func (h *AuctionHandler) FindAuctionsBySpecificity(c *gin.Context) {
 	query := c.Query("q")
-	if query == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Параметр запроса 'q' (специфика) обязателен"})
+	var categoryID uint64
+	if categoryIDStr := c.Query("categoryId"); categoryIDStr != "" {
+		var err error
+		categoryID, err = strconv.ParseUint(categoryIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID рубрики"})
+			return
+		}
+	}
+	if query == "" && categoryID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Укажите рубрику 'categoryId' или параметр запроса 'q' (специфика)"})
 		return
 	}
 
@@ -300,9 +314,13 @@ func (h *AuctionHandler) FindAuctionsBySpecificity(c *gin.Context) {
 
 	filters := make(map[string]string)
 
-	auctions, total, err := h.auctionService.FindAuctionsBySpecificity(query, page, pageSize, filters)
+	auctions, total, err := h.auctionService.FindAuctionsBySpecificity(query, uint(categoryID), page, pageSize, filters)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка поиска аукционов: " + err.Error()})
+		if strings.Contains(err.Error(), "не найдена") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка поиска аукционов: " + err.Error()})
+		}
 		return
 	}
 
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"auction-app/backend/internal/services"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler содержит методы-обработчики журнала аудита
+type AuditHandler struct {
+	auditService *services.AuditService
+}
+
+// NewAuditHandler создает новый экземпляр AuditHandler
+func NewAuditHandler(as *services.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: as}
+}
+
+// respondAuditError сопоставляет ошибку сервиса журнала аудита с HTTP-статусом
+func respondAuditError(c *gin.Context, err error, fallbackMessage string) {
+	if strings.Contains(err.Error(), "недостаточно прав") {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	} else if strings.Contains(err.Error(), "некорректный фильтр") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallbackMessage + ": " + err.Error()})
+	}
+}
+
+// auditFilters собирает фильтры журнала из параметров запроса:
+// actorId, action, targetType, targetId и интервал from/to (ГГГГ-ММ-ДД или RFC 3339)
+func auditFilters(c *gin.Context) map[string]string {
+	filters := make(map[string]string)
+	for _, name := range []string{"actorId", "action", "targetType", "targetId", "from", "to"} {
+		if value := c.Query(name); value != "" {
+			filters[name] = value
+		}
+	}
+	return filters
+}
+
+// GetAuditLog обрабатывает запрос журнала аудита (для админа), начиная с последних записей
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	_, currentUserRole := currentUser(c)
+	entries, total, err := h.auditService.GetAuditLog(page, pageSize, auditFilters(c), currentUserRole)
+	if err != nil {
+		respondAuditError(c, err, "Ошибка получения журнала аудита")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": entries,
+		"pagination": gin.H{
+			"currentPage": page,
+			"pageSize":    pageSize,
+			"totalItems":  total,
+			"totalPages":  (total + int64(pageSize) - 1) / int64(pageSize),
+		},
+	})
+}
+
+// ExportAuditLog выгружает журнал аудита в CSV с теми же фильтрами, что и GetAuditLog
+func (h *AuditHandler) ExportAuditLog(c *gin.Context) {
+	_, currentUserRole := currentUser(c)
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="audit-%s.csv"`, time.Now().Format("20060102-150405")))
+
+	if err := h.auditService.ExportAuditLogCSV(c.Writer, auditFilters(c), currentUserRole); err != nil {
+		if c.Writer.Written() {
+			// часть выгрузки уже отправлена, сменить статус ответа нельзя
+			c.Error(err)
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		respondAuditError(c, err, "Ошибка выгрузки журнала аудита")
+	}
+}
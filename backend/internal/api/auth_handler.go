@@ -56,22 +56,103 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, userFromService, activeRoleFromService, err := h.authService.LoginUser(models.LoginInput{Email: input.Email, Password: input.Password}, input.Role)
+	tokens, userFromService, activeRoleFromService, err := h.authService.LoginUser(models.LoginInput{Email: input.Email, Password: input.Password}, input.Role, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		if strings.Contains(err.Error(), "не найден") || strings.Contains(err.Error(), "неверный пароль") || strings.Contains(err.Error(), "роль не была выбрана") {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "неактивна") {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Внутренняя ошибка сервера при попытке входа: " + err.Error()})
 		}
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{
-		"token":      token,
-		"user":       userFromService,
-		"activeRole": activeRoleFromService,
+		"token":          tokens.AccessToken,
+		"tokenExpiresAt": tokens.AccessTokenExpiresAt,
+		"refreshToken":   tokens.RefreshToken,
+		"user":           userFromService,
+		"activeRole":     activeRoleFromService,
 	})
 }
 
+// Refresh обменивает refresh-токен на новую пару токенов той же сессии
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var input models.RefreshTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	tokens, activeRole, err := h.authService.RefreshSession(input.RefreshToken)
+	if err != nil {
+		if strings.Contains(err.Error(), "недействительный") || strings.Contains(err.Error(), "отозвана") || strings.Contains(err.Error(), "истек") ||
+			strings.Contains(err.Error(), "неактивна") || strings.Contains(err.Error(), "роль недоступна") {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка обновления сессии: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"token":          tokens.AccessToken,
+		"tokenExpiresAt": tokens.AccessTokenExpiresAt,
+		"refreshToken":   tokens.RefreshToken,
+		"activeRole":     activeRole,
+	})
+}
+
+// SwitchRole меняет активную бизнес-роль текущей сессии и возвращает access-токен с новой ролью
+func (h *AuthHandler) SwitchRole(c *gin.Context) {
+	var input models.SwitchRoleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	currentUserID, _ := currentUser(c)
+	sessionIDVal, _ := c.Get("sessionID")
+	sessionID, _ := sessionIDVal.(uint)
+	token, tokenExpiresAt, activeRole, err := h.authService.SwitchRole(currentUserID, sessionID, input.Role)
+	if err != nil {
+		if strings.Contains(err.Error(), "роль недоступна") || strings.Contains(err.Error(), "не может сменить активную роль") || strings.Contains(err.Error(), "неактивна") {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "отозвана") {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка смены активной роли: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"token":          token,
+		"tokenExpiresAt": tokenExpiresAt,
+		"activeRole":     activeRole,
+	})
+}
+
+// Logout завершает текущую сессию пользователя
+func (h *AuthHandler) Logout(c *gin.Context) {
+	sessionIDVal, _ := c.Get("sessionID")
+	sessionID, _ := sessionIDVal.(uint)
+	if err := h.authService.Logout(sessionID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка выхода из системы: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Сессия завершена"})
+}
+
+// LogoutAll завершает все сессии текущего пользователя на всех устройствах
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	currentUserID, _ := currentUser(c)
+	revoked, err := h.authService.LogoutAll(currentUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка завершения сессий: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Все сессии завершены", "revoked": revoked})
+}
+
 func (h *AuthHandler) Me(c *gin.Context) {
 	userIDVal, existsUserID := c.Get("userID")
 	userRoleVal, existsUserRole := c.Get("userRole")
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BlocklistHandler содержит методы-обработчики для черных списков продавцов
+type BlocklistHandler struct {
+	blocklistService *services.BlocklistService
+}
+
+// NewBlocklistHandler создает новый экземпляр BlocklistHandler
+func NewBlocklistHandler(bls *services.BlocklistService) *BlocklistHandler {
+	return &BlocklistHandler{blocklistService: bls}
+}
+
+// GetMyBlocklist обрабатывает запрос на получение черного списка текущего продавца
+func (h *BlocklistHandler) GetMyBlocklist(c *gin.Context) {
+	userIDVal, existsUserID := c.Get("userID")
+	if !existsUserID {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Пользователь не аутентифицирован (userID отсутствует в контексте)"})
+		return
+	}
+	currentUserID, okUserID := userIDVal.(uint)
+	if !okUserID {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Некорректный формат userID в контексте"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	entries, total, err := h.blocklistService.GetMyBlocklist(currentUserID, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения черного списка: " + err.Error()})
+		return
+	}
+	if entries == nil {
+		entries = []models.BlockedBidder{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       entries,
+		"pagination": gin.H{"currentPage": page, "pageSize": pageSize, "totalItems": total, "totalPages": (total + int64(pageSize) - 1) / int64(pageSize)},
+	})
+}
+
+// BlockBidder обрабатывает запрос на добавление пользователя в черный список
+func (h *BlocklistHandler) BlockBidder(c *gin.Context) {
+	var input models.AddBlockedBidderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	userIDVal, existsUserID := c.Get("userID")
+	userRoleVal, existsUserRole := c.Get("userRole")
+	if !existsUserID || !existsUserRole {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Данные пользователя не найдены в контексте аутентификации"})
+		return
+	}
+	currentUserID, okUserID := userIDVal.(uint)
+	currentUserRoleStr, okUserRole := userRoleVal.(string)
+	if !okUserID || !okUserRole {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Некорректный формат данных пользователя в контексте"})
+		return
+	}
+	currentUserRole := models.UserRole(currentUserRoleStr)
+
+	entry, err := h.blocklistService.BlockBidder(currentUserID, currentUserRole, input)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "недостаточно прав") {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "уже в черном списке") {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "нельзя внести в черный список") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка добавления в черный список: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, entry)
+}
+
+// UnblockBidder обрабатывает запрос на удаление пользователя из черного списка
+func (h *BlocklistHandler) UnblockBidder(c *gin.Context) {
+	blockedUserID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID пользователя в URL"})
+		return
+	}
+
+	userIDVal, existsUserID := c.Get("userID")
+	if !existsUserID {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Пользователь не аутентифицирован (userID отсутствует в контексте)"})
+		return
+	}
+	currentUserID, okUserID := userIDVal.(uint)
+	if !okUserID {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Некорректный формат userID в контексте"})
+		return
+	}
+
+	if err := h.blocklistService.UnblockBidder(currentUserID, uint(blockedUserID)); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка удаления из черного списка: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Пользователь удален из черного списка"})
+}
+
+// GetAllBlocklists обрабатывает запрос на просмотр черных списков всех продавцов (для админа)
+func (h *BlocklistHandler) GetAllBlocklists(c *gin.Context) {
+	userRoleVal, _ := c.Get("userRole")
+	currentUserRole := models.UserRole(userRoleVal.(string))
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	filters := make(map[string]string)
+	if ownerID := c.Query("ownerId"); ownerID != "" {
+		filters["ownerId"] = ownerID
+	}
+	if blockedUserID := c.Query("blockedUserId"); blockedUserID != "" {
+		filters["blockedUserId"] = blockedUserID
+	}
+
+	entries, total, err := h.blocklistService.GetAllBlocklists(page, pageSize, filters, currentUserRole)
+	if err != nil {
+		if strings.Contains(err.Error(), "недостаточно прав") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Доступ запрещен"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения черных списков: " + err.Error()})
+		}
+		return
+	}
+	if entries == nil {
+		entries = []models.BlockedBidder{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       entries,
+		"pagination": gin.H{"currentPage": page, "pageSize": pageSize, "totalItems": total, "totalPages": (total + int64(pageSize) - 1) / int64(pageSize)},
+	})
+}
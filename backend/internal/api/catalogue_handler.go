@@ -0,0 +1,45 @@
+package api
+
+import (
+	"auction-app/backend/internal/services"
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CatalogueHandler содержит методы-обработчики для печатного каталога аукциона
+type CatalogueHandler struct {
+	catalogueService *services.CatalogueService
+}
+
+// NewCatalogueHandler создает новый экземпляр CatalogueHandler
+func NewCatalogueHandler(cs *services.CatalogueService) *CatalogueHandler {
+	return &CatalogueHandler{catalogueService: cs}
+}
+
+// GetAuctionCatalogue обрабатывает запрос на выгрузку каталога аукциона в PDF
+func (h *CatalogueHandler) GetAuctionCatalogue(c *gin.Context) {
+	auctionID, err := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона"})
+		return
+	}
+
+	// Формируем документ целиком в памяти, чтобы при ошибке вернуть JSON, а не оборванный PDF
+	var buf bytes.Buffer
+	if err := h.catalogueService.GenerateAuctionCatalogue(uint(auctionID), &buf); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка формирования каталога: " + err.Error()})
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="auction-%d-catalogue.pdf"`, auctionID))
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
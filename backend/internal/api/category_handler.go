@@ -0,0 +1,144 @@
+package api
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CategoryHandler содержит методы-обработчики для рубрикатора
+type CategoryHandler struct {
+	categoryService *services.CategoryService
+}
+
+// NewCategoryHandler создает новый экземпляр CategoryHandler
+func NewCategoryHandler(cs *services.CategoryService) *CategoryHandler {
+	return &CategoryHandler{categoryService: cs}
+}
+
+// GetCategoryTree обрабатывает запрос на получение дерева рубрик
+func (h *CategoryHandler) GetCategoryTree(c *gin.Context) {
+	tree, err := h.categoryService.GetCategoryTree()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения рубрик: " + err.Error()})
+		return
+	}
+	if tree == nil {
+		tree = []models.Category{}
+	}
+	c.JSON(http.StatusOK, tree)
+}
+
+// GetCategoryByID обрабатывает запрос на получение рубрики с подрубриками
+func (h *CategoryHandler) GetCategoryByID(c *gin.Context) {
+	categoryID, err := strconv.ParseUint(c.Param("categoryId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID рубрики"})
+		return
+	}
+
+	category, err := h.categoryService.GetCategoryByID(uint(categoryID))
+	if err != nil {
+		if strings.Contains(err.Error(), "не найдена") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения рубрики: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, category)
+}
+
+// CreateCategory обрабатывает запрос на создание рубрики (для админа)
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var input models.CreateCategoryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	userRoleVal, _ := c.Get("userRole")
+	currentUserRole := models.UserRole(userRoleVal.(string))
+
+	category, err := h.categoryService.CreateCategory(input, currentUserRole)
+	if err != nil {
+		if strings.Contains(err.Error(), "недостаточно прав") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Доступ запрещен"})
+		} else if strings.Contains(err.Error(), "уже существует") {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "не найдена") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка создания рубрики: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory обрабатывает запрос на обновление или перенос рубрики (для админа)
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	categoryID, err := strconv.ParseUint(c.Param("categoryId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID рубрики"})
+		return
+	}
+
+	var input models.UpdateCategoryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	userRoleVal, _ := c.Get("userRole")
+	currentUserRole := models.UserRole(userRoleVal.(string))
+
+	category, err := h.categoryService.UpdateCategory(uint(categoryID), input, currentUserRole)
+	if err != nil {
+		if strings.Contains(err.Error(), "недостаточно прав") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Доступ запрещен"})
+		} else if strings.HasPrefix(err.Error(), "рубрика не найдена") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "уже существует") {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "не найдена") ||
+			strings.Contains(err.Error(), "нельзя перенести") ||
+			strings.Contains(err.Error(), "название рубрики") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка обновления рубрики: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory обрабатывает запрос на удаление рубрики (для админа)
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	categoryID, err := strconv.ParseUint(c.Param("categoryId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID рубрики"})
+		return
+	}
+
+	userRoleVal, _ := c.Get("userRole")
+	currentUserRole := models.UserRole(userRoleVal.(string))
+
+	if err := h.categoryService.DeleteCategory(uint(categoryID), currentUserRole); err != nil {
+		if strings.Contains(err.Error(), "недостаточно прав") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Доступ запрещен"})
+		} else if strings.Contains(err.Error(), "не найдена") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "нельзя удалить") {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка удаления рубрики: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Рубрика успешно удалена"})
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConsignmentHandler содержит методы-обработчики для заявок на комиссию
+type ConsignmentHandler struct {
+	consignmentService *services.ConsignmentService
+}
+
+// NewConsignmentHandler создает новый экземпляр ConsignmentHandler
+func NewConsignmentHandler(cs *services.ConsignmentService) *ConsignmentHandler {
+	return &ConsignmentHandler{consignmentService: cs}
+}
+
+// respondConsignmentError сопоставляет ошибку сервиса заявок с HTTP-статусом
+func respondConsignmentError(c *gin.Context, err error, fallbackMessage string) {
+	if strings.Contains(err.Error(), "не найден") {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	} else if strings.Contains(err.Error(), "недостаточно прав") {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	} else if strings.Contains(err.Error(), "недопустимый тип файла") ||
+		strings.Contains(err.Error(), "слишком большой") ||
+		strings.Contains(err.Error(), "не является корректным изображением") ||
+		strings.Contains(err.Error(), "превышено количество фотографий") ||
+		strings.Contains(err.Error(), "не передано ни одной фотографии") ||
+		strings.Contains(err.Error(), "можно только") ||
+		strings.Contains(err.Error(), "лоты можно добавлять только") ||
+		strings.Contains(err.Error(), "нельзя") ||
+		strings.Contains(err.Error(), "укажите") ||
+		strings.Contains(err.Error(), "некорректный список предметов") ||
+		strings.Contains(err.Error(), "предмет заявки уже назначен") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallbackMessage + ": " + err.Error()})
+	}
+}
+
+// CreateConsignment обрабатывает подачу заявки на комиссию
+func (h *ConsignmentHandler) CreateConsignment(c *gin.Context) {
+	var input models.CreateConsignmentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные данные заявки: " + err.Error()})
+		return
+	}
+
+	currentUserID, currentUserRole := currentUser(c)
+	consignment, err := h.consignmentService.CreateConsignment(input, currentUserID, currentUserRole)
+	if err != nil {
+		respondConsignmentError(c, err, "Ошибка подачи заявки")
+		return
+	}
+	c.JSON(http.StatusCreated, consignment)
+}
+
+// GetConsignments обрабатывает запрос списка заявок (продавец видит свои, администратор - все)
+func (h *ConsignmentHandler) GetConsignments(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	filters := make(map[string]string)
+	if status := c.Query("status"); status != "" {
+		filters["status"] = status
+	}
+	if sellerID := c.Query("sellerId"); sellerID != "" {
+		filters["sellerId"] = sellerID
+	}
+
+	currentUserID, currentUserRole := currentUser(c)
+	consignments, total, err := h.consignmentService.GetConsignments(page, pageSize, filters, currentUserID, currentUserRole)
+	if err != nil {
+		respondConsignmentError(c, err, "Ошибка получения заявок")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": consignments,
+		"pagination": gin.H{
+			"currentPage": page,
+			"pageSize":    pageSize,
+			"totalItems":  total,
+			"totalPages":  (total + int64(pageSize) - 1) / int64(pageSize),
+		},
+	})
+}
+
+// GetConsignmentByID обрабатывает запрос заявки по ID
+func (h *ConsignmentHandler) GetConsignmentByID(c *gin.Context) {
+	consignmentID, err := strconv.ParseUint(c.Param("consignmentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID заявки"})
+		return
+	}
+
+	currentUserID, currentUserRole := currentUser(c)
+	consignment, err := h.consignmentService.GetConsignmentByID(uint(consignmentID), currentUserID, currentUserRole)
+	if err != nil {
+		respondConsignmentError(c, err, "Ошибка получения заявки")
+		return
+	}
+	c.JSON(http.StatusOK, consignment)
+}
+
+// UploadItemImages обрабатывает загрузку фотографий предмета заявки (multipart/form-data, поле "images")
+func (h *ConsignmentHandler) UploadItemImages(c *gin.Context) {
+	consignmentID, errConsignment := strconv.ParseUint(c.Param("consignmentId"), 10, 32)
+	itemID, errItem := strconv.ParseUint(c.Param("itemId"), 10, 32)
+	if errConsignment != nil || errItem != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID заявки или предмета"})
+		return
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ожидается multipart/form-data с файлами в поле images: " + err.Error()})
+		return
+	}
+
+	currentUserID, currentUserRole := currentUser(c)
+	images, err := h.consignmentService.UploadItemImages(uint(consignmentID), uint(itemID), form.File["images"], currentUserID, currentUserRole)
+	if err != nil {
+		respondConsignmentError(c, err, "Ошибка загрузки фотографий")
+		return
+	}
+	c.JSON(http.StatusCreated, images)
+}
+
+// GetItemImageFile отдает файл фотографии предмета заявки
+func (h *ConsignmentHandler) GetItemImageFile(c *gin.Context) {
+	h.serveItemImage(c, false)
+}
+
+// GetItemImageThumbnail отдает миниатюру фотографии предмета заявки
+func (h *ConsignmentHandler) GetItemImageThumbnail(c *gin.Context) {
+	h.serveItemImage(c, true)
+}
+
+func (h *ConsignmentHandler) serveItemImage(c *gin.Context, thumbnail bool) {
+	consignmentID, errConsignment := strconv.ParseUint(c.Param("consignmentId"), 10, 32)
+	itemID, errItem := strconv.ParseUint(c.Param("itemId"), 10, 32)
+	imageID, errImage := strconv.ParseUint(c.Param("imageId"), 10, 32)
+	if errConsignment != nil || errItem != nil || errImage != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID заявки, предмета или фотографии"})
+		return
+	}
+
+	currentUserID, currentUserRole := currentUser(c)
+	reader, contentType, err := h.consignmentService.OpenItemImage(uint(consignmentID), uint(itemID), uint(imageID), thumbnail, currentUserID, currentUserRole)
+	if err != nil {
+		respondConsignmentError(c, err, "Ошибка получения фотографии")
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, -1, contentType, reader, map[string]string{"Cache-Control": "private, max-age=86400"})
+}
+
+// ReviewItem обрабатывает решение сотрудника по предмету заявки
+func (h *ConsignmentHandler) ReviewItem(c *gin.Context) {
+	consignmentID, errConsignment := strconv.ParseUint(c.Param("consignmentId"), 10, 32)
+	itemID, errItem := strconv.ParseUint(c.Param("itemId"), 10, 32)
+	if errConsignment != nil || errItem != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID заявки или предмета"})
+		return
+	}
+
+	var input models.ReviewConsignmentItemInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	currentUserID, currentUserRole := currentUser(c)
+	item, err := h.consignmentService.ReviewItem(uint(consignmentID), uint(itemID), input, currentUserID, currentUserRole)
+	if err != nil {
+		respondConsignmentError(c, err, "Ошибка рассмотрения предмета")
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+// AssignItems обрабатывает назначение принятых предметов на запланированный аукцион
+func (h *ConsignmentHandler) AssignItems(c *gin.Context) {
+	var input models.AssignConsignmentItemsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	currentUserID, currentUserRole := currentUser(c)
+	lots, err := h.consignmentService.AssignItems(input, currentUserID, currentUserRole)
+	if err != nil {
+		respondConsignmentError(c, err, "Ошибка назначения предметов на аукцион")
+		return
+	}
+	c.JSON(http.StatusCreated, lots)
+}
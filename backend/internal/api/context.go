@@ -0,0 +1,16 @@
+package api
+
+import (
+	"auction-app/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// currentUser извлекает ID и роль пользователя из контекста аутентификации
+func currentUser(c *gin.Context) (uint, models.UserRole) {
+	userIDVal, _ := c.Get("userID")
+	userRoleVal, _ := c.Get("userRole")
+	currentUserID, _ := userIDVal.(uint)
+	currentUserRoleStr, _ := userRoleVal.(string)
+	return currentUserID, models.UserRole(currentUserRoleStr)
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreditLimitHandler содержит методы-обработчики для кредитных лимитов участников (для админа)
+type CreditLimitHandler struct {
+	creditLimitService *services.CreditLimitService
+}
+
+// NewCreditLimitHandler создает новый экземпляр CreditLimitHandler
+func NewCreditLimitHandler(cls *services.CreditLimitService) *CreditLimitHandler {
+	return &CreditLimitHandler{creditLimitService: cls}
+}
+
+// GetCreditLimits обрабатывает запрос на получение лимитов участников аукциона и их использования
+func (h *CreditLimitHandler) GetCreditLimits(c *gin.Context) {
+	auctionID, err := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона в URL"})
+		return
+	}
+
+	userRoleVal, _ := c.Get("userRole")
+	currentUserRole := models.UserRole(userRoleVal.(string))
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	limits, total, err := h.creditLimitService.GetCreditLimitsByAuction(uint(auctionID), page, pageSize, currentUserRole)
+	if err != nil {
+		if strings.Contains(err.Error(), "недостаточно прав") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Доступ запрещен"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения кредитных лимитов: " + err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       limits,
+		"pagination": gin.H{"currentPage": page, "pageSize": pageSize, "totalItems": total, "totalPages": (total + int64(pageSize) - 1) / int64(pageSize)},
+	})
+}
+
+// SetCreditLimit обрабатывает запрос на установку кредитного лимита участника на аукционе
+func (h *CreditLimitHandler) SetCreditLimit(c *gin.Context) {
+	adminUserIDVal, _ := c.Get("userID")
+	adminRoleVal, _ := c.Get("userRole")
+	adminUserID := adminUserIDVal.(uint)
+	adminRole := models.UserRole(adminRoleVal.(string))
+
+	auctionID, errAuction := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	targetUserID, errUser := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if errAuction != nil || errUser != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона или пользователя в URL"})
+		return
+	}
+
+	var input models.SetCreditLimitInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные для лимита: " + err.Error()})
+		return
+	}
+
+	usage, err := h.creditLimitService.SetCreditLimit(uint(auctionID), uint(targetUserID), input, adminUserID, adminRole)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "недостаточно прав") {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "завершенного аукциона") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка установки кредитного лимита: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, usage)
+}
+
+// DeleteCreditLimit обрабатывает запрос на снятие кредитного лимита участника
+func (h *CreditLimitHandler) DeleteCreditLimit(c *gin.Context) {
+	adminRoleVal, _ := c.Get("userRole")
+	adminRole := models.UserRole(adminRoleVal.(string))
+
+	auctionID, errAuction := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	targetUserID, errUser := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if errAuction != nil || errUser != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона или пользователя в URL"})
+		return
+	}
+
+	if err := h.creditLimitService.DeleteCreditLimit(uint(auctionID), uint(targetUserID), adminRole); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "недостаточно прав") {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка удаления кредитного лимита: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Кредитный лимит удален"})
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"auction-app/backend/internal/services"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// eventStreamHeartbeat - как часто в поток отправляется служебное событие, чтобы прокси не закрывали соединение
+const eventStreamHeartbeat = 25 * time.Second
+
+// EventStreamHandler отдает события торгов в реальном времени через Server-Sent Events
+type EventStreamHandler struct {
+	hub            *services.EventHub
+	auctionService *services.AuctionService
+	lotService     *services.LotService
+}
+
+// NewEventStreamHandler создает новый экземпляр EventStreamHandler
+func NewEventStreamHandler(hub *services.EventHub, as *services.AuctionService, ls *services.LotService) *EventStreamHandler {
+	return &EventStreamHandler{hub: hub, auctionService: as, lotService: ls}
+}
+
+// StreamAuctionEvents обрабатывает подписку на события всех лотов аукциона
+func (h *EventStreamHandler) StreamAuctionEvents(c *gin.Context) {
+	auctionID, err := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона в URL"})
+		return
+	}
+	if _, err := h.auctionService.GetAuctionByID(uint(auctionID)); err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Аукцион не найден"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения аукциона: " + err.Error()})
+		}
+		return
+	}
+	h.stream(c, uint(auctionID), 0)
+}
+
+// StreamLotEvents обрабатывает подписку на события одного лота (и смену статуса его аукциона)
+func (h *EventStreamHandler) StreamLotEvents(c *gin.Context) {
+	auctionID, errAuction := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	lotID, errLot := strconv.ParseUint(c.Param("lotId"), 10, 32)
+	if errAuction != nil || errLot != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона или лота в URL"})
+		return
+	}
+	lot, err := h.lotService.GetLotByID(uint(lotID), 0, "")
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Лот не найден"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения лота: " + err.Error()})
+		}
+		return
+	}
+	if lot.AuctionID != uint(auctionID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Лот не принадлежит указанному аукциону"})
+		return
+	}
+	h.stream(c, uint(auctionID), uint(lotID))
+}
+
+// stream держит соединение открытым и пересылает клиенту события подписки, пока клиент не отключится
+func (h *EventStreamHandler) stream(c *gin.Context, auctionID, lotID uint) {
+	subscription := h.hub.Subscribe(auctionID, lotID)
+	defer h.hub.Unsubscribe(subscription)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	// заголовки отправляются сразу, иначе клиент ждет ответа до первого события
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(string(event.Type), event)
+			return true
+		case now := <-heartbeat.C:
+			c.SSEvent("heartbeat", gin.H{"time": now})
+			return true
+		}
+	})
+}
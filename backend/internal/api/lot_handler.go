@@ -4,6 +4,7 @@ package api
 import (
 	"auction-app/backend/internal/models"
 	"auction-app/backend/internal/services"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -11,6 +12,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// maxLotImportFileSize - максимальный размер файла для массового импорта лотов
+const maxLotImportFileSize = 5 << 20
+
 // LotHandler содержит методы-обработчики для лотов
 type LotHandler struct {
 	lotService *services.LotService
@@ -57,11 +61,14 @@ func (h *LotHandler) CreateLot(c *gin.Context) {
 	}
 	sellerIDForLot := currentUserID
 
-	lot, err := h.lotService.CreateLot(uint(auctionID), input, sellerIDForLot)
+	lot, err := h.lotService.CreateLot(uint(auctionID), input, sellerIDForLot, currentUserRole)
 	if err != nil {
 		if strings.Contains(err.Error(), "не найден") {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		} else if strings.Contains(err.Error(), "лоты можно добавлять только") || strings.Contains(err.Error(), "стартовая цена") {
+		} else if strings.Contains(err.Error(), "лоты можно добавлять только") || strings.Contains(err.Error(), "стартовая цена") ||
+			strings.Contains(err.Error(), "не поддерживаются") || strings.Contains(err.Error(), "некорректные атрибуты") ||
+			strings.Contains(err.Error(), "рубрика лота") || strings.Contains(err.Error(), "оценк") ||
+			strings.Contains(err.Error(), "запрещенные слова") {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка добавления лота: " + err.Error()})
@@ -71,6 +78,67 @@ func (h *LotHandler) CreateLot(c *gin.Context) {
 	c.JSON(http.StatusCreated, lot)
 }
 
+// ImportLots обрабатывает массовый импорт лотов из файла CSV или XLSX (поле file формы multipart/form-data).
+// При dryRun=true файл только проверяется и возвращаются ошибки по строкам.
+func (h *LotHandler) ImportLots(c *gin.Context) {
+	auctionID, err := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона в URL"})
+		return
+	}
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+
+	userIDVal, _ := c.Get("userID")
+	userRoleVal, _ := c.Get("userRole")
+	currentUserID, _ := userIDVal.(uint)
+	currentUserRoleStr, _ := userRoleVal.(string)
+	currentUserRole := models.UserRole(currentUserRoleStr)
+	if currentUserRole != models.RoleSeller && currentUserRole != models.RoleSystemAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Недостаточно прав для добавления лотов"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ожидается multipart/form-data с файлом в поле file: " + err.Error()})
+		return
+	}
+	if fileHeader.Size > maxLotImportFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Файл импорта слишком большой (максимум 5 МБ)"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Не удалось открыть загруженный файл: " + err.Error()})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Не удалось прочитать загруженный файл: " + err.Error()})
+		return
+	}
+
+	result, err := h.lotService.ImportLots(uint(auctionID), fileHeader.Filename, data, currentUserID, currentUserRole, dryRun)
+	if err != nil {
+		if result != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "result": result})
+		} else if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "ошибка импорта") || strings.Contains(err.Error(), "лоты можно добавлять только") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка импорта лотов: " + err.Error()})
+		}
+		return
+	}
+	if dryRun {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
 // GetLotsByAuctionID обрабатывает запрос на получение списка лотов для конкретного аукциона
 func (h *LotHandler) GetLotsByAuctionID(c *gin.Context) {
 	auctionIDStr := c.Param("auctionId")
@@ -122,7 +190,15 @@ func (h *LotHandler) GetLotByID(c *gin.Context) {
 		return
 	}
 
-	lot, err := h.lotService.GetLotByID(uint(lotID))
+	// Маршрут публичный: пользователь известен, только если запрос пришел с токеном
+	var viewerID uint
+	if userIDVal, exists := c.Get("userID"); exists {
+		viewerID, _ = userIDVal.(uint)
+	}
+	userRoleVal, _ := c.Get("userRole")
+	viewerRoleStr, _ := userRoleVal.(string)
+
+	lot, err := h.lotService.GetLotByID(uint(lotID), viewerID, models.UserRole(viewerRoleStr))
 	if err != nil {
 		if strings.Contains(err.Error(), "не найден") {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Лот не найден"})
@@ -172,6 +248,12 @@ func (h *LotHandler) UpdateLotDetails(c *gin.Context) {
 			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		} else if strings.Contains(err.Error(), "только до начала торгов") ||
 			strings.Contains(err.Error(), "стартовая цена") ||
+			strings.Contains(err.Error(), "количество единиц") ||
+			strings.Contains(err.Error(), "не поддерживаются") ||
+			strings.Contains(err.Error(), "некорректные атрибуты") ||
+			strings.Contains(err.Error(), "рубрика лота") ||
+			strings.Contains(err.Error(), "оценк") ||
+			strings.Contains(err.Error(), "запрещенные слова") ||
 			strings.Contains(err.Error(), "уже есть ставки") ||
 			strings.Contains(err.Error(), "можно только в запланированном аукционе") ||
 			strings.Contains(err.Error(), "ожидает торгов и по нему нет ставок") {
@@ -227,6 +309,77 @@ func (h *LotHandler) DeleteLot(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Лот успешно удален"})
 }
 
+// ReorderLots обрабатывает запрос на изменение порядка лотов аукциона с их перенумерацией
+func (h *LotHandler) ReorderLots(c *gin.Context) {
+	auctionID, err := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона в URL"})
+		return
+	}
+
+	var input models.ReorderLotsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	userIDVal, _ := c.Get("userID")
+	userRoleVal, _ := c.Get("userRole")
+	currentUserID, _ := userIDVal.(uint)
+	currentUserRoleStr, _ := userRoleVal.(string)
+
+	lots, err := h.lotService.ReorderLots(uint(auctionID), input, currentUserID, models.UserRole(currentUserRoleStr))
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "недостаточно прав") {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "некорректный порядок") || strings.Contains(err.Error(), "только в запланированном аукционе") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка изменения порядка лотов: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, lots)
+}
+
+// WithdrawLot обрабатывает запрос на снятие лота с торгов
+func (h *LotHandler) WithdrawLot(c *gin.Context) {
+	auctionID, errAuction := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	lotID, errLot := strconv.ParseUint(c.Param("lotId"), 10, 32)
+	if errAuction != nil || errLot != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона или лота в URL"})
+		return
+	}
+
+	var input models.WithdrawLotInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Укажите причину снятия лота (не короче 5 символов): " + err.Error()})
+		return
+	}
+
+	userIDVal, _ := c.Get("userID")
+	userRoleVal, _ := c.Get("userRole")
+	currentUserID, _ := userIDVal.(uint)
+	currentUserRoleStr, _ := userRoleVal.(string)
+
+	lot, err := h.lotService.WithdrawLot(uint(auctionID), uint(lotID), input, currentUserID, models.UserRole(currentUserRoleStr))
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "недостаточно прав") || strings.Contains(err.Error(), "лот не принадлежит") {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "снять с торгов можно только") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка снятия лота с торгов: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, lot)
+}
+
 // PlaceBid обрабатывает запрос на размещение ставки
 func (h *LotHandler) PlaceBid(c *gin.Context) {
 	auctionIDStr := c.Param("auctionId")
@@ -271,13 +424,22 @@ func (h *LotHandler) PlaceBid(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		} else if strings.Contains(err.Error(), "неактивны") ||
 			strings.Contains(err.Error(), "не принимаются (статус лота)") ||
+			strings.Contains(err.Error(), "не прошел модерацию") ||
 			strings.Contains(err.Error(), "собственный лот") ||
 			strings.Contains(err.Error(), "выше текущей цены") ||
+			strings.Contains(err.Error(), "ниже текущей цены") ||
+			strings.Contains(err.Error(), "не ниже стартовой цены") ||
+			strings.Contains(err.Error(), "ниже вашей предыдущей ставки") ||
+			strings.Contains(err.Error(), "единиц") ||
 			strings.Contains(err.Error(), "уже лидируете") ||
+			strings.Contains(err.Error(), "кредитный лимит") ||
 			strings.Contains(err.Error(), "не удалось проверить правило") {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		} else if strings.Contains(err.Error(), "недостаточно прав") {
+		} else if strings.Contains(err.Error(), "недостаточно прав") ||
+			strings.Contains(err.Error(), "внес вас в черный список") {
 			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "состояние лота изменилось") {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка размещения ставки: " + err.Error()})
 		}
@@ -310,6 +472,18 @@ func (h *LotHandler) GetAllLots(c *gin.Context) {
 	if auctionMonth := c.Query("auctionMonth"); auctionMonth != "" {
 		filters["auctionMonth"] = auctionMonth
 	}
+	if categoryID := c.Query("categoryId"); categoryID != "" {
+		filters["categoryId"] = categoryID
+	}
+	// Фильтры по атрибутам лота: attr.<ключ>=значение, attrMin.<ключ>=число, attrMax.<ключ>=число
+	for param, values := range c.Request.URL.Query() {
+		if len(values) == 0 || values[0] == "" {
+			continue
+		}
+		if strings.HasPrefix(param, "attr.") || strings.HasPrefix(param, "attrMin.") || strings.HasPrefix(param, "attrMax.") {
+			filters[param] = values[0]
+		}
+	}
 
 	lots, total, err := h.lotService.GetAllLots(page, pageSize, filters)
 	if err != nil {
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LotImageHandler содержит методы-обработчики для фотографий лотов
+type LotImageHandler struct {
+	lotImageService *services.LotImageService
+}
+
+// NewLotImageHandler создает новый экземпляр LotImageHandler
+func NewLotImageHandler(lis *services.LotImageService) *LotImageHandler {
+	return &LotImageHandler{lotImageService: lis}
+}
+
+// respondLotImageError сопоставляет ошибку сервиса фотографий с HTTP-статусом
+func respondLotImageError(c *gin.Context, err error, fallbackMessage string) {
+	if strings.Contains(err.Error(), "не найден") {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	} else if strings.Contains(err.Error(), "недостаточно прав") ||
+		strings.Contains(err.Error(), "лот не принадлежит данному аукциону") {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	} else if strings.Contains(err.Error(), "недопустимый тип файла") ||
+		strings.Contains(err.Error(), "слишком большой") ||
+		strings.Contains(err.Error(), "не является корректным изображением") ||
+		strings.Contains(err.Error(), "превышено количество фотографий") ||
+		strings.Contains(err.Error(), "не передано ни одной фотографии") ||
+		strings.Contains(err.Error(), "некорректный порядок фотографий") ||
+		strings.Contains(err.Error(), "изменять фотографии можно только") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallbackMessage + ": " + err.Error()})
+	}
+}
+
+// UploadLotImages обрабатывает загрузку фотографий лота (multipart/form-data, поле "images")
+func (h *LotImageHandler) UploadLotImages(c *gin.Context) {
+	auctionID, errAuction := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	lotID, errLot := strconv.ParseUint(c.Param("lotId"), 10, 32)
+	if errAuction != nil || errLot != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона или лота в URL"})
+		return
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ожидается multipart/form-data с файлами в поле images: " + err.Error()})
+		return
+	}
+
+	userIDVal, _ := c.Get("userID")
+	userRoleVal, _ := c.Get("userRole")
+	currentUserID, _ := userIDVal.(uint)
+	currentUserRoleStr, _ := userRoleVal.(string)
+
+	images, err := h.lotImageService.UploadLotImages(uint(auctionID), uint(lotID), form.File["images"], currentUserID, models.UserRole(currentUserRoleStr))
+	if err != nil {
+		respondLotImageError(c, err, "Ошибка загрузки фотографий")
+		return
+	}
+	c.JSON(http.StatusCreated, images)
+}
+
+// GetLotImages обрабатывает запрос на получение списка фотографий лота
+func (h *LotImageHandler) GetLotImages(c *gin.Context) {
+	lotID, err := strconv.ParseUint(c.Param("lotId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID лота"})
+		return
+	}
+
+	images, err := h.lotImageService.GetLotImages(uint(lotID))
+	if err != nil {
+		respondLotImageError(c, err, "Ошибка получения фотографий")
+		return
+	}
+	if images == nil {
+		images = []models.LotImage{}
+	}
+	c.JSON(http.StatusOK, images)
+}
+
+// GetLotImageFile отдает файл фотографии лота
+func (h *LotImageHandler) GetLotImageFile(c *gin.Context) {
+	h.serveLotImage(c, false)
+}
+
+// GetLotImageThumbnail отдает миниатюру фотографии лота
+func (h *LotImageHandler) GetLotImageThumbnail(c *gin.Context) {
+	h.serveLotImage(c, true)
+}
+
+func (h *LotImageHandler) serveLotImage(c *gin.Context, thumbnail bool) {
+	lotID, errLot := strconv.ParseUint(c.Param("lotId"), 10, 32)
+	imageID, errImage := strconv.ParseUint(c.Param("imageId"), 10, 32)
+	if errLot != nil || errImage != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID лота или фотографии"})
+		return
+	}
+
+	reader, contentType, err := h.lotImageService.OpenLotImage(uint(lotID), uint(imageID), thumbnail)
+	if err != nil {
+		respondLotImageError(c, err, "Ошибка получения фотографии")
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, -1, contentType, reader, map[string]string{"Cache-Control": "public, max-age=86400"})
+}
+
+// ReorderLotImages обрабатывает запрос на изменение порядка фотографий лота
+func (h *LotImageHandler) ReorderLotImages(c *gin.Context) {
+	auctionID, errAuction := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	lotID, errLot := strconv.ParseUint(c.Param("lotId"), 10, 32)
+	if errAuction != nil || errLot != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона или лота в URL"})
+		return
+	}
+
+	var input models.ReorderLotImagesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	userIDVal, _ := c.Get("userID")
+	userRoleVal, _ := c.Get("userRole")
+	currentUserID, _ := userIDVal.(uint)
+	currentUserRoleStr, _ := userRoleVal.(string)
+
+	images, err := h.lotImageService.ReorderLotImages(uint(auctionID), uint(lotID), input, currentUserID, models.UserRole(currentUserRoleStr))
+	if err != nil {
+		respondLotImageError(c, err, "Ошибка изменения порядка фотографий")
+		return
+	}
+	c.JSON(http.StatusOK, images)
+}
+
+// SetPrimaryLotImage обрабатывает запрос на выбор основной фотографии лота
+func (h *LotImageHandler) SetPrimaryLotImage(c *gin.Context) {
+	auctionID, errAuction := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	lotID, errLot := strconv.ParseUint(c.Param("lotId"), 10, 32)
+	imageID, errImage := strconv.ParseUint(c.Param("imageId"), 10, 32)
+	if errAuction != nil || errLot != nil || errImage != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона, лота или фотографии в URL"})
+		return
+	}
+
+	userIDVal, _ := c.Get("userID")
+	userRoleVal, _ := c.Get("userRole")
+	currentUserID, _ := userIDVal.(uint)
+	currentUserRoleStr, _ := userRoleVal.(string)
+
+	images, err := h.lotImageService.SetPrimaryLotImage(uint(auctionID), uint(lotID), uint(imageID), currentUserID, models.UserRole(currentUserRoleStr))
+	if err != nil {
+		respondLotImageError(c, err, "Ошибка назначения основной фотографии")
+		return
+	}
+	c.JSON(http.StatusOK, images)
+}
+
+// DeleteLotImage обрабатывает запрос на удаление фотографии лота
+func (h *LotImageHandler) DeleteLotImage(c *gin.Context) {
+	auctionID, errAuction := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	lotID, errLot := strconv.ParseUint(c.Param("lotId"), 10, 32)
+	imageID, errImage := strconv.ParseUint(c.Param("imageId"), 10, 32)
+	if errAuction != nil || errLot != nil || errImage != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона, лота или фотографии в URL"})
+		return
+	}
+
+	userIDVal, _ := c.Get("userID")
+	userRoleVal, _ := c.Get("userRole")
+	currentUserID, _ := userIDVal.(uint)
+	currentUserRoleStr, _ := userRoleVal.(string)
+
+	if err := h.lotImageService.DeleteLotImage(uint(auctionID), uint(lotID), uint(imageID), currentUserID, models.UserRole(currentUserRoleStr)); err != nil {
+		respondLotImageError(c, err, "Ошибка удаления фотографии")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Фотография успешно удалена"})
+}
@@ -0,0 +1,113 @@
+// backend/internal/api/lot_moderation_handler.go
+package api
+
+import (
+	"auction-app/backend/internal/models"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondModerationError сопоставляет ошибку модерации с HTTP-статусом
+func respondModerationError(c *gin.Context, err error, fallbackMessage string) {
+	if strings.Contains(err.Error(), "не найден") {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	} else if strings.Contains(err.Error(), "недостаточно прав") {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	} else if strings.Contains(err.Error(), "не ожидает модерации") {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	} else if strings.Contains(err.Error(), "некорректный статус модерации") || strings.Contains(err.Error(), "укажите") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallbackMessage + ": " + err.Error()})
+	}
+}
+
+// GetModerationQueue обрабатывает запрос очереди модерации лотов (по умолчанию - ожидающие проверки)
+func (h *LotHandler) GetModerationQueue(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	filters := make(map[string]string)
+	if status := c.Query("status"); status != "" {
+		filters["moderationStatus"] = status
+	}
+	if auctionID := c.Query("auctionId"); auctionID != "" {
+		if _, err := strconv.ParseUint(auctionID, 10, 32); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона"})
+			return
+		}
+		filters["auctionId"] = auctionID
+	}
+	if sellerID := c.Query("sellerId"); sellerID != "" {
+		if _, err := strconv.ParseUint(sellerID, 10, 32); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID продавца"})
+			return
+		}
+		filters["sellerId"] = sellerID
+	}
+
+	_, currentUserRole := currentUser(c)
+	lots, total, err := h.lotService.GetModerationQueue(page, pageSize, filters, currentUserRole)
+	if err != nil {
+		respondModerationError(c, err, "Ошибка получения очереди модерации")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": lots,
+		"pagination": gin.H{
+			"currentPage": page,
+			"pageSize":    pageSize,
+			"totalItems":  total,
+			"totalPages":  (total + int64(pageSize) - 1) / int64(pageSize),
+		},
+	})
+}
+
+// ApproveLot обрабатывает одобрение лота модератором
+func (h *LotHandler) ApproveLot(c *gin.Context) {
+	lotID, err := strconv.ParseUint(c.Param("lotId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID лота в URL"})
+		return
+	}
+
+	currentUserID, currentUserRole := currentUser(c)
+	lot, err := h.lotService.ApproveLot(uint(lotID), currentUserID, currentUserRole)
+	if err != nil {
+		respondModerationError(c, err, "Ошибка одобрения лота")
+		return
+	}
+	c.JSON(http.StatusOK, lot)
+}
+
+// RejectLot обрабатывает отклонение лота модератором с кодом причины
+func (h *LotHandler) RejectLot(c *gin.Context) {
+	lotID, err := strconv.ParseUint(c.Param("lotId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID лота в URL"})
+		return
+	}
+
+	var input models.RejectLotInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Укажите код причины отклонения (prohibited_item, inaccurate_details, poor_images, pricing, duplicate, banned_content, other): " + err.Error()})
+		return
+	}
+
+	currentUserID, currentUserRole := currentUser(c)
+	lot, err := h.lotService.RejectLot(uint(lotID), input, currentUserID, currentUserRole)
+	if err != nil {
+		respondModerationError(c, err, "Ошибка отклонения лота")
+		return
+	}
+	c.JSON(http.StatusOK, lot)
+}
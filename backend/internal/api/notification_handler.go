@@ -0,0 +1,82 @@
+package api
+
+import (
+	"auction-app/backend/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler содержит методы-обработчики для входящих уведомлений текущего пользователя
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+// NewNotificationHandler создает новый экземпляр NotificationHandler
+func NewNotificationHandler(ns *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: ns}
+}
+
+// GetMyNotifications обрабатывает запрос входящих уведомлений (?unread=true - только непрочитанные)
+func (h *NotificationHandler) GetMyNotifications(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 50 {
+		pageSize = 10
+	}
+	unreadOnly := c.Query("unread") == "true"
+
+	currentUserID, _ := currentUser(c)
+	notifications, total, unread, err := h.notificationService.GetMyNotifications(currentUserID, page, pageSize, unreadOnly)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения уведомлений: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data":        notifications,
+		"unreadCount": unread,
+		"pagination": gin.H{
+			"currentPage": page,
+			"pageSize":    pageSize,
+			"totalItems":  total,
+			"totalPages":  (total + int64(pageSize) - 1) / int64(pageSize),
+		},
+	})
+}
+
+// MarkRead обрабатывает отметку уведомления прочитанным
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	notificationID, err := strconv.ParseUint(c.Param("notificationId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID уведомления в URL"})
+		return
+	}
+
+	currentUserID, _ := currentUser(c)
+	notification, err := h.notificationService.MarkRead(currentUserID, uint(notificationID))
+	if err != nil {
+		if strings.Contains(err.Error(), "не найдено") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка отметки уведомления: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, notification)
+}
+
+// MarkAllRead обрабатывает отметку прочитанными всех уведомлений пользователя
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	currentUserID, _ := currentUser(c)
+	count, err := h.notificationService.MarkAllRead(currentUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка отметки уведомлений: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Уведомления отмечены прочитанными", "updated": count})
+}
@@ -143,8 +143,8 @@ func (h *ReportHandler) GetItemsForSaleByDateAndAuction(c *gin.Context) {
 // GetBuyersOfItemsWithSpecificity обрабатывает "Покупатели, купившие предметы заданной специфики."
 func (h *ReportHandler) GetBuyersOfItemsWithSpecificity(c *gin.Context) {
 	specificity := c.Query("specificity")
-	if specificity == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Параметр 'specificity' обязателен"})
+	categoryID, ok := parseReportCategoryID(c, specificity)
+	if !ok {
 		return
 	}
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -156,9 +156,13 @@ func (h *ReportHandler) GetBuyersOfItemsWithSpecificity(c *gin.Context) {
 		pageSize = 10
 	}
 
-	buyers, total, err := h.reportService.GetBuyersOfItemsWithSpecificity(specificity, page, pageSize)
+	buyers, total, err := h.reportService.GetBuyersOfItemsWithSpecificity(specificity, categoryID, page, pageSize)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения данных: " + err.Error()})
+		if strings.Contains(err.Error(), "рубрика не найдена") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения данных: " + err.Error()})
+		}
 		return
 	}
 	if len(buyers) == 0 {
@@ -189,7 +193,7 @@ func (h *ReportHandler) GetSellersByItemCategory(c *gin.Context) {
 		pageSize = 10
 	}
 
-	sellersReport, total, err := h.reportService.GetSellersWithSalesByAuctionSpecificity(category, minSales, page, pageSize)
+	sellersReport, total, err := h.reportService.GetSellersWithSalesByAuctionSpecificity(category, 0, minSales, page, pageSize)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения отчета по продавцам: " + err.Error()})
 		return
@@ -207,8 +211,8 @@ func (h *ReportHandler) GetSellersReportBySpecificity(c *gin.Context) {
 	specificity := c.Query("specificity")
 	minSalesStr := c.DefaultQuery("minSales", "0")
 
-	if specificity == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Параметр 'specificity' (специфика аукциона) обязателен"})
+	categoryID, ok := parseReportCategoryID(c, specificity)
+	if !ok {
 		return
 	}
 	minSales, errMinSales := strconv.ParseFloat(minSalesStr, 64)
@@ -226,9 +230,13 @@ func (h *ReportHandler) GetSellersReportBySpecificity(c *gin.Context) {
 		pageSize = 10
 	}
 
-	sellersReport, total, err := h.reportService.GetSellersWithSalesByAuctionSpecificity(specificity, minSales, page, pageSize)
+	sellersReport, total, err := h.reportService.GetSellersWithSalesByAuctionSpecificity(specificity, categoryID, minSales, page, pageSize)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения отчета по продавцам: " + err.Error()})
+		if strings.Contains(err.Error(), "рубрика не найдена") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения отчета по продавцам: " + err.Error()})
+		}
 		return
 	}
 	if len(sellersReport) == 0 && total == 0 {
@@ -240,3 +248,52 @@ func (h *ReportHandler) GetSellersReportBySpecificity(c *gin.Context) {
 		"pagination": gin.H{"currentPage": page, "pageSize": pageSize, "totalItems": total, "totalPages": (total + int64(pageSize) - 1) / int64(pageSize)},
 	})
 }
+
+// parseReportCategoryID читает параметр categoryId. Без него отчет строится по устаревшему
+// текстовому параметру specificity, поэтому хотя бы один из них обязателен.
+func parseReportCategoryID(c *gin.Context, specificity string) (uint, bool) {
+	categoryIDStr := c.Query("categoryId")
+	if categoryIDStr == "" {
+		if specificity == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Параметр 'categoryId' (рубрика) обязателен"})
+			return 0, false
+		}
+		return 0, true
+	}
+	categoryID, err := strconv.ParseUint(categoryIDStr, 10, 32)
+	if err != nil || categoryID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID рубрики"})
+		return 0, false
+	}
+	return uint(categoryID), true
+}
+
+// GetEstimatePerformance обрабатывает запрос "Продажи относительно предпродажной оценки" (по аукционам и продавцам)
+func (h *ReportHandler) GetEstimatePerformance(c *gin.Context) {
+	filters := make(map[string]string)
+	if auctionID := c.Query("auctionId"); auctionID != "" {
+		if _, err := strconv.ParseUint(auctionID, 10, 32); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона"})
+			return
+		}
+		filters["auctionId"] = auctionID
+	}
+	if sellerID := c.Query("sellerId"); sellerID != "" {
+		if _, err := strconv.ParseUint(sellerID, 10, 32); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID продавца"})
+			return
+		}
+		filters["sellerId"] = sellerID
+	}
+
+	report, err := h.reportService.GetEstimatePerformanceReport(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения отчета по оценкам: " + err.Error()})
+		return
+	}
+	if report.Summary.LotsTotal == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"message": "Проданные лоты с предпродажной оценкой не найдены"})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
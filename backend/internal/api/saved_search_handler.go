@@ -0,0 +1,118 @@
+package api
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SavedSearchHandler содержит методы-обработчики для сохраненных поисков текущего пользователя
+type SavedSearchHandler struct {
+	savedSearchService *services.SavedSearchService
+}
+
+// NewSavedSearchHandler создает новый экземпляр SavedSearchHandler
+func NewSavedSearchHandler(sss *services.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{savedSearchService: sss}
+}
+
+// respondSavedSearchError сопоставляет ошибку сервиса сохраненных поисков с HTTP-статусом
+func respondSavedSearchError(c *gin.Context, err error, fallbackMessage string) {
+	if strings.Contains(err.Error(), "не найден") {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	} else if strings.Contains(err.Error(), "некорректный фильтр") ||
+		strings.Contains(err.Error(), "укажите") ||
+		strings.Contains(err.Error(), "нельзя сохранить") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallbackMessage + ": " + err.Error()})
+	}
+}
+
+// GetMySavedSearches обрабатывает запрос списка сохраненных поисков
+func (h *SavedSearchHandler) GetMySavedSearches(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 50 {
+		pageSize = 10
+	}
+
+	currentUserID, _ := currentUser(c)
+	searches, total, err := h.savedSearchService.GetMySavedSearches(currentUserID, page, pageSize)
+	if err != nil {
+		respondSavedSearchError(c, err, "Ошибка получения сохраненных поисков")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": searches,
+		"pagination": gin.H{
+			"currentPage": page,
+			"pageSize":    pageSize,
+			"totalItems":  total,
+			"totalPages":  (total + int64(pageSize) - 1) / int64(pageSize),
+		},
+	})
+}
+
+// CreateSavedSearch обрабатывает сохранение набора фильтров списка лотов
+func (h *SavedSearchHandler) CreateSavedSearch(c *gin.Context) {
+	var input models.SavedSearchInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	currentUserID, _ := currentUser(c)
+	search, err := h.savedSearchService.CreateSavedSearch(currentUserID, input)
+	if err != nil {
+		respondSavedSearchError(c, err, "Ошибка сохранения поиска")
+		return
+	}
+	c.JSON(http.StatusCreated, search)
+}
+
+// UpdateSavedSearch обрабатывает изменение сохраненного поиска
+func (h *SavedSearchHandler) UpdateSavedSearch(c *gin.Context) {
+	searchID, err := strconv.ParseUint(c.Param("searchId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID сохраненного поиска в URL"})
+		return
+	}
+
+	var input models.SavedSearchInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	currentUserID, _ := currentUser(c)
+	search, err := h.savedSearchService.UpdateSavedSearch(currentUserID, uint(searchID), input)
+	if err != nil {
+		respondSavedSearchError(c, err, "Ошибка обновления сохраненного поиска")
+		return
+	}
+	c.JSON(http.StatusOK, search)
+}
+
+// DeleteSavedSearch обрабатывает удаление сохраненного поиска
+func (h *SavedSearchHandler) DeleteSavedSearch(c *gin.Context) {
+	searchID, err := strconv.ParseUint(c.Param("searchId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID сохраненного поиска в URL"})
+		return
+	}
+
+	currentUserID, _ := currentUser(c)
+	if err := h.savedSearchService.DeleteSavedSearch(currentUserID, uint(searchID)); err != nil {
+		respondSavedSearchError(c, err, "Ошибка удаления сохраненного поиска")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Сохраненный поиск удален"})
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"auction-app/backend/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimelineHandler содержит методы-обработчики хронологии аукциона
+type TimelineHandler struct {
+	timelineService *services.TimelineService
+}
+
+// NewTimelineHandler создает новый экземпляр TimelineHandler
+func NewTimelineHandler(ts *services.TimelineService) *TimelineHandler {
+	return &TimelineHandler{timelineService: ts}
+}
+
+// GetAuctionTimeline обрабатывает запрос хронологии аукциона (?replay=true - с повторным проигрыванием ставок
+// и сравнением результата с сохраненными значениями лотов)
+func (h *TimelineHandler) GetAuctionTimeline(c *gin.Context) {
+	auctionID, err := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона в URL"})
+		return
+	}
+	replay := c.Query("replay") == "true"
+
+	currentUserID, currentUserRole := currentUser(c)
+	timeline, err := h.timelineService.GetAuctionTimeline(uint(auctionID), replay, currentUserID, currentUserRole)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "недостаточно прав") {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения хронологии аукциона: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, timeline)
+}
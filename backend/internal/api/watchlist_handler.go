@@ -0,0 +1,119 @@
+package api
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WatchlistHandler содержит методы-обработчики для списка наблюдения текущего пользователя
+type WatchlistHandler struct {
+	watchlistService *services.WatchlistService
+}
+
+// NewWatchlistHandler создает новый экземпляр WatchlistHandler
+func NewWatchlistHandler(ws *services.WatchlistService) *WatchlistHandler {
+	return &WatchlistHandler{watchlistService: ws}
+}
+
+// GetMyWatchlist обрабатывает запрос списка наблюдения (?type=lots|auctions)
+func (h *WatchlistHandler) GetMyWatchlist(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 50 {
+		pageSize = 10
+	}
+
+	currentUserID, _ := currentUser(c)
+	entries, total, err := h.watchlistService.GetMyWatchlist(currentUserID, page, pageSize, c.Query("type"))
+	if err != nil {
+		if strings.Contains(err.Error(), "некорректный тип") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения списка наблюдения: " + err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": entries,
+		"pagination": gin.H{
+			"currentPage": page,
+			"pageSize":    pageSize,
+			"totalItems":  total,
+			"totalPages":  (total + int64(pageSize) - 1) / int64(pageSize),
+		},
+	})
+}
+
+// AddToWatchlist обрабатывает добавление лота или аукциона в список наблюдения
+func (h *WatchlistHandler) AddToWatchlist(c *gin.Context) {
+	var input models.AddWatchlistInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	currentUserID, _ := currentUser(c)
+	entry, err := h.watchlistService.AddToWatchlist(currentUserID, input)
+	if err != nil {
+		if strings.Contains(err.Error(), "не найден") {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "уже в списке наблюдения") {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else if strings.Contains(err.Error(), "укажите") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка добавления в список наблюдения: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, entry)
+}
+
+// RemoveWatchedLot обрабатывает удаление лота из списка наблюдения
+func (h *WatchlistHandler) RemoveWatchedLot(c *gin.Context) {
+	lotID, err := strconv.ParseUint(c.Param("lotId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID лота в URL"})
+		return
+	}
+
+	currentUserID, _ := currentUser(c)
+	if err := h.watchlistService.RemoveWatchedLot(currentUserID, uint(lotID)); err != nil {
+		respondWatchlistRemoveError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Лот удален из списка наблюдения"})
+}
+
+// RemoveWatchedAuction обрабатывает удаление аукциона из списка наблюдения
+func (h *WatchlistHandler) RemoveWatchedAuction(c *gin.Context) {
+	auctionID, err := strconv.ParseUint(c.Param("auctionId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID аукциона в URL"})
+		return
+	}
+
+	currentUserID, _ := currentUser(c)
+	if err := h.watchlistService.RemoveWatchedAuction(currentUserID, uint(auctionID)); err != nil {
+		respondWatchlistRemoveError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Аукцион удален из списка наблюдения"})
+}
+
+func respondWatchlistRemoveError(c *gin.Context, err error) {
+	if strings.Contains(err.Error(), "не найден") {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка удаления из списка наблюдения: " + err.Error()})
+	}
+}
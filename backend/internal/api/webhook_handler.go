@@ -0,0 +1,171 @@
+package api
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/services"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler содержит методы-обработчики для подписок на вебхуки текущего пользователя
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+// NewWebhookHandler создает новый экземпляр WebhookHandler
+func NewWebhookHandler(ws *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: ws}
+}
+
+// respondWebhookError сопоставляет ошибку сервиса вебхуков с HTTP-статусом
+func respondWebhookError(c *gin.Context, err error, fallbackMessage string) {
+	if strings.Contains(err.Error(), "не найден") {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	} else if strings.Contains(err.Error(), "недостаточно прав") {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	} else if strings.Contains(err.Error(), "некорректный") ||
+		strings.Contains(err.Error(), "укажите") ||
+		strings.Contains(err.Error(), "нельзя создать") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallbackMessage + ": " + err.Error()})
+	}
+}
+
+// GetMyWebhooks обрабатывает запрос списка подписок на вебхуки
+func (h *WebhookHandler) GetMyWebhooks(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 50 {
+		pageSize = 10
+	}
+
+	currentUserID, _ := currentUser(c)
+	subscriptions, total, err := h.webhookService.GetMyWebhooks(currentUserID, page, pageSize)
+	if err != nil {
+		respondWebhookError(c, err, "Ошибка получения подписок на вебхуки")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": subscriptions,
+		"pagination": gin.H{
+			"currentPage": page,
+			"pageSize":    pageSize,
+			"totalItems":  total,
+			"totalPages":  (total + int64(pageSize) - 1) / int64(pageSize),
+		},
+	})
+}
+
+// CreateWebhook обрабатывает создание подписки на вебхуки; в ответе единственный раз возвращается секрет
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var input models.CreateWebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	currentUserID, currentUserRole := currentUser(c)
+	subscription, err := h.webhookService.CreateWebhook(currentUserID, currentUserRole, input)
+	if err != nil {
+		respondWebhookError(c, err, "Ошибка создания подписки на вебхуки")
+		return
+	}
+	c.JSON(http.StatusCreated, subscription)
+}
+
+// UpdateWebhook обрабатывает изменение подписки на вебхуки
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	webhookID, err := strconv.ParseUint(c.Param("webhookId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID подписки в URL"})
+		return
+	}
+
+	var input models.UpdateWebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные входные данные: " + err.Error()})
+		return
+	}
+
+	currentUserID, _ := currentUser(c)
+	subscription, err := h.webhookService.UpdateWebhook(currentUserID, uint(webhookID), input)
+	if err != nil {
+		respondWebhookError(c, err, "Ошибка обновления подписки на вебхуки")
+		return
+	}
+	c.JSON(http.StatusOK, subscription)
+}
+
+// DeleteWebhook обрабатывает удаление подписки на вебхуки
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	webhookID, err := strconv.ParseUint(c.Param("webhookId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID подписки в URL"})
+		return
+	}
+
+	currentUserID, _ := currentUser(c)
+	if err := h.webhookService.DeleteWebhook(currentUserID, uint(webhookID)); err != nil {
+		respondWebhookError(c, err, "Ошибка удаления подписки на вебхуки")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Подписка на вебхуки удалена"})
+}
+
+// GetWebhookDeliveries обрабатывает запрос журнала доставок подписки (?status=pending|succeeded|failed)
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	webhookID, err := strconv.ParseUint(c.Param("webhookId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID подписки в URL"})
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 50 {
+		pageSize = 10
+	}
+
+	currentUserID, _ := currentUser(c)
+	deliveries, total, err := h.webhookService.GetWebhookDeliveries(currentUserID, uint(webhookID), page, pageSize, c.Query("status"))
+	if err != nil {
+		respondWebhookError(c, err, "Ошибка получения журнала доставок")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": deliveries,
+		"pagination": gin.H{
+			"currentPage": page,
+			"pageSize":    pageSize,
+			"totalItems":  total,
+			"totalPages":  (total + int64(pageSize) - 1) / int64(pageSize),
+		},
+	})
+}
+
+// RedeliverWebhook обрабатывает ручную повторную отправку события из журнала
+func (h *WebhookHandler) RedeliverWebhook(c *gin.Context) {
+	webhookID, errWebhook := strconv.ParseUint(c.Param("webhookId"), 10, 32)
+	deliveryID, errDelivery := strconv.ParseUint(c.Param("deliveryId"), 10, 32)
+	if errWebhook != nil || errDelivery != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный ID подписки или доставки в URL"})
+		return
+	}
+
+	currentUserID, _ := currentUser(c)
+	delivery, err := h.webhookService.Redeliver(currentUserID, uint(webhookID), uint(deliveryID))
+	if err != nil {
+		respondWebhookError(c, err, "Ошибка повторной отправки вебхука")
+		return
+	}
+	c.JSON(http.StatusAccepted, delivery)
+}
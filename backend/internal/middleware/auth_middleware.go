@@ -9,8 +9,14 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware проверяет JWT токен авторизации
-func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+// SessionValidator проверяет, что сессия токена действует, а пользователь не заблокирован
+type SessionValidator interface {
+	ValidateSession(userID, sessionID uint, role string) error
+}
+
+// AuthMiddleware проверяет JWT токен авторизации, а также сессию, на которую он ссылается:
+// токены отозванных сессий и заблокированных пользователей отклоняются до истечения их срока
+func AuthMiddleware(cfg *config.Config, sessions SessionValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -46,9 +52,39 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 			return
 		}
 
+		sessionIDClaim, okSession := claims["sid"].(float64)
+		if !okSession {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Токен выдан без сессии, войдите заново"})
+			return
+		}
+
+		if err := sessions.ValidateSession(uint(userIDClaim), uint(sessionIDClaim), userRoleClaim); err != nil {
+			if strings.Contains(err.Error(), "сессия отозвана") || strings.Contains(err.Error(), "неактивна") ||
+				strings.Contains(err.Error(), "роль сессии изменена") {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			} else {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Ошибка проверки сессии: " + err.Error()})
+			}
+			return
+		}
+
 		c.Set("userID", uint(userIDClaim))
 		c.Set("userRole", userRoleClaim)
+		c.Set("sessionID", uint(sessionIDClaim))
 
 		c.Next()
 	}
 }
+
+// OptionalAuthMiddleware для публичных маршрутов: запрос без заголовка авторизации пропускается анонимно,
+// а предъявленный токен проверяется так же, как в AuthMiddleware, чтобы обработчик знал пользователя
+func OptionalAuthMiddleware(cfg *config.Config, sessions SessionValidator) gin.HandlerFunc {
+	required := AuthMiddleware(cfg, sessions)
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		required(c)
+	}
+}
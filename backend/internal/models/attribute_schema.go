@@ -0,0 +1,97 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// AttributeType определяет тип значения атрибута лота
+type AttributeType string
+
+const (
+	AttributeTypeString  AttributeType = "string"
+	AttributeTypeInteger AttributeType = "integer"
+	AttributeTypeNumber  AttributeType = "number"
+	AttributeTypeBoolean AttributeType = "boolean"
+	AttributeTypeEnum    AttributeType = "enum"
+)
+
+// AttributeField описывает одно поле схемы атрибутов (например, "год чеканки" у монет)
+type AttributeField struct {
+	Key      string        `json:"key"`
+	Label    string        `json:"label"`
+	Type     AttributeType `json:"type"`
+	Required bool          `json:"required"`
+	Options  []string      `json:"options,omitempty"` // допустимые значения для типа enum
+	Min      *float64      `json:"min,omitempty"`     // для числовых типов
+	Max      *float64      `json:"max,omitempty"`     // для числовых типов
+}
+
+// AttributeFields - набор полей схемы, хранится в БД как jsonb
+type AttributeFields []AttributeField
+
+func (f AttributeFields) Value() (driver.Value, error) {
+	if f == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(f)
+	return string(data), err
+}
+
+func (f *AttributeFields) Scan(value interface{}) error {
+	return scanJSON(value, f)
+}
+
+// LotAttributes - значения атрибутов лота по ключам полей схемы, хранятся в БД как jsonb
+type LotAttributes map[string]interface{}
+
+func (a LotAttributes) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(a)
+	return string(data), err
+}
+
+func (a *LotAttributes) Scan(value interface{}) error {
+	return scanJSON(value, a)
+}
+
+func scanJSON(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return errors.New("unsupported type for jsonb column")
+	}
+}
+
+// AttributeSchema описывает набор атрибутов для лотов определенной специфики (монеты, живопись и т.д.)
+type AttributeSchema struct {
+	ID          uint            `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string          `gorm:"size:255;not null;uniqueIndex" json:"name"`
+	Description string          `gorm:"type:text" json:"description,omitempty"`
+	Fields      AttributeFields `gorm:"type:jsonb;not null;default:'[]'" json:"fields"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt   time.Time       `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// CreateAttributeSchemaInput структура для создания схемы атрибутов
+type CreateAttributeSchemaInput struct {
+	Name        string           `json:"name" binding:"required,min=2"`
+	Description string           `json:"description"`
+	Fields      []AttributeField `json:"fields" binding:"required,min=1"`
+}
+
+// UpdateAttributeSchemaInput определяет поля схемы атрибутов, которые можно обновить
+type UpdateAttributeSchemaInput struct {
+	Name        *string           `json:"name,omitempty"`
+	Description *string           `json:"description,omitempty"`
+	Fields      *[]AttributeField `json:"fields,omitempty"`
+}
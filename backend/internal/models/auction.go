@@ -16,30 +16,55 @@ const (
 	StatusCompleted AuctionStatus = "Завершен"
 )
 
+// AuctionFormatType определяет формат проведения торгов
+type AuctionFormatType string
+
+const (
+	// FormatEnglish - классический аукцион на повышение: побеждает самая высокая ставка
+	FormatEnglish AuctionFormatType = "english"
+	// FormatReverse - реверсивный (закупочный) аукцион: поставщики снижают цену, побеждает самое низкое предложение
+	FormatReverse AuctionFormatType = "reverse"
+)
+
+// AuctionRoles содержит названия ролей участников для формата аукциона
+type AuctionRoles struct {
+	LotOwner string `json:"lotOwner"`
+	Bidder   string `json:"bidder"`
+}
+
 // Auction представляет модель аукциона
 type Auction struct {
-	ID              uint           `gorm:"primaryKey;autoIncrement" json:"id"`
-	NameSpecificity string         `gorm:"size:255;not null" json:"nameSpecificity"`
-	DescriptionFull string         `gorm:"type:text" json:"descriptionFull,omitempty"`
-	AuctionDate     time.Time      `gorm:"not null" json:"auctionDate"`
-	AuctionTime     string         `gorm:"size:5;not null" json:"auctionTime"`
-	Location        string         `gorm:"size:255;not null" json:"location"`
-	Status          AuctionStatus  `gorm:"type:varchar(50);not null;default:'Запланирован'" json:"status"`
-	CreatedByUserID uint           `gorm:"not null" json:"createdByUserId"`
-	User            User           `gorm:"foreignKey:CreatedByUserID" json:"-"`
-	Lots            []Lot          `gorm:"foreignKey:AuctionID" json:"lots,omitempty"`
-	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"createdAt"`
-	UpdatedAt       time.Time      `gorm:"autoUpdateTime" json:"updatedAt"`
-	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                uint              `gorm:"primaryKey;autoIncrement" json:"id"`
+	NameSpecificity   string            `gorm:"size:255;not null" json:"nameSpecificity"`
+	DescriptionFull   string            `gorm:"type:text" json:"descriptionFull,omitempty"`
+	AuctionDate       time.Time         `gorm:"not null" json:"auctionDate"`
+	AuctionTime       string            `gorm:"size:5;not null" json:"auctionTime"`
+	Location          string            `gorm:"size:255;not null" json:"location"`
+	Status            AuctionStatus     `gorm:"type:varchar(50);not null;default:'Запланирован'" json:"status"`
+	Format            AuctionFormatType `gorm:"type:varchar(20);not null;default:'english'" json:"format"`
+	Roles             *AuctionRoles     `gorm:"-" json:"roles,omitempty"`
+	CategoryID        *uint             `gorm:"index" json:"categoryId,omitempty"`
+	Category          *Category         `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	AttributeSchemaID *uint             `gorm:"index" json:"attributeSchemaId,omitempty"`
+	AttributeSchema   *AttributeSchema  `gorm:"foreignKey:AttributeSchemaID" json:"attributeSchema,omitempty"`
+	CreatedByUserID   uint              `gorm:"not null" json:"createdByUserId"`
+	User              User              `gorm:"foreignKey:CreatedByUserID" json:"-"`
+	Lots              []Lot             `gorm:"foreignKey:AuctionID" json:"lots,omitempty"`
+	CreatedAt         time.Time         `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt         time.Time         `gorm:"autoUpdateTime" json:"updatedAt"`
+	DeletedAt         gorm.DeletedAt    `gorm:"index" json:"-"`
 }
 
 // CreateAuctionInput структура для данных при создании аукциона
 type CreateAuctionInput struct {
-	NameSpecificity string `json:"nameSpecificity" binding:"required,min=5"`
-	DescriptionFull string `json:"descriptionFull"`
-	AuctionDateStr  string `json:"auctionDate" binding:"required"`
-	AuctionTime     string `json:"auctionTime" binding:"required,len=5"`
-	Location        string `json:"location" binding:"required,min=3"`
+	NameSpecificity   string            `json:"nameSpecificity" binding:"required,min=5"`
+	DescriptionFull   string            `json:"descriptionFull"`
+	AuctionDateStr    string            `json:"auctionDate" binding:"required"`
+	AuctionTime       string            `json:"auctionTime" binding:"required,len=5"`
+	Location          string            `json:"location" binding:"required,min=3"`
+	Format            AuctionFormatType `json:"format"`
+	CategoryID        *uint             `json:"categoryId"`
+	AttributeSchemaID *uint             `json:"attributeSchemaId"`
 }
 
 // UpdateAuctionStatusInput структура для обновления статуса аукциона
@@ -49,9 +74,12 @@ type UpdateAuctionStatusInput struct {
 
 // UpdateAuctionInput определяет поля, которые можно обновить для аукциона.
 type UpdateAuctionInput struct {
-	NameSpecificity *string `json:"nameSpecificity,omitempty"`
-	DescriptionFull *string `json:"descriptionFull,omitempty"`
-	AuctionDateStr  *string `json:"auctionDate,omitempty"`
-	AuctionTime     *string `json:"auctionTime,omitempty"`
-	Location        *string `json:"location,omitempty"`
+	NameSpecificity   *string            `json:"nameSpecificity,omitempty"`
+	DescriptionFull   *string            `json:"descriptionFull,omitempty"`
+	AuctionDateStr    *string            `json:"auctionDate,omitempty"`
+	AuctionTime       *string            `json:"auctionTime,omitempty"`
+	Location          *string            `json:"location,omitempty"`
+	Format            *AuctionFormatType `json:"format,omitempty"`
+	CategoryID        *uint              `json:"categoryId,omitempty"`
+	AttributeSchemaID *uint              `json:"attributeSchemaId,omitempty"`
 }
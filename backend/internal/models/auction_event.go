@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// AuctionEventType - тип события торгов, которое передается клиентам в потоке событий
+type AuctionEventType string
+
+const (
+	EventBidPlaced            AuctionEventType = "bid_placed"             // по лоту сделана ставка
+	EventPriceChanged         AuctionEventType = "price_changed"          // изменилась текущая цена или лидер лота
+	EventLotStatusChanged     AuctionEventType = "lot_status_changed"     // изменился статус лота
+	EventAuctionStatusChanged AuctionEventType = "auction_status_changed" // изменился статус аукциона
+)
+
+// AuctionEvent - событие торгов для подписчиков потока аукциона или лота.
+// События передаются между экземплярами бэкенда в JSON, поэтому не хранятся в БД. ID совпадает
+// с ключом доменного события: при повторной доставке клиент может отбросить уже полученное событие.
+type AuctionEvent struct {
+	ID         string                 `json:"id,omitempty"`
+	Type       AuctionEventType       `json:"type"`
+	AuctionID  uint                   `json:"auctionId"`
+	LotID      *uint                  `json:"lotId,omitempty"`
+	Data       map[string]interface{} `json:"data,omitempty"`
+	OccurredAt time.Time              `json:"occurredAt"`
+}
@@ -0,0 +1,58 @@
+// backend/internal/models/audit.go
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+)
+
+// AuditAction - тип действия, которое попадает в журнал аудита
+type AuditAction string
+
+const (
+	AuditUserStatusChanged    AuditAction = "user.status_changed"    // администратор заблокировал или разблокировал пользователя
+	AuditUserRolesChanged     AuditAction = "user.roles_changed"     // администратор изменил доступные бизнес-роли пользователя
+	AuditUserSessionsRevoked  AuditAction = "user.sessions_revoked"  // администратор завершил все сессии пользователя
+	AuditAuctionStatusChanged AuditAction = "auction.status_changed" // изменен статус аукциона
+	AuditAuctionDeleted       AuditAction = "auction.deleted"        // аукцион удален
+)
+
+// AuditActions - все действия, которые записываются в журнал аудита
+var AuditActions = []AuditAction{AuditUserStatusChanged, AuditUserRolesChanged, AuditUserSessionsRevoked, AuditAuctionStatusChanged, AuditAuctionDeleted}
+
+// AuditChange - значение поля до и после действия
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditChanges - изменения полей объекта по именам полей, хранятся в БД как jsonb
+type AuditChanges map[string]AuditChange
+
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(c)
+	return string(data), err
+}
+
+func (c *AuditChanges) Scan(value interface{}) error {
+	return scanJSON(value, c)
+}
+
+// AuditLogEntry - запись журнала аудита: кто (и в какой активной роли), что и с каким объектом сделал,
+// какие поля изменились, с какого IP-адреса и когда. Журнал только дополняется: изменение и удаление
+// записей запрещены триггером в БД.
+type AuditLogEntry struct {
+	ID         uint         `gorm:"primaryKey;autoIncrement" json:"id"`
+	ActorID    uint         `gorm:"not null;index" json:"actorId"`
+	ActorRole  UserRole     `gorm:"type:varchar(50);not null" json:"actorRole"`
+	Action     AuditAction  `gorm:"type:varchar(50);not null;index" json:"action"`
+	TargetType string       `gorm:"size:50;not null;index:idx_audit_target,priority:1" json:"targetType"`
+	TargetID   uint         `gorm:"not null;index:idx_audit_target,priority:2" json:"targetId"`
+	Changes    AuditChanges `gorm:"type:jsonb;not null;default:'{}'" json:"changes"`
+	IPAddress  string       `gorm:"size:64" json:"ipAddress"`
+	CreatedAt  time.Time    `gorm:"autoCreateTime;index" json:"createdAt"`
+}
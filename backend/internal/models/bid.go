@@ -13,13 +13,17 @@ type Bid struct {
 	UserID    uint           `gorm:"not null;index" json:"userId"`
 	User      User           `gorm:"foreignKey:UserID" json:"bidderInfo,omitempty"`
 	BidAmount float64        `gorm:"not null" json:"bidAmount"`
+	Quantity  int            `gorm:"not null;default:1" json:"quantity"`
+	Voided    bool           `gorm:"not null;default:false" json:"voided"` // ставка аннулирована при снятии лота с торгов
 	BidTime   time.Time      `gorm:"autoCreateTime" json:"bidTime"`
 	CreatedAt time.Time      `gorm:"autoCreateTime" json:"-"`
 	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"-"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
-// PlaceBidInput структура для данных при размещении ставки
+// PlaceBidInput структура для данных при размещении ставки.
+// Для многоединичных лотов Amount - цена за единицу, Quantity - запрашиваемое количество единиц.
 type PlaceBidInput struct {
-	Amount float64 `json:"amount" binding:"required,gt=0"`
+	Amount   float64 `json:"amount" binding:"required,gt=0"`
+	Quantity int     `json:"quantity" binding:"omitempty,gte=1"`
 }
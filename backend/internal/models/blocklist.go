@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// BlockedBidder представляет запись черного списка продавца (или организатора аукциона)
+type BlockedBidder struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	OwnerID       uint      `gorm:"not null;uniqueIndex:idx_blocked_bidders_owner_user" json:"ownerId"`
+	Owner         *User     `gorm:"foreignKey:OwnerID" json:"ownerInfo,omitempty"`
+	BlockedUserID uint      `gorm:"not null;uniqueIndex:idx_blocked_bidders_owner_user;index" json:"blockedUserId"`
+	BlockedUser   *User     `gorm:"foreignKey:BlockedUserID" json:"blockedUserInfo,omitempty"`
+	Reason        string    `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+// AddBlockedBidderInput структура для добавления пользователя в черный список
+type AddBlockedBidderInput struct {
+	UserID uint   `json:"userId" binding:"required"`
+	Reason string `json:"reason"`
+}
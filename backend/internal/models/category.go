@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Category представляет рубрику каталога. Рубрики образуют дерево через ParentID.
+type Category struct {
+	ID                uint             `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name              string           `gorm:"size:255;not null" json:"name"`
+	Description       string           `gorm:"type:text" json:"description,omitempty"`
+	ParentID          *uint            `gorm:"index" json:"parentId,omitempty"`
+	Children          []Category       `gorm:"foreignKey:ParentID" json:"children,omitempty"`
+	AttributeSchemaID *uint            `gorm:"index" json:"attributeSchemaId,omitempty"`
+	AttributeSchema   *AttributeSchema `gorm:"foreignKey:AttributeSchemaID" json:"attributeSchema,omitempty"`
+	CreatedAt         time.Time        `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt         time.Time        `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// CreateCategoryInput структура для создания рубрики
+type CreateCategoryInput struct {
+	Name              string `json:"name" binding:"required,min=2"`
+	Description       string `json:"description"`
+	ParentID          *uint  `json:"parentId"`
+	AttributeSchemaID *uint  `json:"attributeSchemaId"`
+}
+
+// UpdateCategoryInput определяет поля рубрики, которые можно обновить.
+// ParentID = 0 переносит рубрику в корень, AttributeSchemaID = 0 снимает схему атрибутов.
+type UpdateCategoryInput struct {
+	Name              *string `json:"name,omitempty"`
+	Description       *string `json:"description,omitempty"`
+	ParentID          *uint   `json:"parentId,omitempty"`
+	AttributeSchemaID *uint   `json:"attributeSchemaId,omitempty"`
+}
@@ -0,0 +1,123 @@
+// backend/internal/models/consignment.go
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ConsignmentStatus определяет статусы заявки на комиссию
+type ConsignmentStatus string
+
+const (
+	// ConsignmentSubmitted - в заявке есть предметы, которые еще не рассмотрены
+	ConsignmentSubmitted ConsignmentStatus = "На рассмотрении"
+	// ConsignmentReviewed - все предметы рассмотрены, принятые ждут назначения на аукцион
+	ConsignmentReviewed ConsignmentStatus = "Рассмотрена"
+	// ConsignmentCompleted - все принятые предметы выставлены на аукционы
+	ConsignmentCompleted ConsignmentStatus = "Завершена"
+)
+
+// ConsignmentItemStatus определяет статусы предмета в заявке на комиссию
+type ConsignmentItemStatus string
+
+const (
+	ConsignmentItemPending  ConsignmentItemStatus = "На рассмотрении"
+	ConsignmentItemAccepted ConsignmentItemStatus = "Принят"
+	ConsignmentItemRejected ConsignmentItemStatus = "Отклонен"
+	ConsignmentItemListed   ConsignmentItemStatus = "Выставлен на аукцион"
+)
+
+// Consignment - заявка продавца на передачу предметов аукционному дому для продажи.
+// Сотрудники рассматривают каждый предмет, а принятые предметы назначают на запланированные аукционы.
+type Consignment struct {
+	ID        uint              `gorm:"primaryKey;autoIncrement" json:"id"`
+	SellerID  uint              `gorm:"not null;index" json:"sellerId"`
+	Seller    *User             `gorm:"foreignKey:SellerID" json:"sellerInfo,omitempty"`
+	Notes     string            `gorm:"type:text" json:"notes,omitempty"`
+	Status    ConsignmentStatus `gorm:"type:varchar(50);not null;default:'На рассмотрении';index" json:"status"`
+	Items     []ConsignmentItem `gorm:"foreignKey:ConsignmentID" json:"items"`
+	CreatedAt time.Time         `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time         `gorm:"autoUpdateTime" json:"updatedAt"`
+	DeletedAt gorm.DeletedAt    `gorm:"index" json:"-"`
+}
+
+// ConsignmentItem - предмет в заявке на комиссию
+type ConsignmentItem struct {
+	ID                 uint                   `gorm:"primaryKey;autoIncrement" json:"id"`
+	ConsignmentID      uint                   `gorm:"not null;index" json:"consignmentId"`
+	Name               string                 `gorm:"size:255;not null" json:"name"`
+	Description        string                 `gorm:"type:text" json:"description,omitempty"`
+	Quantity           int                    `gorm:"not null;default:1" json:"quantity"`
+	AskingPrice        float64                `gorm:"not null" json:"askingPrice"`  // цена, на которую рассчитывает продавец
+	ProposedStartPrice *float64               `json:"proposedStartPrice,omitempty"` // стартовая цена, предложенная сотрудником
+	Status             ConsignmentItemStatus  `gorm:"type:varchar(50);not null;default:'На рассмотрении'" json:"status"`
+	ReviewComment      string                 `gorm:"type:text" json:"reviewComment,omitempty"`
+	ReviewedByUserID   *uint                  `json:"reviewedByUserId,omitempty"`
+	ReviewedAt         *time.Time             `json:"reviewedAt,omitempty"`
+	LotID              *uint                  `gorm:"index" json:"lotId,omitempty"` // лот, созданный при назначении на аукцион
+	Images             []ConsignmentItemImage `gorm:"foreignKey:ItemID" json:"images,omitempty"`
+	CreatedAt          time.Time              `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt          time.Time              `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// ConsignmentItemImage - фотография предмета заявки. Файлы хранятся так же, как фотографии лотов,
+// и копируются в галерею лота при назначении предмета на аукцион.
+type ConsignmentItemImage struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ConsignmentID uint      `gorm:"not null;index" json:"consignmentId"`
+	ItemID        uint      `gorm:"not null;index" json:"itemId"`
+	BlobKey       string    `gorm:"size:255;not null" json:"-"`
+	ThumbnailKey  string    `gorm:"size:255;not null" json:"-"`
+	ContentType   string    `gorm:"size:50;not null" json:"contentType"`
+	OriginalName  string    `gorm:"size:255" json:"originalName"`
+	Size          int64     `gorm:"not null" json:"size"`
+	Width         int       `json:"width"`
+	Height        int       `json:"height"`
+	Position      int       `gorm:"not null;default:0" json:"position"`
+	URL           string    `gorm:"-" json:"url"`
+	ThumbnailURL  string    `gorm:"-" json:"thumbnailUrl"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+// FillURLs заполняет адреса для скачивания фотографии и ее миниатюры
+func (img *ConsignmentItemImage) FillURLs() {
+	img.URL = fmt.Sprintf("/api/v1/consignments/%d/items/%d/images/%d", img.ConsignmentID, img.ItemID, img.ID)
+	img.ThumbnailURL = img.URL + "/thumbnail"
+}
+
+// AfterFind заполняет адреса фотографии после загрузки из БД
+func (img *ConsignmentItemImage) AfterFind(tx *gorm.DB) error {
+	img.FillURLs()
+	return nil
+}
+
+// ConsignmentItemInput описывает предмет при подаче заявки
+type ConsignmentItemInput struct {
+	Name        string  `json:"name" binding:"required,min=3"`
+	Description string  `json:"description"`
+	Quantity    int     `json:"quantity" binding:"omitempty,gte=1"`
+	AskingPrice float64 `json:"askingPrice" binding:"required,gt=0"`
+}
+
+// CreateConsignmentInput структура для подачи заявки на комиссию
+type CreateConsignmentInput struct {
+	Notes string                 `json:"notes"`
+	Items []ConsignmentItemInput `json:"items" binding:"required,min=1,dive"`
+}
+
+// ReviewConsignmentItemInput - решение сотрудника по предмету заявки.
+// Для принятого предмета обязательна предлагаемая стартовая цена.
+type ReviewConsignmentItemInput struct {
+	Accept             bool     `json:"accept"`
+	ProposedStartPrice *float64 `json:"proposedStartPrice"`
+	Comment            string   `json:"comment"`
+}
+
+// AssignConsignmentItemsInput - назначение принятых предметов на запланированный аукцион
+type AssignConsignmentItemsInput struct {
+	AuctionID uint   `json:"auctionId" binding:"required"`
+	ItemIDs   []uint `json:"itemIds" binding:"required,min=1"`
+}
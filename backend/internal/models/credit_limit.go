@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+// CreditLimit определяет лимит участника на аукционе, обеспеченный его депозитом
+type CreditLimit struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      uint      `gorm:"not null;uniqueIndex:idx_credit_limits_user_auction" json:"userId"`
+	User        *User     `gorm:"foreignKey:UserID" json:"userInfo,omitempty"`
+	AuctionID   uint      `gorm:"not null;uniqueIndex:idx_credit_limits_user_auction;index" json:"auctionId"`
+	Amount      float64   `gorm:"not null" json:"amount"`
+	SetByUserID uint      `gorm:"not null" json:"setByUserId"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// SetCreditLimitInput структура для установки кредитного лимита участника
+type SetCreditLimitInput struct {
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+}
+
+// CreditLimitUsage показывает, какая часть кредитного лимита занята лидирующими ставками
+type CreditLimitUsage struct {
+	CreditLimit
+	Used      float64 `json:"used"`
+	Available float64 `json:"available"`
+}
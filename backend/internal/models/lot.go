@@ -15,41 +15,140 @@ const (
 	StatusLotActive LotStatus = "Идет торг"
 	StatusSold      LotStatus = "Продан"
 	StatusUnsold    LotStatus = "Не продан"
+	StatusWithdrawn LotStatus = "Снят с торгов"
+)
+
+// ModerationStatus определяет состояние проверки лота администратором.
+// Лот, не прошедший модерацию, не показывается в публичных списках и не принимает ставки.
+type ModerationStatus string
+
+const (
+	ModerationPending  ModerationStatus = "На модерации"
+	ModerationApproved ModerationStatus = "Одобрен"
+	ModerationRejected ModerationStatus = "Отклонен"
+)
+
+// ModerationReasonCode - код причины отклонения лота модератором
+type ModerationReasonCode string
+
+const (
+	ReasonProhibitedItem    ModerationReasonCode = "prohibited_item"    // предмет запрещен к продаже
+	ReasonInaccurateDetails ModerationReasonCode = "inaccurate_details" // описание неполное или недостоверное
+	ReasonPoorImages        ModerationReasonCode = "poor_images"        // фотографии отсутствуют или низкого качества
+	ReasonPricing           ModerationReasonCode = "pricing"            // стартовая цена или оценка не соответствуют предмету
+	ReasonDuplicate         ModerationReasonCode = "duplicate"          // лот дублирует уже выставленный
+	ReasonBannedContent     ModerationReasonCode = "banned_content"     // недопустимые формулировки в тексте
+	ReasonOther             ModerationReasonCode = "other"              // другая причина (уточняется в комментарии)
 )
 
 // Lot представляет модель лота (предмета) на аукционе
 type Lot struct {
-	ID              uint           `gorm:"primaryKey;autoIncrement" json:"id"`
-	AuctionID       uint           `gorm:"not null;index" json:"auctionId"`
-	LotNumber       int            `gorm:"not null" json:"lotNumber"`
-	Name            string         `gorm:"size:255;not null" json:"name"`
-	Description     string         `gorm:"type:text" json:"description,omitempty"`
-	SellerID        uint           `gorm:"not null" json:"sellerId"`
-	User            *User          `gorm:"foreignKey:SellerID" json:"-"`
-	StartPrice      float64        `gorm:"not null" json:"startPrice"`
-	CurrentPrice    float64        `gorm:"not null" json:"currentPrice"`
-	FinalPrice      *float64       `json:"finalPrice,omitempty"`
-	Status          LotStatus      `gorm:"type:varchar(50);not null;default:'Ожидает торгов'" json:"status"`
-	HighestBidderID *uint          `gorm:"index" json:"highestBidderId,omitempty"`
-	HighestBidder   *User          `gorm:"foreignKey:HighestBidderID" json:"highestBidderInfo,omitempty"`
-	FinalBuyerID    *uint          `gorm:"index" json:"finalBuyerId,omitempty"`
-	FinalBuyer      *User          `gorm:"foreignKey:FinalBuyerID" json:"finalBuyerInfo,omitempty"`
-	Biddings        []Bid          `gorm:"foreignKey:LotID" json:"-"`
-	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"createdAt"`
-	UpdatedAt       time.Time      `gorm:"autoUpdateTime" json:"updatedAt"`
-	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                uint                 `gorm:"primaryKey;autoIncrement" json:"id"`
+	AuctionID         uint                 `gorm:"not null;index" json:"auctionId"`
+	LotNumber         int                  `gorm:"not null" json:"lotNumber"`
+	Name              string               `gorm:"size:255;not null" json:"name"`
+	Description       string               `gorm:"type:text" json:"description,omitempty"`
+	SellerID          uint                 `gorm:"not null" json:"sellerId"`
+	User              *User                `gorm:"foreignKey:SellerID" json:"-"`
+	Quantity          int                  `gorm:"not null;default:1" json:"quantity"`
+	StartPrice        float64              `gorm:"not null" json:"startPrice"`
+	CurrentPrice      float64              `gorm:"not null" json:"currentPrice"`
+	FinalPrice        *float64             `json:"finalPrice,omitempty"`
+	Status            LotStatus            `gorm:"type:varchar(50);not null;default:'Ожидает торгов'" json:"status"`
+	HighestBidderID   *uint                `gorm:"index" json:"highestBidderId,omitempty"`
+	HighestBidder     *User                `gorm:"foreignKey:HighestBidderID" json:"highestBidderInfo,omitempty"`
+	Allocations       []LotAllocation      `gorm:"foreignKey:LotID" json:"allocations,omitempty"` // покупатели проданного лота: кому сколько единиц и по какой цене
+	Roles             *AuctionRoles        `gorm:"-" json:"roles,omitempty"`
+	WatcherCount      int64                `gorm:"-" json:"watcherCount"` // сколько пользователей следят за лотом
+	Images            []LotImage           `gorm:"foreignKey:LotID" json:"images,omitempty"`
+	Attributes        LotAttributes        `gorm:"type:jsonb;not null;default:'{}'" json:"attributes"`
+	CategoryID        *uint                `gorm:"index" json:"categoryId,omitempty"` // если не задана, действует рубрика аукциона
+	EstimateLow       *float64             `json:"estimateLow,omitempty"`             // предпродажная оценка (за единицу), нижняя граница
+	EstimateHigh      *float64             `json:"estimateHigh,omitempty"`            // предпродажная оценка (за единицу), верхняя граница
+	WithdrawalReason  string               `gorm:"type:text" json:"withdrawalReason,omitempty"`
+	WithdrawnAt       *time.Time           `json:"withdrawnAt,omitempty"`
+	WithdrawnByUserID *uint                `json:"withdrawnByUserId,omitempty"`
+	ModerationStatus  ModerationStatus     `gorm:"type:varchar(50);not null;default:'Одобрен';index" json:"moderationStatus"` // лоты, созданные до появления модерации, считаются одобренными
+	ModerationReason  ModerationReasonCode `gorm:"size:50" json:"moderationReason,omitempty"`
+	ModerationComment string               `gorm:"type:text" json:"moderationComment,omitempty"`
+	ModeratedByUserID *uint                `json:"moderatedByUserId,omitempty"`
+	ModeratedAt       *time.Time           `json:"moderatedAt,omitempty"`
+	PublishedAt       *time.Time           `json:"publishedAt,omitempty"` // когда лот в последний раз прошел модерацию
+	Biddings          []Bid                `gorm:"foreignKey:LotID" json:"-"`
+	CreatedAt         time.Time            `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt         time.Time            `gorm:"autoUpdateTime" json:"updatedAt"`
+	DeletedAt         gorm.DeletedAt       `gorm:"index" json:"-"`
+}
+
+// LotAllocation фиксирует, сколько единиц лота досталось покупателю и по какой цене за единицу.
+// Для лота из одной единицы создается ровно одно распределение на победителя.
+type LotAllocation struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	LotID     uint      `gorm:"not null;index" json:"lotId"`
+	UserID    uint      `gorm:"not null;index" json:"userId"`
+	User      *User     `gorm:"foreignKey:UserID" json:"buyerInfo,omitempty"`
+	Quantity  int       `gorm:"not null" json:"quantity"`
+	Price     float64   `gorm:"not null" json:"price"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
 }
 
 // CreateLotInput структура для данных при создании лота
 type CreateLotInput struct {
-	Name        string  `json:"name" binding:"required,min=3"`
-	Description string  `json:"description"`
-	StartPrice  float64 `json:"startPrice" binding:"required,gt=0"`
+	Name         string                 `json:"name" binding:"required,min=3"`
+	Description  string                 `json:"description"`
+	StartPrice   float64                `json:"startPrice" binding:"required,gt=0"`
+	Quantity     int                    `json:"quantity" binding:"omitempty,gte=1"`
+	Attributes   map[string]interface{} `json:"attributes"`
+	CategoryID   *uint                  `json:"categoryId"`
+	EstimateLow  *float64               `json:"estimateLow"`
+	EstimateHigh *float64               `json:"estimateHigh"`
 }
 
 // UpdateLotInput определяет поля, которые можно обновить для лота.
 type UpdateLotInput struct {
-	Name        *string  `json:"name,omitempty"`
-	Description *string  `json:"description,omitempty"`
-	StartPrice  *float64 `json:"startPrice,omitempty"`
+	Name         *string                `json:"name,omitempty"`
+	Description  *string                `json:"description,omitempty"`
+	StartPrice   *float64               `json:"startPrice,omitempty"`
+	Quantity     *int                   `json:"quantity,omitempty"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	CategoryID   *uint                  `json:"categoryId,omitempty"`
+	EstimateLow  *float64               `json:"estimateLow,omitempty"`
+	EstimateHigh *float64               `json:"estimateHigh,omitempty"`
+}
+
+// ReorderLotsInput структура для изменения порядка (и номеров) лотов аукциона
+type ReorderLotsInput struct {
+	LotIDs []uint `json:"lotIds" binding:"required,min=1"`
+}
+
+// WithdrawLotInput структура для снятия лота с торгов
+type WithdrawLotInput struct {
+	Reason string `json:"reason" binding:"required,min=5"`
+}
+
+// RejectLotInput - решение модератора об отклонении лота с кодом причины
+type RejectLotInput struct {
+	ReasonCode ModerationReasonCode `json:"reasonCode" binding:"required,oneof=prohibited_item inaccurate_details poor_images pricing duplicate banned_content other"`
+	Comment    string               `json:"comment"`
+}
+
+// EstimatePerformanceRow сравнивает цены продажи проданных лотов с их предпродажной оценкой
+// для одной группы (аукциона или продавца)
+type EstimatePerformanceRow struct {
+	GroupID           uint    `json:"groupId"`
+	GroupName         string  `json:"groupName"`
+	LotsTotal         int64   `json:"lotsTotal"`
+	AboveEstimate     int64   `json:"aboveEstimate"`
+	WithinEstimate    int64   `json:"withinEstimate"`
+	BelowEstimate     int64   `json:"belowEstimate"`
+	HammerTotal       float64 `json:"hammerTotal"`
+	EstimateLowTotal  float64 `json:"estimateLowTotal"`
+	EstimateHighTotal float64 `json:"estimateHighTotal"`
+}
+
+// EstimatePerformanceReport - отчет о продажах относительно оценок в разрезе аукционов и продавцов
+type EstimatePerformanceReport struct {
+	Summary   EstimatePerformanceRow   `json:"summary"`
+	ByAuction []EstimatePerformanceRow `json:"byAuction"`
+	BySeller  []EstimatePerformanceRow `json:"bySeller"`
 }
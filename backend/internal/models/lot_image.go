@@ -0,0 +1,43 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LotImage представляет фотографию лота. Сами файлы хранятся в BlobStore, в БД - только ключи.
+type LotImage struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	LotID        uint      `gorm:"not null;index" json:"lotId"`
+	BlobKey      string    `gorm:"size:255;not null" json:"-"`
+	ThumbnailKey string    `gorm:"size:255;not null" json:"-"`
+	ContentType  string    `gorm:"size:100;not null" json:"contentType"`
+	OriginalName string    `gorm:"size:255" json:"originalName,omitempty"`
+	Size         int64     `gorm:"not null" json:"size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	Position     int       `gorm:"not null" json:"position"`
+	IsPrimary    bool      `gorm:"not null;default:false" json:"isPrimary"`
+	URL          string    `gorm:"-" json:"url"`
+	ThumbnailURL string    `gorm:"-" json:"thumbnailUrl"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+// FillURLs заполняет адреса для скачивания фотографии и ее миниатюры
+func (img *LotImage) FillURLs() {
+	img.URL = fmt.Sprintf("/api/v1/lots/%d/images/%d", img.LotID, img.ID)
+	img.ThumbnailURL = img.URL + "/thumbnail"
+}
+
+// AfterFind заполняет адреса фотографии после загрузки из БД
+func (img *LotImage) AfterFind(tx *gorm.DB) error {
+	img.FillURLs()
+	return nil
+}
+
+// ReorderLotImagesInput структура для изменения порядка фотографий лота
+type ReorderLotImagesInput struct {
+	ImageIDs []uint `json:"imageIds" binding:"required,min=1"`
+}
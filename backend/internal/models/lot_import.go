@@ -0,0 +1,20 @@
+// backend/internal/models/lot_import.go
+package models
+
+// LotImportRowError описывает ошибки одной строки файла импорта.
+// Row - номер строки в файле (строка заголовков имеет номер 1).
+type LotImportRowError struct {
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
+
+// LotImportResult - результат массового импорта лотов из файла.
+// В режиме проверки (DryRun) лоты не сохраняются, а Lots содержит лоты в том виде, в каком они были бы созданы.
+type LotImportResult struct {
+	DryRun    bool                `json:"dryRun"`
+	TotalRows int                 `json:"totalRows"`
+	ValidRows int                 `json:"validRows"`
+	Imported  int                 `json:"imported"`
+	Errors    []LotImportRowError `json:"errors"`
+	Lots      []Lot               `json:"lots"`
+}
@@ -0,0 +1,18 @@
+// backend/internal/models/notification.go
+package models
+
+import (
+	"time"
+)
+
+// Notification - уведомление во входящих пользователя (снятие лота, решение модерации, новые лоты по поиску и т.п.)
+type Notification struct {
+	ID      uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID  uint       `gorm:"not null;index" json:"userId"`
+	Subject string     `gorm:"size:255;not null" json:"subject"`
+	Message string     `gorm:"type:text" json:"message"`
+	ReadAt  *time.Time `json:"readAt,omitempty"`
+	// IdempotencyKey не дает создать повторное уведомление при повторной обработке события
+	IdempotencyKey *string   `gorm:"size:128;uniqueIndex" json:"-"`
+	CreatedAt      time.Time `gorm:"autoCreateTime;index" json:"createdAt"`
+}
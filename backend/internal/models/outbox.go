@@ -0,0 +1,137 @@
+// backend/internal/models/outbox.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DomainEventType - тип доменного события, которое записывается в outbox вместе с изменением состояния
+type DomainEventType string
+
+const (
+	DomainBidPlaced            DomainEventType = "bid.placed"             // сделана ставка
+	DomainLotWithdrawn         DomainEventType = "lot.withdrawn"          // лот снят с торгов
+	DomainLotStatusChanged     DomainEventType = "lot.status_changed"     // статус лота изменился при смене статуса аукциона
+	DomainLotSettled           DomainEventType = "lot.settled"            // подведены итоги торгов по лоту
+	DomainAuctionStatusChanged DomainEventType = "auction.status_changed" // изменился статус аукциона
+)
+
+// OutboxStatus - состояние обработки события outbox
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "pending"   // ожидает обработки или повторной попытки
+	OutboxProcessed OutboxStatus = "processed" // все подписчики обработали событие
+	OutboxFailed    OutboxStatus = "failed"    // попытки исчерпаны
+)
+
+// OutboxEvent - доменное событие в таблице outbox. Записывается в той же транзакции, что и изменение
+// состояния, поэтому событие не теряется и не появляется для отмененного изменения. EventKey -
+// ключ идемпотентности: подписчики используют его, чтобы повторная доставка не давала дублей.
+type OutboxEvent struct {
+	ID            uint            `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventKey      string          `gorm:"size:64;not null;uniqueIndex" json:"eventKey"`
+	EventType     DomainEventType `gorm:"type:varchar(50);not null" json:"eventType"`
+	AggregateType string          `gorm:"size:50;not null" json:"aggregateType"`
+	AggregateID   uint            `gorm:"not null;index" json:"aggregateId"`
+	Payload       string          `gorm:"type:jsonb;not null" json:"payload"`
+	Status        OutboxStatus    `gorm:"type:varchar(20);not null;default:'pending';index:idx_outbox_due,priority:1" json:"status"`
+	NextAttemptAt time.Time       `gorm:"not null;index:idx_outbox_due,priority:2" json:"nextAttemptAt"`
+	Attempts      int             `gorm:"not null;default:0" json:"attempts"`
+	LastError     string          `gorm:"type:text" json:"lastError,omitempty"`
+	OccurredAt    time.Time       `gorm:"not null" json:"occurredAt"`
+	ProcessedAt   *time.Time      `json:"processedAt,omitempty"`
+}
+
+// Decode разбирает данные события в структуру соответствующего типа
+func (e *OutboxEvent) Decode(target interface{}) error {
+	return json.Unmarshal([]byte(e.Payload), target)
+}
+
+// OutboxConsumption отмечает, что подписчик обработал событие. Если обработка прервалась, событие
+// повторяется только для подписчиков без такой отметки.
+type OutboxConsumption struct {
+	EventID     uint      `gorm:"primaryKey" json:"eventId"`
+	Consumer    string    `gorm:"primaryKey;size:50" json:"consumer"`
+	ProcessedAt time.Time `gorm:"not null" json:"processedAt"`
+}
+
+// BidPlacedPayload - данные события bid.placed
+type BidPlacedPayload struct {
+	AuctionID               uint      `json:"auctionId"`
+	OrganizerID             uint      `json:"organizerId"`
+	LotID                   uint      `json:"lotId"`
+	LotNumber               int       `json:"lotNumber"`
+	LotName                 string    `json:"lotName"`
+	SellerID                uint      `json:"sellerId"`
+	BidID                   uint      `json:"bidId"`
+	BidderID                uint      `json:"bidderId"`
+	Amount                  float64   `json:"amount"`
+	Quantity                int       `json:"quantity"`
+	PlacedAt                time.Time `json:"placedAt"`
+	CurrentPrice            float64   `json:"currentPrice"`
+	PreviousPrice           float64   `json:"previousPrice"`
+	HighestBidderID         *uint     `json:"highestBidderId,omitempty"`
+	PreviousHighestBidderID *uint     `json:"previousHighestBidderId,omitempty"`
+	LotStatus               LotStatus `json:"lotStatus"`
+	PreviousLotStatus       LotStatus `json:"previousLotStatus"`
+	// PreviousLeaders и CurrentLeaders - участники, лидировавшие до и после ставки
+	// (для многоединичного лота - все, кто получает единицы)
+	PreviousLeaders []uint `json:"previousLeaders"`
+	CurrentLeaders  []uint `json:"currentLeaders"`
+}
+
+// LotWithdrawnPayload - данные события lot.withdrawn
+type LotWithdrawnPayload struct {
+	AuctionID   uint      `json:"auctionId"`
+	AuctionName string    `json:"auctionName"`
+	OrganizerID uint      `json:"organizerId"`
+	LotID       uint      `json:"lotId"`
+	LotNumber   int       `json:"lotNumber"`
+	LotName     string    `json:"lotName"`
+	SellerID    uint      `json:"sellerId"`
+	Status      LotStatus `json:"status"`
+	Reason      string    `json:"reason"`
+	// Leaders - участники, лидировавшие в момент снятия; их ставки аннулированы
+	Leaders []uint `json:"leaders"`
+}
+
+// LotStatusChangedPayload - данные события lot.status_changed
+type LotStatusChangedPayload struct {
+	AuctionID      uint      `json:"auctionId"`
+	LotID          uint      `json:"lotId"`
+	LotNumber      int       `json:"lotNumber"`
+	LotName        string    `json:"lotName"`
+	Status         LotStatus `json:"status"`
+	PreviousStatus LotStatus `json:"previousStatus"`
+}
+
+// LotSettledPayload - данные события lot.settled
+type LotSettledPayload struct {
+	AuctionID   uint            `json:"auctionId"`
+	AuctionName string          `json:"auctionName"`
+	OrganizerID uint            `json:"organizerId"`
+	LotID       uint            `json:"lotId"`
+	LotNumber   int             `json:"lotNumber"`
+	LotName     string          `json:"lotName"`
+	SellerID    uint            `json:"sellerId"`
+	Status      LotStatus       `json:"status"`
+	FinalPrice  *float64        `json:"finalPrice,omitempty"`
+	Allocations []LotAllocation `json:"allocations"`
+	Reason      string          `json:"reason,omitempty"`
+}
+
+// AuctionStatusChangedPayload - данные события auction.status_changed
+type AuctionStatusChangedPayload struct {
+	AuctionID      uint          `json:"auctionId"`
+	Name           string        `json:"name"`
+	Location       string        `json:"location"`
+	OrganizerID    uint          `json:"organizerId"`
+	Status         AuctionStatus `json:"status"`
+	PreviousStatus AuctionStatus `json:"previousStatus"`
+	// SellerIDs - продавцы лотов аукциона; SoldLots и UnsoldLots заполняются при завершении
+	SellerIDs  []uint `json:"sellerIds"`
+	SoldLots   int    `json:"soldLots"`
+	UnsoldLots int    `json:"unsoldLots"`
+}
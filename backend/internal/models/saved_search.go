@@ -0,0 +1,41 @@
+// backend/internal/models/saved_search.go
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+)
+
+// SearchFilters - набор фильтров списка лотов (те же параметры, что у GET /lots), хранится в БД как jsonb
+type SearchFilters map[string]string
+
+func (f SearchFilters) Value() (driver.Value, error) {
+	if f == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(f)
+	return string(data), err
+}
+
+func (f *SearchFilters) Scan(value interface{}) error {
+	return scanJSON(value, f)
+}
+
+// SavedSearch - сохраненный покупателем поиск лотов. Фоновая задача проверяет его по лотам, опубликованным
+// после LastCheckedAt, и кладет совпадения во входящие уведомления пользователя.
+type SavedSearch struct {
+	ID            uint          `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID        uint          `gorm:"not null;index" json:"userId"`
+	Name          string        `gorm:"size:255;not null" json:"name"`
+	Filters       SearchFilters `gorm:"type:jsonb;not null;default:'{}'" json:"filters"`
+	LastCheckedAt time.Time     `gorm:"not null" json:"lastCheckedAt"`
+	CreatedAt     time.Time     `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt     time.Time     `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// SavedSearchInput структура для создания и изменения сохраненного поиска
+type SavedSearchInput struct {
+	Name    string            `json:"name" binding:"required,min=1,max=255"`
+	Filters map[string]string `json:"filters" binding:"required,min=1"`
+}
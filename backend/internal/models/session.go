@@ -0,0 +1,47 @@
+// backend/internal/models/session.go
+package models
+
+import "time"
+
+// UserSession - сессия входа пользователя. Access-токен ссылается на сессию (claim sid), поэтому
+// после отзыва сессии или блокировки пользователя токен перестает приниматься сразу, не дожидаясь истечения.
+type UserSession struct {
+	ID            uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID        uint       `gorm:"not null;index" json:"userId"`
+	ActiveRole    UserRole   `gorm:"type:varchar(50);not null" json:"activeRole"`
+	UserAgent     string     `gorm:"type:text" json:"userAgent,omitempty"`
+	IPAddress     string     `gorm:"size:64" json:"ipAddress,omitempty"`
+	LastUsedAt    time.Time  `gorm:"not null" json:"lastUsedAt"`
+	RevokedAt     *time.Time `json:"revokedAt,omitempty"`
+	RevokedReason string     `gorm:"size:255" json:"revokedReason,omitempty"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+// RefreshToken - refresh-токен сессии. В БД хранится только SHA-256 хеш токена. Токен одноразовый:
+// при обновлении он помечается использованным и выдается следующий; повторное предъявление
+// использованного токена означает его утечку, и вся сессия отзывается.
+type RefreshToken struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	SessionID uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// AuthTokens - пара токенов, выдаваемая при входе и обновлении сессии
+type AuthTokens struct {
+	AccessToken          string    `json:"token"`
+	AccessTokenExpiresAt time.Time `json:"tokenExpiresAt"`
+	RefreshToken         string    `json:"refreshToken"`
+}
+
+// RefreshTokenInput структура запроса обновления пары токенов
+type RefreshTokenInput struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+// SwitchRoleInput структура запроса смены активной бизнес-роли
+type SwitchRoleInput struct {
+	Role UserRole `json:"role" binding:"required"`
+}
@@ -0,0 +1,75 @@
+// backend/internal/models/timeline.go
+package models
+
+import "time"
+
+// BidAttempt - отклоненная попытка ставки с причиной отказа. Принятые ставки хранятся в Bid,
+// а отклоненные нужны, чтобы при споре восстановить ход торгов полностью.
+type BidAttempt struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	AuctionID uint      `gorm:"not null;index" json:"auctionId"`
+	LotID     uint      `gorm:"not null;index" json:"lotId"`
+	UserID    uint      `gorm:"not null;index" json:"userId"`
+	Amount    float64   `gorm:"not null" json:"amount"`
+	Quantity  int       `gorm:"not null;default:1" json:"quantity"`
+	Reason    string    `gorm:"type:text;not null" json:"reason"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+// TimelineEventType - тип события в хронологии аукциона
+type TimelineEventType string
+
+const (
+	TimelineAuctionCreated       TimelineEventType = "auction_created"        // аукцион создан
+	TimelineAuctionStatusChanged TimelineEventType = "auction_status_changed" // изменился статус аукциона
+	TimelineLotOpened            TimelineEventType = "lot_opened"             // лот вышел в торги
+	TimelineLotStatusChanged     TimelineEventType = "lot_status_changed"     // статус лота изменился иначе
+	TimelineLotWithdrawn         TimelineEventType = "lot_withdrawn"          // лот снят с торгов
+	TimelineBidPlaced            TimelineEventType = "bid_placed"             // ставка принята (возможно, позже аннулирована)
+	TimelineBidRejected          TimelineEventType = "bid_rejected"           // ставка отклонена
+	TimelineLotSettled           TimelineEventType = "lot_settled"            // подведены итоги торгов по лоту
+)
+
+// TimelineEvent - событие хронологии аукциона
+type TimelineEvent struct {
+	At        time.Time              `json:"at"`
+	Type      TimelineEventType      `json:"type"`
+	LotID     *uint                  `json:"lotId,omitempty"`
+	LotNumber int                    `json:"lotNumber,omitempty"`
+	UserID    *uint                  `json:"userId,omitempty"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
+
+// LotOutcome - состояние торгов по лоту: сохраненное в БД или полученное повторным проигрыванием ставок
+type LotOutcome struct {
+	Status          LotStatus           `json:"status"`
+	CurrentPrice    float64             `json:"currentPrice"`
+	HighestBidderID *uint               `json:"highestBidderId,omitempty"`
+	FinalPrice      *float64            `json:"finalPrice,omitempty"`
+	Allocations     []AllocationOutcome `json:"allocations,omitempty"`
+}
+
+// AllocationOutcome - сколько единиц лота досталось покупателю и по какой цене за единицу
+type AllocationOutcome struct {
+	UserID   uint    `json:"userId"`
+	Quantity int     `json:"quantity"`
+	Price    float64 `json:"price"`
+}
+
+// LotReplayResult - сравнение сохраненного состояния лота с результатом повторного проигрывания ставок
+type LotReplayResult struct {
+	LotID       uint       `json:"lotId"`
+	LotNumber   int        `json:"lotNumber"`
+	Stored      LotOutcome `json:"stored"`
+	Replayed    LotOutcome `json:"replayed"`
+	Matches     bool       `json:"matches"`
+	Differences []string   `json:"differences,omitempty"`
+}
+
+// AuctionTimeline - упорядоченная хронология аукциона; Replay заполняется в режиме проигрывания
+type AuctionTimeline struct {
+	AuctionID uint              `json:"auctionId"`
+	Status    AuctionStatus     `json:"status"`
+	Events    []TimelineEvent   `json:"events"`
+	Replay    []LotReplayResult `json:"replay,omitempty"`
+}
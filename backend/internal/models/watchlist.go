@@ -0,0 +1,24 @@
+// backend/internal/models/watchlist.go
+package models
+
+import (
+	"time"
+)
+
+// WatchlistEntry - лот или аукцион, за которым пользователь следит без участия в торгах.
+// Заполнено ровно одно из полей LotID и AuctionID.
+type WatchlistEntry struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_watchlist_user_lot;uniqueIndex:idx_watchlist_user_auction" json:"userId"`
+	LotID     *uint     `gorm:"uniqueIndex:idx_watchlist_user_lot;index" json:"lotId,omitempty"`
+	Lot       *Lot      `gorm:"foreignKey:LotID" json:"lot,omitempty"`
+	AuctionID *uint     `gorm:"uniqueIndex:idx_watchlist_user_auction" json:"auctionId,omitempty"`
+	Auction   *Auction  `gorm:"foreignKey:AuctionID" json:"auction,omitempty"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+// AddWatchlistInput структура для добавления в список наблюдения: указывается либо лот, либо аукцион
+type AddWatchlistInput struct {
+	LotID     *uint `json:"lotId"`
+	AuctionID *uint `json:"auctionId"`
+}
@@ -0,0 +1,90 @@
+// backend/internal/models/webhook.go
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+)
+
+// WebhookEventType - тип события, о котором внешняя система получает вебхук
+type WebhookEventType string
+
+const (
+	WebhookLotSold          WebhookEventType = "lot.sold"          // лот продан по итогам торгов
+	WebhookLotUnsold        WebhookEventType = "lot.unsold"        // лот не продан по итогам торгов
+	WebhookBidPlaced        WebhookEventType = "bid.placed"        // по лоту сделана ставка
+	WebhookAuctionCompleted WebhookEventType = "auction.completed" // аукцион завершен
+)
+
+// WebhookEventTypes - все поддерживаемые типы событий
+var WebhookEventTypes = []WebhookEventType{WebhookLotSold, WebhookLotUnsold, WebhookBidPlaced, WebhookAuctionCompleted}
+
+// WebhookEventList - список типов событий подписки, хранится в БД как jsonb
+type WebhookEventList []WebhookEventType
+
+func (l WebhookEventList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(l)
+	return string(data), err
+}
+
+func (l *WebhookEventList) Scan(value interface{}) error {
+	return scanJSON(value, l)
+}
+
+// WebhookDeliveryStatus - состояние доставки вебхука
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"   // ожидает отправки или повторной попытки
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded" // получатель ответил кодом 2xx
+	DeliveryFailed    WebhookDeliveryStatus = "failed"    // попытки исчерпаны
+)
+
+// WebhookSubscription - подписка пользователя на события торгов. Продавец и организатор получают события
+// по своим лотам и аукционам, системный администратор - по всем. Тело каждого запроса подписывается
+// HMAC-SHA256 с секретом подписки; секрет показывается только при создании.
+type WebhookSubscription struct {
+	ID        uint             `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    uint             `gorm:"not null;index" json:"userId"`
+	URL       string           `gorm:"size:2048;not null" json:"url"`
+	Secret    string           `gorm:"size:128;not null" json:"secret,omitempty"`
+	Events    WebhookEventList `gorm:"type:jsonb;not null;default:'[]'" json:"events"`
+	IsActive  bool             `gorm:"not null;default:true" json:"isActive"`
+	CreatedAt time.Time        `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time        `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// WebhookDelivery - запись журнала доставки: одно событие для одной подписки со всеми попытками отправки
+type WebhookDelivery struct {
+	ID               uint                  `gorm:"primaryKey;autoIncrement" json:"id"`
+	SubscriptionID   uint                  `gorm:"not null;index;uniqueIndex:idx_webhook_delivery_event,where:redelivery_of_id IS NULL" json:"subscriptionId"`
+	EventID          string                `gorm:"size:64;not null;index;uniqueIndex:idx_webhook_delivery_event,where:redelivery_of_id IS NULL" json:"eventId"`
+	EventType        WebhookEventType      `gorm:"type:varchar(50);not null" json:"eventType"`
+	Payload          string                `gorm:"type:text;not null" json:"payload"`
+	Status           WebhookDeliveryStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	Attempts         int                   `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt    *time.Time            `gorm:"index" json:"nextAttemptAt,omitempty"`
+	LastResponseCode int                   `json:"lastResponseCode,omitempty"`
+	LastError        string                `gorm:"type:text" json:"lastError,omitempty"`
+	DeliveredAt      *time.Time            `json:"deliveredAt,omitempty"`
+	RedeliveryOfID   *uint                 `json:"redeliveryOfId,omitempty"`
+	CreatedAt        time.Time             `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt        time.Time             `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// CreateWebhookInput структура для создания подписки на вебхуки
+type CreateWebhookInput struct {
+	URL    string             `json:"url" binding:"required,url,max=2048"`
+	Events []WebhookEventType `json:"events" binding:"required,min=1"`
+}
+
+// UpdateWebhookInput структура для изменения подписки; незаданные поля не меняются
+type UpdateWebhookInput struct {
+	URL      *string            `json:"url" binding:"omitempty,url,max=2048"`
+	Events   []WebhookEventType `json:"events" binding:"omitempty,min=1"`
+	IsActive *bool              `json:"isActive"`
+}
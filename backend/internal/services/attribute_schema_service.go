@@ -0,0 +1,262 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// attributeKeyPattern ограничивает ключи атрибутов, чтобы их можно было безопасно использовать в фильтрах
+var attributeKeyPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,49}$`)
+
+// AttributeSchemaService управляет схемами атрибутов лотов
+type AttributeSchemaService struct {
+	schemaStore store.AttributeSchemaStore
+}
+
+// NewAttributeSchemaService создает новый экземпляр AttributeSchemaService
+func NewAttributeSchemaService(ss store.AttributeSchemaStore) *AttributeSchemaService {
+	return &AttributeSchemaService{schemaStore: ss}
+}
+
+// GetAllAttributeSchemas возвращает все схемы атрибутов
+func (s *AttributeSchemaService) GetAllAttributeSchemas() ([]models.AttributeSchema, error) {
+	schemas, err := s.schemaStore.GetAllAttributeSchemas()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения схем атрибутов: %w", err)
+	}
+	return schemas, nil
+}
+
+// GetAttributeSchemaByID возвращает схему атрибутов по ID
+func (s *AttributeSchemaService) GetAttributeSchemaByID(id uint) (*models.AttributeSchema, error) {
+	schema, err := s.schemaStore.GetAttributeSchemaByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения схемы атрибутов: %w", err)
+	}
+	if schema == nil {
+		return nil, errors.New("схема атрибутов не найдена")
+	}
+	return schema, nil
+}
+
+// CreateAttributeSchema создает новую схему атрибутов (только для администратора)
+func (s *AttributeSchemaService) CreateAttributeSchema(input models.CreateAttributeSchemaInput, currentUserRole models.UserRole) (*models.AttributeSchema, error) {
+	if currentUserRole != models.RoleSystemAdmin {
+		return nil, errors.New("недостаточно прав для управления схемами атрибутов")
+	}
+	if err := validateAttributeFields(input.Fields); err != nil {
+		return nil, err
+	}
+	existing, err := s.schemaStore.GetAttributeSchemaByName(input.Name)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка проверки схемы атрибутов: %w", err)
+	}
+	if existing != nil {
+		return nil, errors.New("схема атрибутов с таким названием уже существует")
+	}
+
+	schema := models.AttributeSchema{
+		Name:        input.Name,
+		Description: input.Description,
+		Fields:      input.Fields,
+	}
+	if err := s.schemaStore.CreateAttributeSchema(&schema); err != nil {
+		return nil, fmt.Errorf("ошибка создания схемы атрибутов: %w", err)
+	}
+	return &schema, nil
+}
+
+// UpdateAttributeSchema обновляет схему атрибутов (только для администратора).
+// Уже сохраненные значения атрибутов лотов не перепроверяются.
+func (s *AttributeSchemaService) UpdateAttributeSchema(id uint, input models.UpdateAttributeSchemaInput, currentUserRole models.UserRole) (*models.AttributeSchema, error) {
+	if currentUserRole != models.RoleSystemAdmin {
+		return nil, errors.New("недостаточно прав для управления схемами атрибутов")
+	}
+	schema, err := s.GetAttributeSchemaByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil && *input.Name != schema.Name {
+		if len(strings.TrimSpace(*input.Name)) < 2 {
+			return nil, errors.New("некорректное название схемы атрибутов")
+		}
+		existing, err := s.schemaStore.GetAttributeSchemaByName(*input.Name)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка проверки схемы атрибутов: %w", err)
+		}
+		if existing != nil {
+			return nil, errors.New("схема атрибутов с таким названием уже существует")
+		}
+		schema.Name = *input.Name
+	}
+	if input.Description != nil {
+		schema.Description = *input.Description
+	}
+	if input.Fields != nil {
+		if err := validateAttributeFields(*input.Fields); err != nil {
+			return nil, err
+		}
+		schema.Fields = *input.Fields
+	}
+
+	if err := s.schemaStore.UpdateAttributeSchema(schema); err != nil {
+		return nil, fmt.Errorf("ошибка обновления схемы атрибутов: %w", err)
+	}
+	return schema, nil
+}
+
+// DeleteAttributeSchema удаляет схему атрибутов, если она не используется аукционами
+func (s *AttributeSchemaService) DeleteAttributeSchema(id uint, currentUserRole models.UserRole) error {
+	if currentUserRole != models.RoleSystemAdmin {
+		return errors.New("недостаточно прав для управления схемами атрибутов")
+	}
+	if _, err := s.GetAttributeSchemaByID(id); err != nil {
+		return err
+	}
+	used, err := s.schemaStore.CountAuctionsUsingSchema(id)
+	if err != nil {
+		return fmt.Errorf("ошибка проверки использования схемы атрибутов: %w", err)
+	}
+	if used > 0 {
+		return fmt.Errorf("схема атрибутов используется в %d аукционах и не может быть удалена", used)
+	}
+	return s.schemaStore.DeleteAttributeSchema(id)
+}
+
+// validateAttributeFields проверяет корректность описания полей схемы
+func validateAttributeFields(fields []models.AttributeField) error {
+	if len(fields) == 0 {
+		return errors.New("схема атрибутов должна содержать хотя бы одно поле")
+	}
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if !attributeKeyPattern.MatchString(field.Key) {
+			return fmt.Errorf("некорректный ключ атрибута %q: допускаются латинские буквы, цифры и _", field.Key)
+		}
+		if seen[field.Key] {
+			return fmt.Errorf("ключ атрибута %q повторяется", field.Key)
+		}
+		seen[field.Key] = true
+		if strings.TrimSpace(field.Label) == "" {
+			return fmt.Errorf("для атрибута %q не задано название", field.Key)
+		}
+
+		switch field.Type {
+		case models.AttributeTypeString, models.AttributeTypeBoolean:
+		case models.AttributeTypeInteger, models.AttributeTypeNumber:
+			if field.Min != nil && field.Max != nil && *field.Min > *field.Max {
+				return fmt.Errorf("для атрибута %q минимум больше максимума", field.Key)
+			}
+		case models.AttributeTypeEnum:
+			if len(field.Options) == 0 {
+				return fmt.Errorf("для атрибута %q типа enum не заданы допустимые значения", field.Key)
+			}
+		default:
+			return fmt.Errorf("некорректный тип атрибута %q: %s", field.Key, field.Type)
+		}
+	}
+	return nil
+}
+
+// validateLotAttributes проверяет значения атрибутов лота по схеме и приводит их к типам полей
+func validateLotAttributes(schema *models.AttributeSchema, values map[string]interface{}) (models.LotAttributes, error) {
+	if schema == nil {
+		if len(values) > 0 {
+			return nil, errors.New("некорректные атрибуты: для аукциона не задана схема атрибутов")
+		}
+		return models.LotAttributes{}, nil
+	}
+
+	fieldsByKey := make(map[string]models.AttributeField, len(schema.Fields))
+	for _, field := range schema.Fields {
+		fieldsByKey[field.Key] = field
+	}
+	for key := range values {
+		if _, ok := fieldsByKey[key]; !ok {
+			return nil, fmt.Errorf("некорректные атрибуты: поле %q отсутствует в схеме %q", key, schema.Name)
+		}
+	}
+
+	result := make(models.LotAttributes, len(values))
+	for _, field := range schema.Fields {
+		raw, present := values[field.Key]
+		if !present || raw == nil || raw == "" {
+			if field.Required {
+				return nil, fmt.Errorf("некорректные атрибуты: не заполнено обязательное поле %q (%s)", field.Key, field.Label)
+			}
+			continue
+		}
+		value, err := normalizeAttributeValue(field, raw)
+		if err != nil {
+			return nil, fmt.Errorf("некорректные атрибуты: поле %q (%s): %w", field.Key, field.Label, err)
+		}
+		result[field.Key] = value
+	}
+	return result, nil
+}
+
+func normalizeAttributeValue(field models.AttributeField, raw interface{}) (interface{}, error) {
+	switch field.Type {
+	case models.AttributeTypeString:
+		str, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("ожидается строка")
+		}
+		return strings.TrimSpace(str), nil
+
+	case models.AttributeTypeBoolean:
+		b, ok := raw.(bool)
+		if !ok {
+			return nil, errors.New("ожидается true или false")
+		}
+		return b, nil
+
+	case models.AttributeTypeEnum:
+		str, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("ожидается строка")
+		}
+		for _, option := range field.Options {
+			if strings.EqualFold(option, strings.TrimSpace(str)) {
+				return option, nil
+			}
+		}
+		return nil, fmt.Errorf("допустимые значения: %s", strings.Join(field.Options, ", "))
+
+	case models.AttributeTypeInteger, models.AttributeTypeNumber:
+		var num float64
+		switch v := raw.(type) {
+		case float64:
+			num = v
+		case string:
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, errors.New("ожидается число")
+			}
+			num = parsed
+		default:
+			return nil, errors.New("ожидается число")
+		}
+		if field.Type == models.AttributeTypeInteger && num != math.Trunc(num) {
+			return nil, errors.New("ожидается целое число")
+		}
+		if field.Min != nil && num < *field.Min {
+			return nil, fmt.Errorf("значение меньше минимального (%v)", *field.Min)
+		}
+		if field.Max != nil && num > *field.Max {
+			return nil, fmt.Errorf("значение больше максимального (%v)", *field.Max)
+		}
+		if field.Type == models.AttributeTypeInteger {
+			return int64(num), nil
+		}
+		return num, nil
+	}
+	return nil, fmt.Errorf("неизвестный тип атрибута %s", field.Type)
+}
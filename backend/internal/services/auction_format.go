@@ -0,0 +1,173 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"errors"
+	"fmt"
+)
+
+// LotBidsLoader возвращает все ставки по лоту в хронологическом порядке
+type LotBidsLoader func(lotID uint) ([]models.Bid, error)
+
+// BidContext содержит данные, необходимые формату аукциона для проверки и применения ставки
+type BidContext struct {
+	Auction  *models.Auction
+	Lot      *models.Lot
+	BidderID uint
+	Amount   float64
+	Quantity int
+	// Bids - все ранее сделанные ставки по лоту
+	Bids []models.Bid
+	// LoadBids позволяет формату получить ставки по другим лотам аукциона
+	LoadBids LotBidsLoader
+}
+
+// SettlementDecision описывает итог торгов по одному лоту
+type SettlementDecision struct {
+	Lot         models.Lot
+	Allocations []models.LotAllocation
+	Reason      string
+}
+
+// AuctionFormat задает правила проведения торгов. Сервисы лотов и аукционов
+// не содержат правил конкретного формата и вызывают эти методы.
+type AuctionFormat interface {
+	// Name возвращает код формата, который хранится в models.Auction.Format
+	Name() models.AuctionFormatType
+	// Roles возвращает названия ролей владельца лота и участника торгов для ответов API
+	Roles() models.AuctionRoles
+	// BidderPays сообщает, оплачивает ли победитель лот (от этого зависит применение кредитных лимитов)
+	BidderPays() bool
+	// ValidateLot проверяет параметры лота при создании и редактировании
+	ValidateLot(lot *models.Lot) error
+	// ValidateBid проверяет ставку до ее сохранения
+	ValidateBid(bc *BidContext) error
+	// ApplyBid обновляет текущую цену и лидера лота после сохранения ставки
+	ApplyBid(bc *BidContext, bid models.Bid)
+	// PublicView скрывает сведения о ходе торгов, которые формат не раскрывает участникам
+	PublicView(lot *models.Lot)
+	// Settle подводит итоги торгов по лотам аукциона при его завершении.
+	// Возвращаются только лоты, состояние которых изменилось.
+	Settle(auction *models.Auction, loadBids LotBidsLoader) ([]SettlementDecision, error)
+}
+
+var auctionFormats = make(map[models.AuctionFormatType]AuctionFormat)
+
+// RegisterAuctionFormat регистрирует формат аукциона. Повторная регистрация заменяет формат с тем же кодом.
+func RegisterAuctionFormat(format AuctionFormat) {
+	auctionFormats[format.Name()] = format
+}
+
+// auctionFormatFor возвращает формат по коду; пустой код означает формат по умолчанию
+func auctionFormatFor(name models.AuctionFormatType) (AuctionFormat, error) {
+	if name == "" {
+		name = models.FormatEnglish
+	}
+	format, ok := auctionFormats[name]
+	if !ok {
+		return nil, fmt.Errorf("некорректный формат аукциона: %s", name)
+	}
+	return format, nil
+}
+
+// applyAuctionRoles заполняет названия ролей участников в ответе по формату аукциона
+func applyAuctionRoles(auction *models.Auction) {
+	if auction == nil {
+		return
+	}
+	if format, err := auctionFormatFor(auction.Format); err == nil {
+		roles := format.Roles()
+		auction.Roles = &roles
+	}
+}
+
+func init() {
+	RegisterAuctionFormat(englishFormat{})
+	RegisterAuctionFormat(reverseFormat{})
+}
+
+// settleSingleUnitLot подводит итог по лоту из одной единицы: победителем становится лидер торгов.
+// При wonBy != nil действует правило одного предмета: участник, уже выигравший лот на этом аукционе, второй лот не получает.
+func settleSingleUnitLot(lot models.Lot, wonBy map[uint]uint) (SettlementDecision, bool) {
+	if lot.Status == models.StatusWithdrawn {
+		return SettlementDecision{}, false
+	}
+	if (lot.Status == models.StatusLotActive || lot.Status == models.StatusPending) && lot.HighestBidderID != nil {
+		buyerID := *lot.HighestBidderID
+		if wonBy != nil {
+			if otherLotID, alreadyWon := wonBy[buyerID]; alreadyWon {
+				markLotUnsold(&lot)
+				return SettlementDecision{
+					Lot:    lot,
+					Reason: fmt.Sprintf("лидер торгов (пользователь %d) уже выиграл лот %d на этом аукционе", buyerID, otherLotID),
+				}, true
+			}
+			wonBy[buyerID] = lot.ID
+		}
+		finalPrice := lot.CurrentPrice
+		lot.Status = models.StatusSold
+		lot.FinalPrice = &finalPrice
+		lot.Allocations = []models.LotAllocation{{LotID: lot.ID, UserID: buyerID, Quantity: 1, Price: finalPrice}}
+		return SettlementDecision{
+			Lot:         lot,
+			Allocations: lot.Allocations,
+			Reason:      fmt.Sprintf("продан лидеру торгов (пользователь %d) за %.2f", buyerID, finalPrice),
+		}, true
+	}
+	if lot.Status != models.StatusSold && lot.Status != models.StatusUnsold {
+		markLotUnsold(&lot)
+		return SettlementDecision{Lot: lot, Reason: "ставок не поступило"}, true
+	}
+	return SettlementDecision{}, false
+}
+
+func markLotUnsold(lot *models.Lot) {
+	lot.Status = models.StatusUnsold
+	lot.HighestBidderID = nil
+	lot.Allocations = nil
+	lot.FinalPrice = nil
+}
+
+// hideBidderPersonalData оставляет в сведениях об участниках торгов лота только ID и, если формат
+// раскрывает участников, ФИО. Email и паспортные данные в публичные ответы не попадают.
+func hideBidderPersonalData(lot *models.Lot, showNames bool) {
+	publicUser := func(user *models.User) *models.User {
+		if user == nil {
+			return nil
+		}
+		public := &models.User{ID: user.ID}
+		if showNames {
+			public.FullName = user.FullName
+		}
+		return public
+	}
+	lot.HighestBidder = publicUser(lot.HighestBidder)
+	for i := range lot.Allocations {
+		lot.Allocations[i].User = publicUser(lot.Allocations[i].User)
+	}
+}
+
+// validateEstimateRange проверяет предпродажную оценку лота. В торгах на повышение нижняя граница
+// оценки не может быть ниже стартовой цены, в торгах на понижение верхняя граница не может ее превышать.
+func validateEstimateRange(lot *models.Lot, ascending bool) error {
+	if lot.EstimateLow == nil && lot.EstimateHigh == nil {
+		return nil
+	}
+	if lot.EstimateLow == nil || lot.EstimateHigh == nil {
+		return errors.New("оценка лота должна содержать нижнюю и верхнюю границу")
+	}
+	low, high := *lot.EstimateLow, *lot.EstimateHigh
+	if low <= 0 || high <= 0 {
+		return errors.New("границы оценки лота должны быть положительными")
+	}
+	if low > high {
+		return errors.New("нижняя граница оценки лота не может превышать верхнюю")
+	}
+	if ascending && low < lot.StartPrice {
+		return fmt.Errorf("нижняя граница оценки лота не может быть ниже стартовой цены (%.2f)", lot.StartPrice)
+	}
+	if !ascending && high > lot.StartPrice {
+		return fmt.Errorf("верхняя граница оценки лота не может превышать стартовую цену (%.2f)", lot.StartPrice)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"testing"
+)
+
+// Общие сценарии для всех зарегистрированных форматов: каждый сценарий задает ожидаемый
+// результат для каждого формата, а формат без ожидания считается не покрытым тестами.
+
+type bidExpectation struct {
+	accepted bool
+	price    float64 // текущая цена лота после ApplyBid
+}
+
+func TestAuctionFormatsBidConformance(t *testing.T) {
+	const bidderID = uint(1)
+	leader := bidderID
+
+	tests := []struct {
+		name string
+		// bidderLeadsOtherLot - участник уже лидирует на другом активном лоте этого аукциона
+		bidderLeadsOtherLot bool
+		amount              float64
+		quantity            int
+		want                map[models.AuctionFormatType]bidExpectation
+	}{
+		{
+			name:     "ставка выше текущей цены",
+			amount:   150,
+			quantity: 1,
+			want: map[models.AuctionFormatType]bidExpectation{
+				models.FormatEnglish: {accepted: true, price: 150},
+				models.FormatReverse: {accepted: false},
+			},
+		},
+		{
+			name:     "ставка ниже текущей цены",
+			amount:   80,
+			quantity: 1,
+			want: map[models.AuctionFormatType]bidExpectation{
+				models.FormatEnglish: {accepted: false},
+				models.FormatReverse: {accepted: true, price: 80},
+			},
+		},
+		{
+			name:     "ставка равна текущей цене",
+			amount:   100,
+			quantity: 1,
+			want: map[models.AuctionFormatType]bidExpectation{
+				models.FormatEnglish: {accepted: false},
+				models.FormatReverse: {accepted: false},
+			},
+		},
+		{
+			name:     "больше одной единицы на одноединичном лоте",
+			amount:   150,
+			quantity: 2,
+			want: map[models.AuctionFormatType]bidExpectation{
+				models.FormatEnglish: {accepted: false},
+				models.FormatReverse: {accepted: false},
+			},
+		},
+		{
+			name:                "участник уже лидирует на другом лоте",
+			bidderLeadsOtherLot: true,
+			amount:              150,
+			quantity:            1,
+			want: map[models.AuctionFormatType]bidExpectation{
+				models.FormatEnglish: {accepted: false},
+				models.FormatReverse: {accepted: false},
+			},
+		},
+		{
+			name:                "участник уже лидирует на другом лоте, предложение ниже цены",
+			bidderLeadsOtherLot: true,
+			amount:              80,
+			quantity:            1,
+			want: map[models.AuctionFormatType]bidExpectation{
+				models.FormatEnglish: {accepted: false},
+				models.FormatReverse: {accepted: true, price: 80},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for name, format := range auctionFormats {
+			want, ok := tt.want[name]
+			if !ok {
+				t.Errorf("%s: нет ожидаемого результата для формата %s", tt.name, name)
+				continue
+			}
+			t.Run(tt.name+"/"+string(name), func(t *testing.T) {
+				lot := models.Lot{ID: 10, AuctionID: 1, Quantity: 1, StartPrice: 100, CurrentPrice: 100, Status: models.StatusLotActive}
+				auction := models.Auction{ID: 1, Format: name, Lots: []models.Lot{lot}}
+				if tt.bidderLeadsOtherLot {
+					auction.Lots = append(auction.Lots, models.Lot{ID: 11, AuctionID: 1, Quantity: 1, CurrentPrice: 300, Status: models.StatusLotActive, HighestBidderID: &leader})
+				}
+				bc := &BidContext{
+					Auction:  &auction,
+					Lot:      &lot,
+					BidderID: bidderID,
+					Amount:   tt.amount,
+					Quantity: tt.quantity,
+					LoadBids: func(uint) ([]models.Bid, error) { return nil, nil },
+				}
+
+				err := format.ValidateBid(bc)
+				if (err == nil) != want.accepted {
+					t.Fatalf("ValidateBid вернул ошибку %v, ожидалось принятие ставки: %v", err, want.accepted)
+				}
+				if err != nil {
+					return
+				}
+
+				format.ApplyBid(bc, models.Bid{ID: 1, LotID: lot.ID, UserID: bidderID, BidAmount: tt.amount, Quantity: tt.quantity})
+				if lot.CurrentPrice != want.price {
+					t.Errorf("текущая цена = %.2f, ожидалось %.2f", lot.CurrentPrice, want.price)
+				}
+				if lot.HighestBidderID == nil || *lot.HighestBidderID != bidderID {
+					t.Errorf("лидер = %v, ожидался участник %d", lot.HighestBidderID, bidderID)
+				}
+			})
+		}
+	}
+}
+
+// lotOutcomeExpectation - ожидаемое состояние лота после Settle; buyerID == 0 означает отсутствие покупателя
+type lotOutcomeExpectation struct {
+	status  models.LotStatus
+	buyerID uint
+	price   float64
+}
+
+func TestAuctionFormatsSettleConformance(t *testing.T) {
+	first, second := uint(1), uint(2)
+
+	tests := []struct {
+		name string
+		lots []models.Lot
+		// want - ожидаемые итоги по ID лота; лоты, отсутствующие в карте, не должны попасть в решения
+		want map[models.AuctionFormatType]map[uint]lotOutcomeExpectation
+	}{
+		{
+			name: "лот продается лидеру по текущей цене",
+			lots: []models.Lot{
+				{ID: 10, Quantity: 1, CurrentPrice: 150, Status: models.StatusLotActive, HighestBidderID: &first},
+			},
+			want: map[models.AuctionFormatType]map[uint]lotOutcomeExpectation{
+				models.FormatEnglish: {10: {status: models.StatusSold, buyerID: first, price: 150}},
+				models.FormatReverse: {10: {status: models.StatusSold, buyerID: first, price: 150}},
+			},
+		},
+		{
+			name: "лот без ставок не продан",
+			lots: []models.Lot{
+				{ID: 10, Quantity: 1, CurrentPrice: 100, Status: models.StatusLotActive},
+			},
+			want: map[models.AuctionFormatType]map[uint]lotOutcomeExpectation{
+				models.FormatEnglish: {10: {status: models.StatusUnsold}},
+				models.FormatReverse: {10: {status: models.StatusUnsold}},
+			},
+		},
+		{
+			name: "снятый лот не меняется",
+			lots: []models.Lot{
+				{ID: 10, Quantity: 1, CurrentPrice: 150, Status: models.StatusWithdrawn, HighestBidderID: &first},
+			},
+			want: map[models.AuctionFormatType]map[uint]lotOutcomeExpectation{
+				models.FormatEnglish: {},
+				models.FormatReverse: {},
+			},
+		},
+		{
+			name: "один участник лидирует на двух лотах",
+			lots: []models.Lot{
+				{ID: 10, Quantity: 1, CurrentPrice: 150, Status: models.StatusLotActive, HighestBidderID: &first},
+				{ID: 11, Quantity: 1, CurrentPrice: 90, Status: models.StatusLotActive, HighestBidderID: &first},
+				{ID: 12, Quantity: 1, CurrentPrice: 70, Status: models.StatusLotActive, HighestBidderID: &second},
+			},
+			want: map[models.AuctionFormatType]map[uint]lotOutcomeExpectation{
+				models.FormatEnglish: {
+					10: {status: models.StatusSold, buyerID: first, price: 150},
+					11: {status: models.StatusUnsold},
+					12: {status: models.StatusSold, buyerID: second, price: 70},
+				},
+				models.FormatReverse: {
+					10: {status: models.StatusSold, buyerID: first, price: 150},
+					11: {status: models.StatusSold, buyerID: first, price: 90},
+					12: {status: models.StatusSold, buyerID: second, price: 70},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for name, format := range auctionFormats {
+			want, ok := tt.want[name]
+			if !ok {
+				t.Errorf("%s: нет ожидаемого результата для формата %s", tt.name, name)
+				continue
+			}
+			t.Run(tt.name+"/"+string(name), func(t *testing.T) {
+				lots := make([]models.Lot, len(tt.lots))
+				copy(lots, tt.lots)
+				auction := &models.Auction{ID: 1, Format: name, Lots: lots}
+
+				decisions, err := format.Settle(auction, func(uint) ([]models.Bid, error) { return nil, nil })
+				if err != nil {
+					t.Fatalf("Settle вернул ошибку: %v", err)
+				}
+				if len(decisions) != len(want) {
+					t.Fatalf("получено %d решений, ожидалось %d", len(decisions), len(want))
+				}
+				for _, decision := range decisions {
+					expected, ok := want[decision.Lot.ID]
+					if !ok {
+						t.Errorf("лишнее решение по лоту %d", decision.Lot.ID)
+						continue
+					}
+					if decision.Lot.Status != expected.status {
+						t.Errorf("лот %d: статус = %s, ожидался %s", decision.Lot.ID, decision.Lot.Status, expected.status)
+					}
+					if expected.buyerID == 0 {
+						if len(decision.Allocations) != 0 || decision.Lot.FinalPrice != nil {
+							t.Errorf("лот %d: ожидалось отсутствие покупателя, получено %+v", decision.Lot.ID, decision.Allocations)
+						}
+						continue
+					}
+					if len(decision.Allocations) != 1 || decision.Allocations[0].UserID != expected.buyerID || decision.Allocations[0].Quantity != 1 {
+						t.Errorf("лот %d: распределение = %+v, ожидался покупатель %d", decision.Lot.ID, decision.Allocations, expected.buyerID)
+					}
+					if decision.Lot.FinalPrice == nil || *decision.Lot.FinalPrice != expected.price {
+						t.Errorf("лот %d: итоговая цена = %v, ожидалось %.2f", decision.Lot.ID, decision.Lot.FinalPrice, expected.price)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestAuctionFormatsPublicViewHidesPersonalData(t *testing.T) {
+	wantName := map[models.AuctionFormatType]bool{
+		models.FormatEnglish: true,
+		models.FormatReverse: false,
+	}
+	for name, format := range auctionFormats {
+		showsName, ok := wantName[name]
+		if !ok {
+			t.Errorf("нет ожидаемого результата для формата %s", name)
+			continue
+		}
+		t.Run(string(name), func(t *testing.T) {
+			bidder := &models.User{ID: 1, FullName: "Иван Петров", Email: "ivan@example.com", PassportData: "4500123456"}
+			buyer := *bidder
+			lot := &models.Lot{HighestBidder: bidder, Allocations: []models.LotAllocation{{UserID: 1, User: &buyer}}}
+
+			format.PublicView(lot)
+
+			for _, user := range []*models.User{lot.HighestBidder, lot.Allocations[0].User} {
+				if user == nil || user.ID != 1 {
+					t.Fatalf("ID участника должен сохраниться, получено %+v", user)
+				}
+				if user.Email != "" || user.PassportData != "" {
+					t.Errorf("контактные и паспортные данные раскрыты: %+v", user)
+				}
+				if (user.FullName != "") != showsName {
+					t.Errorf("ФИО = %q, ожидалось раскрытие: %v", user.FullName, showsName)
+				}
+			}
+		})
+	}
+}
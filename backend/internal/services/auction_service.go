@@ -11,13 +11,29 @@ import (
 
 // AuctionService provides business logic for auction operations.
 type AuctionService struct {
-	auctionStore store.AuctionStore
-	lotStore     store.LotStore
+	auctionStore  store.AuctionStore
+	lotStore      store.LotStore
+	bidStore      store.BidStore
+	schemaStore   store.AttributeSchemaStore
+	categoryStore store.CategoryStore
+	outbox        OutboxWaker
 }
 
 // NewAuctionService создает новый экземпляр AuctionService.
-func NewAuctionService(as store.AuctionStore, ls store.LotStore) *AuctionService {
-	return &AuctionService{auctionStore: as, lotStore: ls}
+func NewAuctionService(as store.AuctionStore, ls store.LotStore, bs store.BidStore, ss store.AttributeSchemaStore, cs store.CategoryStore, outbox OutboxWaker) *AuctionService {
+	return &AuctionService{auctionStore: as, lotStore: ls, bidStore: bs, schemaStore: ss, categoryStore: cs, outbox: outbox}
+}
+
+// getAttributeSchema загружает схему атрибутов, выбранную для аукциона
+func (s *AuctionService) getAttributeSchema(id uint) (*models.AttributeSchema, error) {
+	schema, err := s.schemaStore.GetAttributeSchemaByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения схемы атрибутов: %w", err)
+	}
+	if schema == nil {
+		return nil, errors.New("схема атрибутов не найдена")
+	}
+	return schema, nil
 }
 
 // CreateAuction обрабатывает бизнес-логику для создания нового аукциона.
@@ -30,6 +46,11 @@ func (s *AuctionService) CreateAuction(input models.CreateAuctionInput, createdB
 		return nil, errors.New("некорректный формат времени (ожидается ЧЧ:ММ)")
 	}
 
+	format, err := auctionFormatFor(input.Format)
+	if err != nil {
+		return nil, err
+	}
+
 	auction := models.Auction{
 		NameSpecificity: input.NameSpecificity,
 		DescriptionFull: input.DescriptionFull,
@@ -37,12 +58,37 @@ func (s *AuctionService) CreateAuction(input models.CreateAuctionInput, createdB
 		AuctionTime:     input.AuctionTime,
 		Location:        input.Location,
 		Status:          models.StatusScheduled,
+		Format:          format.Name(),
 		CreatedByUserID: createdByUserID,
 	}
+	schemaID := input.AttributeSchemaID
+	if input.CategoryID != nil {
+		category, err := getCategory(s.categoryStore, *input.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		auction.CategoryID = &category.ID
+		auction.Category = category
+		if schemaID == nil {
+			// Схема атрибутов по умолчанию наследуется от рубрики
+			if schemaID, err = inheritedSchemaID(s.categoryStore, category.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if schemaID != nil {
+		schema, err := s.getAttributeSchema(*schemaID)
+		if err != nil {
+			return nil, err
+		}
+		auction.AttributeSchemaID = &schema.ID
+		auction.AttributeSchema = schema
+	}
 
 	if err := s.auctionStore.CreateAuction(&auction); err != nil {
 		return nil, fmt.Errorf("ошибка создания аукциона в хранилище: %w", err)
 	}
+	applyAuctionRoles(&auction)
 	return &auction, nil
 }
 
@@ -62,18 +108,22 @@ func (s *AuctionService) GetAllAuctions(page, pageSize int, filters map[string]s
 	if err != nil {
 		return nil, 0, fmt.Errorf("ошибка получения списка аукционов: %w", err)
 	}
+	for i := range auctions {
+		applyAuctionRoles(&auctions[i])
+	}
 	return auctions, total, nil
 }
 
-// GetAuctionByID извлекает информацию об одном аукционе по его идентификатору, включая лоты.
+// GetAuctionByID извлекает информацию об одном аукционе по его идентификатору, включая лоты, прошедшие модерацию.
 func (s *AuctionService) GetAuctionByID(id uint) (*models.Auction, error) {
-	auction, err := s.auctionStore.GetAuctionByID(id)
+	auction, err := s.auctionStore.GetPublicAuctionByID(id)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения аукциона ID %d: %w", id, err)
 	}
 	if auction == nil {
 		return nil, errors.New("аукцион не найден")
 	}
+	applyAuctionRoles(auction)
 	return auction, nil
 }
 
@@ -124,15 +174,54 @@ func (s *AuctionService) UpdateAuction(auctionID uint, input models.UpdateAuctio
 	if input.Location != nil {
 		auction.Location = *input.Location
 	}
+	if input.Format != nil && *input.Format != auction.Format {
+		format, errFormat := auctionFormatFor(*input.Format)
+		if errFormat != nil {
+			return nil, errFormat
+		}
+		for i := range auction.Lots {
+			if errLot := format.ValidateLot(&auction.Lots[i]); errLot != nil {
+				return nil, errLot
+			}
+		}
+		auction.Format = format.Name()
+	}
+	if input.CategoryID != nil && (auction.CategoryID == nil || *auction.CategoryID != *input.CategoryID) {
+		category, errCategory := getCategory(s.categoryStore, *input.CategoryID)
+		if errCategory != nil {
+			return nil, errCategory
+		}
+		auction.CategoryID = &category.ID
+		auction.Category = category
+		if input.AttributeSchemaID == nil && auction.AttributeSchemaID == nil && len(auction.Lots) == 0 {
+			inherited, errSchema := inheritedSchemaID(s.categoryStore, category.ID)
+			if errSchema != nil {
+				return nil, errSchema
+			}
+			input.AttributeSchemaID = inherited
+		}
+	}
+	if input.AttributeSchemaID != nil && (auction.AttributeSchemaID == nil || *auction.AttributeSchemaID != *input.AttributeSchemaID) {
+		if len(auction.Lots) > 0 {
+			return nil, errors.New("нельзя сменить схему атрибутов: в аукционе уже есть лоты")
+		}
+		schema, errSchema := s.getAttributeSchema(*input.AttributeSchemaID)
+		if errSchema != nil {
+			return nil, errSchema
+		}
+		auction.AttributeSchemaID = &schema.ID
+		auction.AttributeSchema = schema
+	}
 
 	if err := s.auctionStore.UpdateAuction(auction); err != nil {
 		return nil, fmt.Errorf("ошибка обновления аукциона в хранилище: %w", err)
 	}
+	applyAuctionRoles(auction)
 	return auction, nil
 }
 
 // UpdateAuctionStatus обрабатывает логику изменения статуса аукциона.
-func (s *AuctionService) UpdateAuctionStatus(auctionID uint, newStatus models.AuctionStatus, currentUserID uint, currentUserRole models.UserRole) (*models.Auction, error) {
+func (s *AuctionService) UpdateAuctionStatus(auctionID uint, newStatus models.AuctionStatus, currentUserID uint, currentUserRole models.UserRole, clientIP string) (*models.Auction, error) {
 	if currentUserRole != models.RoleSystemAdmin && currentUserRole != models.RoleSeller {
 		return nil, errors.New("недостаточно прав для изменения статуса аукциона")
 	}
@@ -146,6 +235,7 @@ func (s *AuctionService) UpdateAuctionStatus(auctionID uint, newStatus models.Au
 	}
 
 	if auction.Status == newStatus {
+		applyAuctionRoles(auction)
 		return auction, nil
 	}
 	if auction.Status == models.StatusCompleted && newStatus != models.StatusCompleted {
@@ -156,66 +246,68 @@ func (s *AuctionService) UpdateAuctionStatus(auctionID uint, newStatus models.Au
 	}
 
 	var lotsToUpdateInStore []models.Lot
+	var allocationsToCreate []models.LotAllocation
+	var decisions []SettlementDecision
 
 	if newStatus == models.StatusCompleted && auction.Status == models.StatusActive {
-		lotsWonByUsersOnThisAuction := make(map[uint]uint)
-		currentLots := make([]models.Lot, len(auction.Lots))
-		copy(currentLots, auction.Lots)
-
-		for i := range currentLots {
-			lot := &currentLots[i]
-
-			if (lot.Status == models.StatusLotActive || lot.Status == models.StatusPending) && lot.HighestBidderID != nil {
-				buyerID := *lot.HighestBidderID
-				if _, alreadyWon := lotsWonByUsersOnThisAuction[buyerID]; alreadyWon {
-					if lot.Status != models.StatusUnsold {
-						lot.Status = models.StatusUnsold
-						lot.HighestBidderID = nil
-						lot.FinalBuyerID = nil
-						lot.FinalPrice = nil
-						lotsToUpdateInStore = append(lotsToUpdateInStore, *lot)
-					}
-				} else {
-					lot.Status = models.StatusSold
-					lot.FinalPrice = &lot.CurrentPrice
-					lot.FinalBuyerID = lot.HighestBidderID
-					lotsWonByUsersOnThisAuction[buyerID] = lot.ID
-					lotsToUpdateInStore = append(lotsToUpdateInStore, *lot)
-				}
-			} else if lot.Status != models.StatusSold && lot.Status != models.StatusUnsold {
-				lot.Status = models.StatusUnsold
-				lot.HighestBidderID = nil
-				lot.FinalBuyerID = nil
-				lot.FinalPrice = nil
-				lotsToUpdateInStore = append(lotsToUpdateInStore, *lot)
-			}
+		format, errFormat := auctionFormatFor(auction.Format)
+		if errFormat != nil {
+			return nil, errFormat
+		}
+		var errSettle error
+		decisions, errSettle = format.Settle(auction, s.bidStore.GetAllBidsByLotID)
+		if errSettle != nil {
+			return nil, fmt.Errorf("ошибка подведения итогов торгов: %w", errSettle)
+		}
+		for _, decision := range decisions {
+			lotsToUpdateInStore = append(lotsToUpdateInStore, decision.Lot)
+			allocationsToCreate = append(allocationsToCreate, decision.Allocations...)
 		}
 	} else if newStatus == models.StatusActive && auction.Status == models.StatusScheduled {
 		currentLots := make([]models.Lot, len(auction.Lots))
 		copy(currentLots, auction.Lots)
 		for i := range currentLots {
 			lot := &currentLots[i]
-			if lot.Status == models.StatusPending {
+			// лоты, не прошедшие модерацию, в торги не выходят
+			if lot.Status == models.StatusPending && lot.ModerationStatus == models.ModerationApproved {
 				lot.Status = models.StatusLotActive
 				lotsToUpdateInStore = append(lotsToUpdateInStore, *lot)
 			}
 		}
 	}
 
-	err = s.auctionStore.UpdateAuctionStatus(auctionID, newStatus, lotsToUpdateInStore)
+	events, err := buildAuctionStatusEvents(auction, newStatus, lotsToUpdateInStore, decisions)
+	if err != nil {
+		return nil, err
+	}
+	auditEntry := &models.AuditLogEntry{
+		ActorID:    currentUserID,
+		ActorRole:  currentUserRole,
+		Action:     models.AuditAuctionStatusChanged,
+		TargetType: "auction",
+		TargetID:   auctionID,
+		Changes: auditDiff(
+			map[string]interface{}{"status": auction.Status},
+			map[string]interface{}{"status": newStatus},
+		),
+		IPAddress: clientIP,
+	}
+	err = s.auctionStore.UpdateAuctionStatus(auctionID, newStatus, lotsToUpdateInStore, allocationsToCreate, events, auditEntry)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка обновления статуса аукциона и лотов в хранилище: %w", err)
 	}
+	s.outbox.Wake()
 
 	updatedAuction, fetchErr := s.auctionStore.GetAuctionByID(auctionID)
 	if fetchErr != nil {
 		return nil, fmt.Errorf("ошибка получения обновленного аукциона: %w", fetchErr)
 	}
+	applyAuctionRoles(updatedAuction)
 	return updatedAuction, nil
 }
 
 // DeleteAuction управляет логикой удаления аукциона.
-func (s *AuctionService) DeleteAuction(auctionID uint, currentUserID uint, currentUserRole models.UserRole) error {
+func (s *AuctionService) DeleteAuction(auctionID uint, currentUserID uint, currentUserRole models.UserRole, clientIP string) error {
 	auction, err := s.auctionStore.GetAuctionByID(auctionID)
 	if err != nil {
 		return fmt.Errorf("ошибка получения аукциона для удаления: %w", err)
@@ -238,11 +330,25 @@ func (s *AuctionService) DeleteAuction(auctionID uint, currentUserID uint, curre
 		return errors.New("нельзя удалить активный аукцион. Сначала завершите его")
 	}
 
-	return s.auctionStore.DeleteAuction(auctionID)
+	return s.auctionStore.DeleteAuction(auctionID, &models.AuditLogEntry{
+		ActorID:    currentUserID,
+		ActorRole:  currentUserRole,
+		Action:     models.AuditAuctionDeleted,
+		TargetType: "auction",
+		TargetID:   auctionID,
+		Changes: auditDiff(map[string]interface{}{
+			"nameSpecificity": auction.NameSpecificity,
+			"status":          auction.Status,
+			"auctionDate":     auction.AuctionDate.Format("2006-01-02"),
+			"createdByUserId": auction.CreatedByUserID,
+			"lotCount":        len(auction.Lots),
+		}, nil),
+		IPAddress: clientIP,
+	})
 }
 
-// FindAuctionsBySpecificity retrieves auctions based on specificity query.
-func (s *AuctionService) FindAuctionsBySpecificity(query string, page, pageSize int, filters map[string]string) ([]models.Auction, int64, error) {
+// FindAuctionsBySpecificity retrieves auctions by category (including subcategories) and/or specificity query.
+func (s *AuctionService) FindAuctionsBySpecificity(query string, categoryID uint, page, pageSize int, filters map[string]string) ([]models.Auction, int64, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -250,5 +356,90 @@ func (s *AuctionService) FindAuctionsBySpecificity(query string, page, pageSize
 		pageSize = 10
 	}
 	offset := (page - 1) * pageSize
-	return s.auctionStore.FindAuctionsBySpecificity(query, offset, pageSize)
+	if categoryID != 0 {
+		if _, err := getCategory(s.categoryStore, categoryID); err != nil {
+			return nil, 0, err
+		}
+	}
+	auctions, total, err := s.auctionStore.FindAuctionsBySpecificity(query, categoryID, offset, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range auctions {
+		applyAuctionRoles(&auctions[i])
+	}
+	return auctions, total, nil
+}
+
+// buildAuctionStatusEvents готовит доменные события смены статуса аукциона: по каждому лоту, вышедшему
+// в торги или получившему итоги, и по самому аукциону. События записываются в одной транзакции со сменой статуса.
+func buildAuctionStatusEvents(auction *models.Auction, newStatus models.AuctionStatus, updatedLots []models.Lot, decisions []SettlementDecision) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	soldLots, unsoldLots := 0, 0
+	if len(decisions) > 0 {
+		for _, decision := range decisions {
+			lot := decision.Lot
+			if lot.Status == models.StatusSold {
+				soldLots++
+			} else if lot.Status == models.StatusUnsold {
+				unsoldLots++
+			}
+			event, err := newOutboxEvent(models.DomainLotSettled, "lot", lot.ID, models.LotSettledPayload{
+				AuctionID:   auction.ID,
+				AuctionName: auction.NameSpecificity,
+				OrganizerID: auction.CreatedByUserID,
+				LotID:       lot.ID,
+				LotNumber:   lot.LotNumber,
+				LotName:     lot.Name,
+				SellerID:    lot.SellerID,
+				Status:      lot.Status,
+				FinalPrice:  lot.FinalPrice,
+				Allocations: decision.Allocations,
+				Reason:      decision.Reason,
+			})
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+	} else {
+		previousStatuses := make(map[uint]models.LotStatus, len(auction.Lots))
+		for _, lot := range auction.Lots {
+			previousStatuses[lot.ID] = lot.Status
+		}
+		for _, lot := range updatedLots {
+			event, err := newOutboxEvent(models.DomainLotStatusChanged, "lot", lot.ID, models.LotStatusChangedPayload{
+				AuctionID:      auction.ID,
+				LotID:          lot.ID,
+				LotNumber:      lot.LotNumber,
+				LotName:        lot.Name,
+				Status:         lot.Status,
+				PreviousStatus: previousStatuses[lot.ID],
+			})
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+	}
+
+	sellerIDs := make([]uint, 0, len(auction.Lots))
+	for _, lot := range auction.Lots {
+		sellerIDs = append(sellerIDs, lot.SellerID)
+	}
+	event, err := newOutboxEvent(models.DomainAuctionStatusChanged, "auction", auction.ID, models.AuctionStatusChangedPayload{
+		AuctionID:      auction.ID,
+		Name:           auction.NameSpecificity,
+		Location:       auction.Location,
+		OrganizerID:    auction.CreatedByUserID,
+		Status:         newStatus,
+		PreviousStatus: auction.Status,
+		SellerIDs:      sellerIDs,
+		SoldLots:       soldLots,
+		UnsoldLots:     unsoldLots,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(events, event), nil
 }
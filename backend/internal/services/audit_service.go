@@ -0,0 +1,178 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// auditExportBatchSize - сколько записей журнала читается из БД за раз при выгрузке в CSV
+const auditExportBatchSize = 500
+
+// auditCSVHeader - заголовок выгрузки журнала аудита в CSV
+var auditCSVHeader = []string{"id", "createdAt", "actorId", "actorRole", "action", "targetType", "targetId", "changes", "ipAddress"}
+
+// AuditService выдает журнал аудита администраторам. Записи добавляют хранилища в транзакции
+// самого действия (см. insertAuditLogEntry), поэтому действие без записи в журнале не сохраняется.
+type AuditService struct {
+	auditStore store.AuditStore
+}
+
+// NewAuditService создает новый экземпляр AuditService
+func NewAuditService(as store.AuditStore) *AuditService {
+	return &AuditService{auditStore: as}
+}
+
+// auditDiff сравнивает значения полей до и после действия и оставляет только изменившиеся.
+// Для удаления объекта after передается nil: в записи остаются все поля со значением after = null.
+func auditDiff(before, after map[string]interface{}) models.AuditChanges {
+	changes := make(models.AuditChanges)
+	for field, beforeValue := range before {
+		afterValue := after[field]
+		if after != nil && reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+		changes[field] = models.AuditChange{Before: beforeValue, After: afterValue}
+	}
+	for field, afterValue := range after {
+		if _, ok := before[field]; !ok {
+			changes[field] = models.AuditChange{Before: nil, After: afterValue}
+		}
+	}
+	return changes
+}
+
+// normalizeAuditFilters проверяет фильтры журнала и приводит границы интервала к RFC 3339.
+// Границы from и to принимаются как дата ГГГГ-ММ-ДД или момент времени в RFC 3339; дата в to включается целиком.
+func normalizeAuditFilters(filters map[string]string) (map[string]string, error) {
+	normalized := make(map[string]string, len(filters))
+	for _, name := range []string{"actorId", "targetId"} {
+		if value := filters[name]; value != "" {
+			if _, err := strconv.ParseUint(value, 10, 32); err != nil {
+				return nil, fmt.Errorf("некорректный фильтр %s: ожидается числовой ID", name)
+			}
+			normalized[name] = value
+		}
+	}
+	if action := filters["action"]; action != "" {
+		valid := false
+		for _, known := range models.AuditActions {
+			if models.AuditAction(action) == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("некорректный фильтр action: %s", action)
+		}
+		normalized["action"] = action
+	}
+	if targetType := filters["targetType"]; targetType != "" {
+		normalized["targetType"] = targetType
+	}
+	for _, name := range []string{"from", "to"} {
+		value := filters[name]
+		if value == "" {
+			continue
+		}
+		moment, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			day, errDay := time.Parse("2006-01-02", value)
+			if errDay != nil {
+				return nil, fmt.Errorf("некорректный фильтр %s: ожидается дата ГГГГ-ММ-ДД или время в формате RFC 3339", name)
+			}
+			moment = day
+			if name == "to" {
+				moment = day.AddDate(0, 0, 1)
+			}
+		}
+		normalized[name] = moment.Format(time.RFC3339)
+	}
+	return normalized, nil
+}
+
+// GetAuditLog возвращает страницу журнала аудита (только для системного администратора)
+func (s *AuditService) GetAuditLog(page, pageSize int, filters map[string]string, currentUserRole models.UserRole) ([]models.AuditLogEntry, int64, error) {
+	if currentUserRole != models.RoleSystemAdmin {
+		return nil, 0, errors.New("недостаточно прав для просмотра журнала аудита")
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	normalized, err := normalizeAuditFilters(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	entries, total, err := s.auditStore.GetAuditLogEntries((page-1)*pageSize, pageSize, normalized)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения журнала аудита: %w", err)
+	}
+	return entries, total, nil
+}
+
+// ExportAuditLogCSV выгружает в w все записи журнала аудита, подходящие под фильтры, в формате CSV
+// (только для системного администратора). Права и фильтры проверяются до записи первой строки.
+func (s *AuditService) ExportAuditLogCSV(w io.Writer, filters map[string]string, currentUserRole models.UserRole) error {
+	if currentUserRole != models.RoleSystemAdmin {
+		return errors.New("недостаточно прав для выгрузки журнала аудита")
+	}
+	normalized, err := normalizeAuditFilters(filters)
+	if err != nil {
+		return err
+	}
+
+	// заголовок пишется вместе с первой порцией записей: если журнал не удалось прочитать,
+	// в w еще ничего не записано и обработчик может вернуть ошибку
+	writer := csv.NewWriter(w)
+	headerWritten := false
+	err = s.auditStore.ForEachAuditLogEntryBatch(normalized, auditExportBatchSize, func(entries []models.AuditLogEntry) error {
+		if !headerWritten {
+			if err := writer.Write(auditCSVHeader); err != nil {
+				return err
+			}
+			headerWritten = true
+		}
+		for _, entry := range entries {
+			changes, err := json.Marshal(entry.Changes)
+			if err != nil {
+				return err
+			}
+			err = writer.Write([]string{
+				strconv.FormatUint(uint64(entry.ID), 10),
+				entry.CreatedAt.UTC().Format(time.RFC3339),
+				strconv.FormatUint(uint64(entry.ActorID), 10),
+				string(entry.ActorRole),
+				string(entry.Action),
+				entry.TargetType,
+				strconv.FormatUint(uint64(entry.TargetID), 10),
+				string(changes),
+				entry.IPAddress,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка чтения журнала аудита: %w", err)
+	}
+	if !headerWritten {
+		if err := writer.Write(auditCSVHeader); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
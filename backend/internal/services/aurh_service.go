@@ -6,23 +6,36 @@ import (
 	"auction-app/backend/internal/models"
 	"auction-app/backend/internal/store"
 	"auction-app/backend/internal/utils"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 )
 
+// Причины отзыва сессий, сохраняемые в UserSession.RevokedReason
+const (
+	sessionRevokedLogout     = "выход из системы"
+	sessionRevokedLogoutAll  = "выход на всех устройствах"
+	sessionRevokedTokenReuse = "повторное использование refresh-токена"
+	sessionRevokedInactive   = "учетная запись неактивна"
+	sessionRevokedRole       = "активная роль больше недоступна"
+)
+
 // AuthService предоставляет методы для аутентификации и регистрации
 type AuthService struct {
-	userStore store.UserStore
-	cfg       *config.Config
+	userStore    store.UserStore
+	sessionStore store.SessionStore
+	cfg          *config.Config
 }
 
 // NewAuthService создает новый экземпляр AuthService
-func NewAuthService(userStore store.UserStore, cfg *config.Config) *AuthService {
-	return &AuthService{userStore: userStore, cfg: cfg}
+func NewAuthService(userStore store.UserStore, sessionStore store.SessionStore, cfg *config.Config) *AuthService {
+	return &AuthService{userStore: userStore, sessionStore: sessionStore, cfg: cfg}
 }
 
 // RegisterUser регистрирует нового пользователя
@@ -69,71 +82,260 @@ func (s *AuthService) RegisterUser(input models.RegisterUserInput) (*models.User
 	return &user, nil
 }
 
-// LoginUser аутентифицирует пользователя и возвращает JWT токен, данные пользователя и активную роль
-func (s *AuthService) LoginUser(input models.LoginInput, chosenRole models.UserRole) (string, *models.User, models.UserRole, error) {
+// LoginUser аутентифицирует пользователя, открывает новую сессию и возвращает пару токенов,
+// данные пользователя и активную роль
+func (s *AuthService) LoginUser(input models.LoginInput, chosenRole models.UserRole, userAgent, clientIP string) (*models.AuthTokens, *models.User, models.UserRole, error) {
 	log.Printf("[AuthService] Login attempt for email: %s, chosenRole: %s", input.Email, chosenRole)
 	user, err := s.userStore.GetUserByEmail(input.Email)
 	if err != nil {
 		log.Printf("[AuthService] Error getting user by email %s: %v", input.Email, err)
-		return "", nil, "", fmt.Errorf("ошибка получения пользователя по email: %w", err)
+		return nil, nil, "", fmt.Errorf("ошибка получения пользователя по email: %w", err)
 	}
 	if user == nil {
 		log.Printf("[AuthService] User not found for email: %s", input.Email)
-		return "", nil, "", errors.New("пользователь с таким email не найден")
+		return nil, nil, "", errors.New("пользователь с таким email не найден")
 	}
 	log.Printf("[AuthService] User found: ID %d, Main Role from DB: %s, IsActive: %t", user.ID, user.Role, user.IsActive)
 
 	if !user.IsActive {
 		log.Printf("[AuthService] Login attempt for inactive user: %s", input.Email)
-		return "", nil, "", errors.New("учетная запись пользователя неактивна")
+		return nil, nil, "", errors.New("учетная запись пользователя неактивна")
 	}
 
 	if !user.CheckPassword(input.Password) {
 		log.Printf("[AuthService] Invalid password for user: %s", input.Email)
-		return "", nil, "", errors.New("неверный пароль")
+		return nil, nil, "", errors.New("неверный пароль")
 	}
 	log.Printf("[AuthService] Password check passed for user: %s", input.Email)
 
-	finalActiveRole := chosenRole
+	finalActiveRole, err := resolveActiveRole(user, chosenRole)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	refreshToken, refreshTokenRecord, err := s.newRefreshToken()
+	if err != nil {
+		log.Printf("[AuthService] Error generating refresh token for user %s: %v", input.Email, err)
+		return nil, nil, "", fmt.Errorf("ошибка генерации refresh-токена: %w", err)
+	}
+	session := models.UserSession{
+		UserID:     user.ID,
+		ActiveRole: finalActiveRole,
+		UserAgent:  userAgent,
+		IPAddress:  clientIP,
+		LastUsedAt: time.Now(),
+	}
+	if err := s.sessionStore.CreateSession(&session, refreshTokenRecord); err != nil {
+		log.Printf("[AuthService] Error creating session for user %s: %v", input.Email, err)
+		return nil, nil, "", fmt.Errorf("ошибка создания сессии: %w", err)
+	}
+
+	accessToken, accessTokenExpiresAt, err := utils.GenerateJWT(user.ID, string(finalActiveRole), session.ID, s.cfg)
+	if err != nil {
+		log.Printf("[AuthService] Error generating JWT for user %s: %v", input.Email, err)
+		return nil, nil, "", fmt.Errorf("ошибка генерации JWT токена: %w", err)
+	}
+	log.Printf("[AuthService] Session %d opened for user: %s, activeRole in token: %s", session.ID, input.Email, finalActiveRole)
+
+	user.PasswordHash = ""
+	tokens := &models.AuthTokens{AccessToken: accessToken, AccessTokenExpiresAt: accessTokenExpiresAt, RefreshToken: refreshToken}
+	return tokens, user, finalActiveRole, nil
+}
 
+// resolveActiveRole определяет активную роль сессии: системный администратор всегда работает в своей роли,
+// остальные пользователи - в выбранной бизнес-роли, если она есть среди доступных им
+func resolveActiveRole(user *models.User, chosenRole models.UserRole) (models.UserRole, error) {
 	if user.Role == models.RoleSystemAdmin {
-		finalActiveRole = models.RoleSystemAdmin
-		log.Printf("[AuthService] User %s is SYSTEM_ADMIN. Active role forced to: %s", input.Email, finalActiveRole)
-	} else {
-		if chosenRole == "" {
-			log.Printf("[AuthService] No role chosen for non-admin user: %s", input.Email)
-			return "", nil, "", errors.New("активная роль не была выбрана")
-		}
-		var availableRoles []string
-		if errUnmarshal := json.Unmarshal([]byte(user.AvailableBusinessRoles), &availableRoles); errUnmarshal != nil {
-			log.Printf("[AuthService] Error unmarshalling AvailableBusinessRoles for user %s: %v. Stored JSON: %s", user.Email, errUnmarshal, user.AvailableBusinessRoles)
-			return "", nil, "", errors.New("ошибка определения доступных ролей пользователя")
-		}
+		log.Printf("[AuthService] User %s is SYSTEM_ADMIN. Active role forced to: %s", user.Email, models.RoleSystemAdmin)
+		return models.RoleSystemAdmin, nil
+	}
+	if chosenRole == "" {
+		log.Printf("[AuthService] No role chosen for non-admin user: %s", user.Email)
+		return "", errors.New("активная роль не была выбрана")
+	}
+	var availableRoles []string
+	if errUnmarshal := json.Unmarshal([]byte(user.AvailableBusinessRoles), &availableRoles); errUnmarshal != nil {
+		log.Printf("[AuthService] Error unmarshalling AvailableBusinessRoles for user %s: %v. Stored JSON: %s", user.Email, errUnmarshal, user.AvailableBusinessRoles)
+		return "", errors.New("ошибка определения доступных ролей пользователя")
+	}
 
-		isRoleAllowed := false
-		for _, ar := range availableRoles {
-			if ar == string(chosenRole) {
-				isRoleAllowed = true
-				break
-			}
+	for _, ar := range availableRoles {
+		if ar == string(chosenRole) {
+			log.Printf("[AuthService] User %s. Chosen active role: %s. Available roles: %v", user.Email, chosenRole, availableRoles)
+			return chosenRole, nil
 		}
+	}
+	log.Printf("[AuthService] Chosen role '%s' is not available for user %s. Available: %v", chosenRole, user.Email, availableRoles)
+	return "", errors.New("выбранная роль недоступна для этого пользователя")
+}
+
+// hashRefreshToken возвращает SHA-256 хеш refresh-токена; в БД хранится только он
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newRefreshToken генерирует случайный refresh-токен и запись для его хранения (без привязки к сессии)
+func (s *AuthService) newRefreshToken() (string, *models.RefreshToken, error) {
+	token, err := randomHex(32)
+	if err != nil {
+		return "", nil, err
+	}
+	record := &models.RefreshToken{
+		TokenHash: hashRefreshToken(token),
+		ExpiresAt: time.Now().Add(time.Duration(s.cfg.RefreshTokenTTLHours) * time.Hour),
+	}
+	return token, record, nil
+}
+
+// revokeSession отзывает сессию, ошибка отзыва только записывается в журнал приложения
+func (s *AuthService) revokeSession(sessionID uint, reason string) {
+	if err := s.sessionStore.RevokeSession(sessionID, reason, time.Now()); err != nil {
+		log.Printf("[AuthService] Не удалось отозвать сессию %d (%s): %v", sessionID, reason, err)
+	}
+}
 
-		if !isRoleAllowed {
-			log.Printf("[AuthService] Chosen role '%s' is not available for user %s. Available: %v", chosenRole, input.Email, availableRoles)
-			return "", nil, "", errors.New("выбранная роль недоступна для этого пользователя")
+// RefreshSession обменивает refresh-токен на новую пару токенов той же сессии. Использованный refresh-токен
+// больше не принимается; его повторное предъявление считается утечкой, и сессия отзывается целиком.
+// Перед выдачей токенов заново проверяются статус пользователя и доступность активной роли.
+func (s *AuthService) RefreshSession(refreshToken string) (*models.AuthTokens, models.UserRole, error) {
+	record, err := s.sessionStore.GetRefreshTokenByHash(hashRefreshToken(refreshToken))
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка получения refresh-токена: %w", err)
+	}
+	if record == nil {
+		return nil, "", errors.New("недействительный refresh-токен")
+	}
+	session, err := s.sessionStore.GetSessionByID(record.SessionID)
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка получения сессии: %w", err)
+	}
+	if session == nil || session.RevokedAt != nil {
+		return nil, "", errors.New("сессия отозвана, войдите заново")
+	}
+	if record.UsedAt != nil {
+		log.Printf("[AuthService] Refresh token of session %d (user %d) was presented again, revoking session", session.ID, session.UserID)
+		s.revokeSession(session.ID, sessionRevokedTokenReuse)
+		return nil, "", errors.New("refresh-токен уже использован, сессия отозвана")
+	}
+	if time.Now().After(record.ExpiresAt) {
+		return nil, "", errors.New("срок действия refresh-токена истек, войдите заново")
+	}
+
+	user, err := s.userStore.GetUserByID(session.UserID)
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+	if user == nil || !user.IsActive {
+		s.revokeSession(session.ID, sessionRevokedInactive)
+		return nil, "", errors.New("учетная запись пользователя неактивна")
+	}
+	if _, err := resolveActiveRole(user, session.ActiveRole); err != nil {
+		s.revokeSession(session.ID, sessionRevokedRole)
+		return nil, "", err
+	}
+
+	nextToken, nextRecord, err := s.newRefreshToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка генерации refresh-токена: %w", err)
+	}
+	nextRecord.SessionID = session.ID
+	rotated, err := s.sessionStore.RotateRefreshToken(record.ID, nextRecord, time.Now())
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка обновления сессии: %w", err)
+	}
+	if !rotated {
+		// тот же токен успели использовать параллельным запросом
+		log.Printf("[AuthService] Refresh token of session %d (user %d) was used concurrently, revoking session", session.ID, session.UserID)
+		s.revokeSession(session.ID, sessionRevokedTokenReuse)
+		return nil, "", errors.New("refresh-токен уже использован, сессия отозвана")
+	}
+
+	accessToken, accessTokenExpiresAt, err := utils.GenerateJWT(user.ID, string(session.ActiveRole), session.ID, s.cfg)
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка генерации JWT токена: %w", err)
+	}
+	tokens := &models.AuthTokens{AccessToken: accessToken, AccessTokenExpiresAt: accessTokenExpiresAt, RefreshToken: nextToken}
+	return tokens, session.ActiveRole, nil
+}
+
+// ValidateSession проверяет, что сессия access-токена не отозвана, ее пользователь не заблокирован,
+// а роль токена совпадает с активной ролью сессии (после смены роли прежние токены не принимаются)
+func (s *AuthService) ValidateSession(userID, sessionID uint, role string) error {
+	session, err := s.sessionStore.GetSessionByID(sessionID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения сессии: %w", err)
+	}
+	if session == nil || session.UserID != userID || session.RevokedAt != nil {
+		return errors.New("сессия отозвана, войдите заново")
+	}
+	if string(session.ActiveRole) != role {
+		return errors.New("активная роль сессии изменена, обновите токен")
+	}
+	user, err := s.userStore.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+	if user == nil || !user.IsActive {
+		return errors.New("учетная запись пользователя неактивна")
+	}
+	return nil
+}
+
+// SwitchRole меняет активную бизнес-роль текущей сессии без повторного входа и выдает access-токен с новой ролью.
+// Роль проверяется по доступным бизнес-ролям пользователя; refresh-токен сессии остается прежним.
+func (s *AuthService) SwitchRole(userID, sessionID uint, requestedRole models.UserRole) (string, time.Time, models.UserRole, error) {
+	user, err := s.userStore.GetUserByID(userID)
+	if err != nil {
+		return "", time.Time{}, "", fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+	if user == nil || !user.IsActive {
+		return "", time.Time{}, "", errors.New("учетная запись пользователя неактивна")
+	}
+	if user.Role == models.RoleSystemAdmin {
+		return "", time.Time{}, "", errors.New("системный администратор не может сменить активную роль")
+	}
+	activeRole, err := resolveActiveRole(user, requestedRole)
+	if err != nil {
+		return "", time.Time{}, "", err
+	}
+
+	session, err := s.sessionStore.GetSessionByID(sessionID)
+	if err != nil {
+		return "", time.Time{}, "", fmt.Errorf("ошибка получения сессии: %w", err)
+	}
+	if session == nil || session.UserID != userID || session.RevokedAt != nil {
+		return "", time.Time{}, "", errors.New("сессия отозвана, войдите заново")
+	}
+	if session.ActiveRole != activeRole {
+		if err := s.sessionStore.UpdateSessionRole(session.ID, activeRole); err != nil {
+			return "", time.Time{}, "", fmt.Errorf("ошибка смены активной роли: %w", err)
 		}
-		log.Printf("[AuthService] User %s. Chosen active role: %s. Available roles: %v", input.Email, finalActiveRole, availableRoles)
+		log.Printf("[AuthService] Session %d of user %d switched active role: %s -> %s", session.ID, userID, session.ActiveRole, activeRole)
 	}
 
-	token, err := utils.GenerateJWT(user.ID, string(finalActiveRole), s.cfg)
+	accessToken, accessTokenExpiresAt, err := utils.GenerateJWT(userID, string(activeRole), session.ID, s.cfg)
 	if err != nil {
-		log.Printf("[AuthService] Error generating JWT for user %s: %v", input.Email, err)
-		return "", nil, "", fmt.Errorf("ошибка генерации JWT токена: %w", err)
+		return "", time.Time{}, "", fmt.Errorf("ошибка генерации JWT токена: %w", err)
 	}
-	log.Printf("[AuthService] JWT generated successfully for user: %s, activeRole in token: %s", input.Email, finalActiveRole)
+	return accessToken, accessTokenExpiresAt, activeRole, nil
+}
 
-	user.PasswordHash = ""
-	return token, user, finalActiveRole, nil
+// Logout завершает текущую сессию: ее access- и refresh-токены перестают приниматься
+func (s *AuthService) Logout(sessionID uint) error {
+	if err := s.sessionStore.RevokeSession(sessionID, sessionRevokedLogout, time.Now()); err != nil {
+		return fmt.Errorf("ошибка завершения сессии: %w", err)
+	}
+	return nil
+}
+
+// LogoutAll завершает все сессии пользователя, включая текущую, и возвращает их число
+func (s *AuthService) LogoutAll(userID uint) (int64, error) {
+	revoked, err := s.sessionStore.RevokeUserSessions(userID, sessionRevokedLogoutAll, time.Now(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка завершения сессий: %w", err)
+	}
+	return revoked, nil
 }
 
 func (s *AuthService) ValidateToken(tokenString string) (jwt.MapClaims, error) {
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"errors"
+	"fmt"
+)
+
+// BlocklistService управляет черными списками участников, которые ведут продавцы и организаторы аукционов
+type BlocklistService struct {
+	blocklistStore store.BlocklistStore
+	userStore      store.UserStore
+}
+
+// NewBlocklistService создает новый экземпляр BlocklistService
+func NewBlocklistService(bls store.BlocklistStore, us store.UserStore) *BlocklistService {
+	return &BlocklistService{blocklistStore: bls, userStore: us}
+}
+
+// BlockBidder добавляет пользователя в черный список текущего продавца
+func (s *BlocklistService) BlockBidder(ownerID uint, ownerRole models.UserRole, input models.AddBlockedBidderInput) (*models.BlockedBidder, error) {
+	if ownerRole != models.RoleSeller && ownerRole != models.RoleSystemAdmin {
+		return nil, errors.New("недостаточно прав: вести черный список может только продавец или организатор аукциона")
+	}
+	if input.UserID == ownerID {
+		return nil, errors.New("нельзя внести в черный список самого себя")
+	}
+
+	user, err := s.userStore.GetUserByID(input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+	if user.Role == models.RoleSystemAdmin {
+		return nil, errors.New("нельзя внести в черный список системного администратора")
+	}
+
+	existing, err := s.blocklistStore.GetBlockedBidder(ownerID, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка проверки черного списка: %w", err)
+	}
+	if existing != nil {
+		return nil, errors.New("пользователь уже в черном списке")
+	}
+
+	entry := models.BlockedBidder{
+		OwnerID:       ownerID,
+		BlockedUserID: input.UserID,
+		Reason:        input.Reason,
+	}
+	if err := s.blocklistStore.AddBlockedBidder(&entry); err != nil {
+		return nil, fmt.Errorf("ошибка добавления в черный список: %w", err)
+	}
+	user.PasswordHash = ""
+	entry.BlockedUser = user
+	return &entry, nil
+}
+
+// UnblockBidder удаляет пользователя из черного списка текущего продавца
+func (s *BlocklistService) UnblockBidder(ownerID uint, blockedUserID uint) error {
+	return s.blocklistStore.RemoveBlockedBidder(ownerID, blockedUserID)
+}
+
+// GetMyBlocklist возвращает черный список текущего продавца
+func (s *BlocklistService) GetMyBlocklist(ownerID uint, page, pageSize int) ([]models.BlockedBidder, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	} else if pageSize > 100 {
+		pageSize = 100
+	}
+	offset := (page - 1) * pageSize
+
+	entries, total, err := s.blocklistStore.GetBlockedBiddersByOwnerID(ownerID, offset, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения черного списка: %w", err)
+	}
+	return entries, total, nil
+}
+
+// GetAllBlocklists возвращает записи черных списков всех продавцов (для администратора)
+func (s *BlocklistService) GetAllBlocklists(page, pageSize int, filters map[string]string, currentUserRole models.UserRole) ([]models.BlockedBidder, int64, error) {
+	if currentUserRole != models.RoleSystemAdmin {
+		return nil, 0, errors.New("недостаточно прав для просмотра черных списков")
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	entries, total, err := s.blocklistStore.GetAllBlockedBidders(offset, pageSize, filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения черных списков: %w", err)
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+const (
+	catalogueFont        = "go"
+	catalogueImageSize   = 45.0 // сторона области под фотографию лота, мм
+	catalogueLineHeight  = 5.0
+	catalogueBlockMargin = 6.0
+)
+
+// CatalogueService формирует печатный каталог аукциона в формате PDF
+type CatalogueService struct {
+	auctionStore  store.AuctionStore
+	lotImageStore store.LotImageStore
+	blobStore     store.BlobStore
+}
+
+// NewCatalogueService создает новый экземпляр CatalogueService
+func NewCatalogueService(as store.AuctionStore, lis store.LotImageStore, bs store.BlobStore) *CatalogueService {
+	return &CatalogueService{auctionStore: as, lotImageStore: lis, blobStore: bs}
+}
+
+// GenerateAuctionCatalogue формирует PDF-каталог аукциона: шапка с названием, датой и местом
+// проведения и все лоты по порядку номеров с описанием, оценкой, стартовой ценой и основной фотографией.
+func (s *CatalogueService) GenerateAuctionCatalogue(auctionID uint, w io.Writer) error {
+	auction, err := s.auctionStore.GetAuctionByID(auctionID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения аукциона: %w", err)
+	}
+	if auction == nil {
+		return errors.New("аукцион не найден")
+	}
+	lots := make([]models.Lot, 0, len(auction.Lots))
+	for _, lot := range auction.Lots {
+		if lot.Status != models.StatusWithdrawn && lot.ModerationStatus == models.ModerationApproved {
+			lots = append(lots, lot)
+		}
+	}
+	sort.Slice(lots, func(i, j int) bool { return lots[i].LotNumber < lots[j].LotNumber })
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(catalogueFont, "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes(catalogueFont, "B", gobold.TTF)
+	pdf.SetTitle(auction.NameSpecificity, true)
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AliasNbPages("{nb}")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont(catalogueFont, "", 8)
+		pdf.CellFormat(0, 10, fmt.Sprintf("%s — стр. %d из {nb}", auction.NameSpecificity, pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	s.writeCatalogueHeader(pdf, auction, len(lots))
+	for i := range lots {
+		s.writeCatalogueLot(pdf, &lots[i])
+	}
+
+	if pdf.Err() {
+		return fmt.Errorf("ошибка формирования PDF: %w", pdf.Error())
+	}
+	return pdf.Output(w)
+}
+
+func (s *CatalogueService) writeCatalogueHeader(pdf *gofpdf.Fpdf, auction *models.Auction, lotCount int) {
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	contentWidth := pageWidth - left - right
+
+	pdf.SetFont(catalogueFont, "B", 18)
+	pdf.MultiCell(contentWidth, 9, auction.NameSpecificity, "", "C", false)
+	pdf.Ln(2)
+
+	pdf.SetFont(catalogueFont, "", 11)
+	pdf.CellFormat(contentWidth, 6, fmt.Sprintf("Дата проведения: %s, %s", auction.AuctionDate.Format("02.01.2006"), auction.AuctionTime), "", 1, "C", false, 0, "")
+	pdf.CellFormat(contentWidth, 6, "Место проведения: "+auction.Location, "", 1, "C", false, 0, "")
+	pdf.CellFormat(contentWidth, 6, fmt.Sprintf("Лотов в каталоге: %d", lotCount), "", 1, "C", false, 0, "")
+	if auction.DescriptionFull != "" {
+		pdf.Ln(3)
+		pdf.SetFont(catalogueFont, "", 10)
+		pdf.MultiCell(contentWidth, catalogueLineHeight, auction.DescriptionFull, "", "J", false)
+	}
+
+	pdf.Ln(4)
+	y := pdf.GetY()
+	pdf.Line(left, y, pageWidth-right, y)
+	pdf.Ln(catalogueBlockMargin)
+}
+
+func (s *CatalogueService) writeCatalogueLot(pdf *gofpdf.Fpdf, lot *models.Lot) {
+	pageWidth, pageHeight := pdf.GetPageSize()
+	left, _, right, bottom := pdf.GetMargins()
+	textX := left + catalogueImageSize + 5
+	textWidth := pageWidth - right - textX
+
+	details := []string{fmt.Sprintf("Стартовая цена: %s", formatCatalogueMoney(lot.StartPrice))}
+	if lot.EstimateLow != nil && lot.EstimateHigh != nil {
+		details = append(details, fmt.Sprintf("Оценка: %s – %s", formatCatalogueMoney(*lot.EstimateLow), formatCatalogueMoney(*lot.EstimateHigh)))
+	}
+	if lot.Quantity > 1 {
+		details = append(details, fmt.Sprintf("Количество: %d ед. (цены указаны за единицу)", lot.Quantity))
+	}
+
+	// Переносим блок лота на новую страницу целиком, если он не помещается
+	pdf.SetFont(catalogueFont, "", 10)
+	descriptionLines := 0
+	if lot.Description != "" {
+		descriptionLines = len(pdf.SplitText(lot.Description, textWidth))
+	}
+	pdf.SetFont(catalogueFont, "B", 12)
+	titleLines := len(pdf.SplitText(fmt.Sprintf("Лот № %d. %s", lot.LotNumber, lot.Name), textWidth))
+	textHeight := float64(titleLines)*6 + float64(descriptionLines+len(details))*catalogueLineHeight + 2
+	blockHeight := textHeight
+	if blockHeight < catalogueImageSize {
+		blockHeight = catalogueImageSize
+	}
+	if pdf.GetY()+blockHeight > pageHeight-bottom-5 && blockHeight < pageHeight-bottom-30 {
+		pdf.AddPage()
+	}
+
+	top := pdf.GetY()
+	s.drawPrimaryImage(pdf, lot.ID, left, top)
+
+	pdf.SetXY(textX, top)
+	pdf.SetFont(catalogueFont, "B", 12)
+	pdf.MultiCell(textWidth, 6, fmt.Sprintf("Лот № %d. %s", lot.LotNumber, lot.Name), "", "L", false)
+	if lot.Description != "" {
+		pdf.SetX(textX)
+		pdf.SetFont(catalogueFont, "", 10)
+		pdf.MultiCell(textWidth, catalogueLineHeight, lot.Description, "", "L", false)
+	}
+	pdf.Ln(2)
+	pdf.SetFont(catalogueFont, "B", 10)
+	for _, line := range details {
+		pdf.SetX(textX)
+		pdf.CellFormat(textWidth, catalogueLineHeight, line, "", 1, "L", false, 0, "")
+	}
+
+	if pdf.GetY() > top && pdf.GetY() < top+catalogueImageSize {
+		pdf.SetY(top + catalogueImageSize)
+	}
+	pdf.Ln(catalogueBlockMargin / 2)
+	y := pdf.GetY()
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.Line(left, y, pageWidth-right, y)
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.Ln(catalogueBlockMargin / 2)
+}
+
+// drawPrimaryImage выводит миниатюру основной фотографии лота или пустую рамку, если фотографии нет
+func (s *CatalogueService) drawPrimaryImage(pdf *gofpdf.Fpdf, lotID uint, x, y float64) {
+	image, data := s.loadPrimaryThumbnail(lotID)
+	if image == nil {
+		pdf.SetDrawColor(200, 200, 200)
+		pdf.Rect(x, y, catalogueImageSize, catalogueImageSize, "D")
+		pdf.SetDrawColor(0, 0, 0)
+		pdf.SetFont(catalogueFont, "", 8)
+		pdf.SetXY(x, y+catalogueImageSize/2-3)
+		pdf.CellFormat(catalogueImageSize, 6, "нет фотографии", "", 0, "C", false, 0, "")
+		return
+	}
+
+	name := fmt.Sprintf("lot-%d-image-%d", lotID, image.ID)
+	info := pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: "JPG"}, bytes.NewReader(data))
+	if pdf.Err() || info == nil {
+		log.Printf("Не удалось добавить фотографию %d лота %d в каталог: %v", image.ID, lotID, pdf.Error())
+		pdf.ClearError()
+		return
+	}
+
+	// Вписываем фотографию в квадрат с сохранением пропорций
+	w, h := info.Width(), info.Height()
+	scale := catalogueImageSize / w
+	if h*scale > catalogueImageSize {
+		scale = catalogueImageSize / h
+	}
+	drawW, drawH := w*scale, h*scale
+	pdf.ImageOptions(name, x+(catalogueImageSize-drawW)/2, y+(catalogueImageSize-drawH)/2, drawW, drawH, false, gofpdf.ImageOptions{ImageType: "JPG"}, 0, "")
+}
+
+func (s *CatalogueService) loadPrimaryThumbnail(lotID uint) (*models.LotImage, []byte) {
+	images, err := s.lotImageStore.GetLotImages(lotID)
+	if err != nil || len(images) == 0 {
+		return nil, nil
+	}
+	primary := &images[0]
+	for i := range images {
+		if images[i].IsPrimary {
+			primary = &images[i]
+			break
+		}
+	}
+
+	// Миниатюры всегда хранятся в JPEG и достаточно малы для печати в размере каталога
+	reader, err := s.blobStore.Open(primary.ThumbnailKey)
+	if err != nil {
+		log.Printf("Не удалось открыть миниатюру фотографии %d лота %d: %v", primary.ID, lotID, err)
+		return nil, nil
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil
+	}
+	return primary, data
+}
+
+// formatCatalogueMoney форматирует сумму с разделителем разрядов: 12 500,00 руб.
+func formatCatalogueMoney(amount float64) string {
+	raw := fmt.Sprintf("%.2f", amount)
+	intPart, fracPart := raw[:len(raw)-3], raw[len(raw)-2:]
+	negative := strings.HasPrefix(intPart, "-")
+	intPart = strings.TrimPrefix(intPart, "-")
+
+	var grouped strings.Builder
+	for i, digit := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			grouped.WriteRune(' ')
+		}
+		grouped.WriteRune(digit)
+	}
+	result := grouped.String() + "," + fracPart + " руб."
+	if negative {
+		result = "-" + result
+	}
+	return result
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CategoryService управляет иерархическим рубрикатором аукционов и лотов
+type CategoryService struct {
+	categoryStore store.CategoryStore
+	schemaStore   store.AttributeSchemaStore
+}
+
+// NewCategoryService создает новый экземпляр CategoryService
+func NewCategoryService(cs store.CategoryStore, ss store.AttributeSchemaStore) *CategoryService {
+	return &CategoryService{categoryStore: cs, schemaStore: ss}
+}
+
+// GetCategoryTree возвращает рубрикатор в виде дерева (корневые рубрики с вложенными подрубриками)
+func (s *CategoryService) GetCategoryTree() ([]models.Category, error) {
+	categories, err := s.categoryStore.GetAllCategories()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения рубрик: %w", err)
+	}
+
+	childrenByParent := make(map[uint][]models.Category)
+	var roots []models.Category
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+		} else {
+			childrenByParent[*category.ParentID] = append(childrenByParent[*category.ParentID], category)
+		}
+	}
+
+	var attach func(nodes []models.Category) []models.Category
+	attach = func(nodes []models.Category) []models.Category {
+		for i := range nodes {
+			nodes[i].Children = attach(childrenByParent[nodes[i].ID])
+		}
+		return nodes
+	}
+	return attach(roots), nil
+}
+
+// GetCategoryByID возвращает рубрику вместе с непосредственными подрубриками
+func (s *CategoryService) GetCategoryByID(id uint) (*models.Category, error) {
+	category, err := getCategory(s.categoryStore, id)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := s.GetCategoryTree()
+	if err != nil {
+		return nil, err
+	}
+	if node := findCategoryNode(tree, id); node != nil {
+		category.Children = node.Children
+	}
+	return category, nil
+}
+
+func findCategoryNode(nodes []models.Category, id uint) *models.Category {
+	for i := range nodes {
+		if nodes[i].ID == id {
+			return &nodes[i]
+		}
+		if found := findCategoryNode(nodes[i].Children, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// CreateCategory создает рубрику (только для администратора)
+func (s *CategoryService) CreateCategory(input models.CreateCategoryInput, currentUserRole models.UserRole) (*models.Category, error) {
+	if currentUserRole != models.RoleSystemAdmin {
+		return nil, errors.New("недостаточно прав для управления рубриками")
+	}
+	name := strings.TrimSpace(input.Name)
+
+	if input.ParentID != nil {
+		if _, err := getCategory(s.categoryStore, *input.ParentID); err != nil {
+			return nil, fmt.Errorf("родительская %w", err)
+		}
+	}
+	if err := s.checkNameUnique(input.ParentID, name, 0); err != nil {
+		return nil, err
+	}
+
+	category := models.Category{
+		Name:        name,
+		Description: input.Description,
+		ParentID:    input.ParentID,
+	}
+	if input.AttributeSchemaID != nil {
+		schema, err := s.getSchema(*input.AttributeSchemaID)
+		if err != nil {
+			return nil, err
+		}
+		category.AttributeSchemaID = &schema.ID
+	}
+
+	if err := s.categoryStore.CreateCategory(&category); err != nil {
+		return nil, fmt.Errorf("ошибка создания рубрики: %w", err)
+	}
+	return getCategory(s.categoryStore, category.ID)
+}
+
+// UpdateCategory обновляет рубрику (только для администратора), не допуская циклов в иерархии
+func (s *CategoryService) UpdateCategory(id uint, input models.UpdateCategoryInput, currentUserRole models.UserRole) (*models.Category, error) {
+	if currentUserRole != models.RoleSystemAdmin {
+		return nil, errors.New("недостаточно прав для управления рубриками")
+	}
+	category, err := getCategory(s.categoryStore, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ParentID != nil {
+		if *input.ParentID == 0 {
+			category.ParentID = nil
+		} else {
+			if _, err := getCategory(s.categoryStore, *input.ParentID); err != nil {
+				return nil, fmt.Errorf("родительская %w", err)
+			}
+			subtree, err := s.categoryStore.GetCategorySubtreeIDs(id)
+			if err != nil {
+				return nil, fmt.Errorf("ошибка получения подрубрик: %w", err)
+			}
+			for _, descendantID := range subtree {
+				if descendantID == *input.ParentID {
+					return nil, errors.New("рубрику нельзя перенести внутрь самой себя или своей подрубрики")
+				}
+			}
+			parentID := *input.ParentID
+			category.ParentID = &parentID
+		}
+	}
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if len([]rune(name)) < 2 {
+			return nil, errors.New("название рубрики должно содержать не менее 2 символов")
+		}
+		category.Name = name
+	}
+	if input.Name != nil || input.ParentID != nil {
+		if err := s.checkNameUnique(category.ParentID, category.Name, category.ID); err != nil {
+			return nil, err
+		}
+	}
+	if input.Description != nil {
+		category.Description = *input.Description
+	}
+	if input.AttributeSchemaID != nil {
+		if *input.AttributeSchemaID == 0 {
+			category.AttributeSchemaID = nil
+		} else {
+			schema, err := s.getSchema(*input.AttributeSchemaID)
+			if err != nil {
+				return nil, err
+			}
+			category.AttributeSchemaID = &schema.ID
+		}
+	}
+
+	if err := s.categoryStore.UpdateCategory(category); err != nil {
+		return nil, fmt.Errorf("ошибка обновления рубрики: %w", err)
+	}
+	return getCategory(s.categoryStore, id)
+}
+
+// DeleteCategory удаляет рубрику, если у нее нет подрубрик и она не назначена аукционам или лотам
+func (s *CategoryService) DeleteCategory(id uint, currentUserRole models.UserRole) error {
+	if currentUserRole != models.RoleSystemAdmin {
+		return errors.New("недостаточно прав для управления рубриками")
+	}
+	if _, err := getCategory(s.categoryStore, id); err != nil {
+		return err
+	}
+	children, auctions, lots, err := s.categoryStore.CountCategoryUsage(id)
+	if err != nil {
+		return fmt.Errorf("ошибка проверки использования рубрики: %w", err)
+	}
+	if children > 0 {
+		return errors.New("рубрику нельзя удалить: у нее есть подрубрики")
+	}
+	if auctions > 0 || lots > 0 {
+		return fmt.Errorf("рубрику нельзя удалить: она назначена %d аукционам и %d лотам", auctions, lots)
+	}
+	return s.categoryStore.DeleteCategory(id)
+}
+
+func (s *CategoryService) checkNameUnique(parentID *uint, name string, selfID uint) error {
+	existing, err := s.categoryStore.GetCategoryByName(parentID, name)
+	if err != nil {
+		return fmt.Errorf("ошибка проверки рубрики: %w", err)
+	}
+	if existing != nil && existing.ID != selfID {
+		return errors.New("рубрика с таким названием уже существует на этом уровне")
+	}
+	return nil
+}
+
+func (s *CategoryService) getSchema(id uint) (*models.AttributeSchema, error) {
+	schema, err := s.schemaStore.GetAttributeSchemaByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения схемы атрибутов: %w", err)
+	}
+	if schema == nil {
+		return nil, errors.New("схема атрибутов не найдена")
+	}
+	return schema, nil
+}
+
+// getCategory загружает рубрику и возвращает ошибку "рубрика не найдена", если ее нет
+func getCategory(categoryStore store.CategoryStore, id uint) (*models.Category, error) {
+	category, err := categoryStore.GetCategoryByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения рубрики: %w", err)
+	}
+	if category == nil {
+		return nil, errors.New("рубрика не найдена")
+	}
+	return category, nil
+}
+
+// inheritedSchemaID возвращает схему атрибутов, ближайшую к рубрике вверх по иерархии
+func inheritedSchemaID(categoryStore store.CategoryStore, categoryID uint) (*uint, error) {
+	path, err := categoryStore.GetCategoryPath(categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения родительских рубрик: %w", err)
+	}
+	for _, category := range path {
+		if category.AttributeSchemaID != nil {
+			return category.AttributeSchemaID, nil
+		}
+	}
+	return nil, nil
+}
+
+// categoryInSubtree проверяет, что рубрика childID совпадает с rootID или является ее подрубрикой
+func categoryInSubtree(categoryStore store.CategoryStore, rootID, childID uint) (bool, error) {
+	subtree, err := categoryStore.GetCategorySubtreeIDs(rootID)
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения подрубрик: %w", err)
+	}
+	for _, id := range subtree {
+		if id == childID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,384 @@
+// backend/internal/services/consignment_service.go
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConsignmentService управляет приемом предметов на комиссию: продавец подает заявку с предметами,
+// сотрудники аукционного дома рассматривают каждый предмет и назначают принятые на запланированные аукционы.
+type ConsignmentService struct {
+	consignmentStore store.ConsignmentStore
+	auctionStore     store.AuctionStore
+	lotImageStore    store.LotImageStore
+	blobStore        store.BlobStore
+	lotService       *LotService
+	notifier         Notifier
+	maxFileSize      int64
+	maxImagePixels   int64
+}
+
+// NewConsignmentService создает новый экземпляр ConsignmentService
+func NewConsignmentService(cs store.ConsignmentStore, as store.AuctionStore, lis store.LotImageStore, bs store.BlobStore, lotService *LotService, n Notifier, maxFileSize, maxImagePixels int64) *ConsignmentService {
+	return &ConsignmentService{
+		consignmentStore: cs,
+		auctionStore:     as,
+		lotImageStore:    lis,
+		blobStore:        bs,
+		lotService:       lotService,
+		notifier:         n,
+		maxFileSize:      maxFileSize,
+		maxImagePixels:   maxImagePixels,
+	}
+}
+
+// CreateConsignment регистрирует заявку продавца на комиссию
+func (s *ConsignmentService) CreateConsignment(input models.CreateConsignmentInput, currentUserID uint, currentUserRole models.UserRole) (*models.Consignment, error) {
+	if currentUserRole != models.RoleSeller {
+		return nil, errors.New("недостаточно прав: подать заявку на комиссию может только продавец")
+	}
+
+	consignment := models.Consignment{
+		SellerID: currentUserID,
+		Notes:    strings.TrimSpace(input.Notes),
+		Status:   models.ConsignmentSubmitted,
+	}
+	for _, itemInput := range input.Items {
+		quantity := itemInput.Quantity
+		if quantity < 1 {
+			quantity = 1
+		}
+		consignment.Items = append(consignment.Items, models.ConsignmentItem{
+			Name:        strings.TrimSpace(itemInput.Name),
+			Description: itemInput.Description,
+			Quantity:    quantity,
+			AskingPrice: itemInput.AskingPrice,
+			Status:      models.ConsignmentItemPending,
+		})
+	}
+	if err := s.consignmentStore.CreateConsignment(&consignment); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения заявки: %w", err)
+	}
+	return &consignment, nil
+}
+
+// GetConsignments возвращает заявки: администратору - все (с фильтрами), продавцу - только его собственные
+func (s *ConsignmentService) GetConsignments(page, pageSize int, filters map[string]string, currentUserID uint, currentUserRole models.UserRole) ([]models.Consignment, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	} else if pageSize > 100 {
+		pageSize = 100
+	}
+	if currentUserRole != models.RoleSystemAdmin {
+		filters["sellerId"] = strconv.FormatUint(uint64(currentUserID), 10)
+	}
+	return s.consignmentStore.GetConsignments((page-1)*pageSize, pageSize, filters)
+}
+
+// GetConsignmentByID возвращает заявку владельцу или администратору
+func (s *ConsignmentService) GetConsignmentByID(consignmentID uint, currentUserID uint, currentUserRole models.UserRole) (*models.Consignment, error) {
+	consignment, err := s.consignmentStore.GetConsignmentByID(consignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заявки: %w", err)
+	}
+	if consignment == nil {
+		return nil, errors.New("заявка не найдена")
+	}
+	if currentUserRole != models.RoleSystemAdmin && consignment.SellerID != currentUserID {
+		return nil, errors.New("недостаточно прав для просмотра этой заявки")
+	}
+	return consignment, nil
+}
+
+// getConsignmentItem загружает заявку с проверкой доступа и находит в ней предмет
+func (s *ConsignmentService) getConsignmentItem(consignmentID, itemID uint, currentUserID uint, currentUserRole models.UserRole) (*models.Consignment, *models.ConsignmentItem, error) {
+	consignment, err := s.GetConsignmentByID(consignmentID, currentUserID, currentUserRole)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range consignment.Items {
+		if consignment.Items[i].ID == itemID {
+			return consignment, &consignment.Items[i], nil
+		}
+	}
+	return nil, nil, errors.New("предмет заявки не найден")
+}
+
+// UploadItemImages добавляет фотографии к предмету заявки. Пока предмет не рассмотрен,
+// фотографии может добавлять продавец, подавший заявку.
+func (s *ConsignmentService) UploadItemImages(consignmentID, itemID uint, files []*multipart.FileHeader, currentUserID uint, currentUserRole models.UserRole) ([]models.ConsignmentItemImage, error) {
+	if len(files) == 0 {
+		return nil, errors.New("не передано ни одной фотографии")
+	}
+	consignment, item, err := s.getConsignmentItem(consignmentID, itemID, currentUserID, currentUserRole)
+	if err != nil {
+		return nil, err
+	}
+	if consignment.SellerID != currentUserID {
+		return nil, errors.New("недостаточно прав: добавлять фотографии может только продавец, подавший заявку")
+	}
+	if item.Status != models.ConsignmentItemPending {
+		return nil, errors.New("добавлять фотографии можно только к предмету, который еще не рассмотрен")
+	}
+	if len(item.Images)+len(files) > maxImagesPerLot {
+		return nil, fmt.Errorf("превышено количество фотографий: у предмета может быть не более %d фотографий", maxImagesPerLot)
+	}
+
+	images := make([]models.ConsignmentItemImage, 0, len(files))
+	var storedKeys []string
+	cleanup := func() {
+		for _, key := range storedKeys {
+			if err := s.blobStore.Delete(key); err != nil {
+				log.Printf("Не удалось удалить файл %s: %v", key, err)
+			}
+		}
+	}
+	for i, fileHeader := range files {
+		stored, keys, err := storeImageFile(s.blobStore, s.maxFileSize, s.maxImagePixels, fmt.Sprintf("consignments/%d/%d", consignmentID, itemID), fileHeader)
+		storedKeys = append(storedKeys, keys...)
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		images = append(images, models.ConsignmentItemImage{
+			ConsignmentID: consignmentID,
+			ItemID:        itemID,
+			BlobKey:       stored.BlobKey,
+			ThumbnailKey:  stored.ThumbnailKey,
+			ContentType:   stored.ContentType,
+			OriginalName:  stored.OriginalName,
+			Size:          stored.Size,
+			Width:         stored.Width,
+			Height:        stored.Height,
+			Position:      len(item.Images) + i,
+		})
+	}
+
+	if err := s.consignmentStore.CreateConsignmentItemImages(images); err != nil {
+		cleanup()
+		return nil, fmt.Errorf("ошибка сохранения фотографий: %w", err)
+	}
+	for i := range images {
+		images[i].FillURLs()
+	}
+	return images, nil
+}
+
+// OpenItemImage открывает файл фотографии предмета заявки (или ее миниатюры)
+func (s *ConsignmentService) OpenItemImage(consignmentID, itemID, imageID uint, thumbnail bool, currentUserID uint, currentUserRole models.UserRole) (io.ReadCloser, string, error) {
+	if _, _, err := s.getConsignmentItem(consignmentID, itemID, currentUserID, currentUserRole); err != nil {
+		return nil, "", err
+	}
+	img, err := s.consignmentStore.GetConsignmentItemImage(itemID, imageID)
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка получения фотографии: %w", err)
+	}
+	if img == nil {
+		return nil, "", errors.New("фотография не найдена")
+	}
+
+	key, contentType := img.BlobKey, img.ContentType
+	if thumbnail {
+		key, contentType = img.ThumbnailKey, "image/jpeg"
+	}
+	reader, err := s.blobStore.Open(key)
+	if err != nil {
+		return nil, "", err
+	}
+	return reader, contentType, nil
+}
+
+// ReviewItem фиксирует решение сотрудника по предмету заявки: принять с предлагаемой стартовой ценой или отклонить.
+// Решение можно изменить, пока предмет не выставлен на аукцион. Продавец получает уведомление о решении.
+func (s *ConsignmentService) ReviewItem(consignmentID, itemID uint, input models.ReviewConsignmentItemInput, currentUserID uint, currentUserRole models.UserRole) (*models.ConsignmentItem, error) {
+	if currentUserRole != models.RoleSystemAdmin {
+		return nil, errors.New("недостаточно прав: рассматривать заявки могут только сотрудники аукционного дома")
+	}
+	consignment, item, err := s.getConsignmentItem(consignmentID, itemID, currentUserID, currentUserRole)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status == models.ConsignmentItemListed {
+		return nil, errors.New("предмет уже выставлен на аукцион, решение по нему изменить нельзя")
+	}
+
+	now := time.Now()
+	item.ReviewComment = strings.TrimSpace(input.Comment)
+	item.ReviewedByUserID = &currentUserID
+	item.ReviewedAt = &now
+	if input.Accept {
+		if input.ProposedStartPrice == nil || *input.ProposedStartPrice <= 0 {
+			return nil, errors.New("для принятого предмета укажите положительную стартовую цену")
+		}
+		item.Status = models.ConsignmentItemAccepted
+		item.ProposedStartPrice = input.ProposedStartPrice
+	} else {
+		item.Status = models.ConsignmentItemRejected
+		item.ProposedStartPrice = nil
+	}
+	if err := s.consignmentStore.UpdateConsignmentItem(item); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения решения по предмету: %w", err)
+	}
+
+	subject := fmt.Sprintf("Решение по предмету «%s» из заявки № %d", item.Name, consignment.ID)
+	message := "Предмет отклонен."
+	if input.Accept {
+		message = fmt.Sprintf("Предмет принят на комиссию, предлагаемая стартовая цена %.2f.", *item.ProposedStartPrice)
+	}
+	if item.ReviewComment != "" {
+		message += " Комментарий: " + item.ReviewComment
+	}
+	if err := s.notifier.Notify(consignment.SellerID, subject, message); err != nil {
+		log.Printf("[ConsignmentService] Не удалось уведомить продавца %d о решении по предмету %d: %v", consignment.SellerID, item.ID, err)
+	}
+	return item, nil
+}
+
+// AssignItems назначает принятые предметы на запланированный аукцион: для каждого предмета создается лот
+// от имени продавца, подавшего заявку, со стартовой ценой, предложенной сотрудником. Лоты создаются в одной
+// транзакции, фотографии предметов копируются в галереи лотов.
+func (s *ConsignmentService) AssignItems(input models.AssignConsignmentItemsInput, currentUserID uint, currentUserRole models.UserRole) ([]models.Lot, error) {
+	if currentUserRole != models.RoleSystemAdmin {
+		return nil, errors.New("недостаточно прав: назначать предметы на аукцион могут только сотрудники аукционного дома")
+	}
+	auction, err := s.auctionStore.GetAuctionByID(input.AuctionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения аукциона: %w", err)
+	}
+	if auction == nil {
+		return nil, errors.New("аукцион не найден")
+	}
+	if auction.Status != models.StatusScheduled {
+		return nil, errors.New("лоты можно добавлять только в запланированные аукционы")
+	}
+
+	seen := make(map[uint]bool, len(input.ItemIDs))
+	for _, id := range input.ItemIDs {
+		if seen[id] {
+			return nil, errors.New("некорректный список предметов: предмет указан дважды")
+		}
+		seen[id] = true
+	}
+	items, err := s.consignmentStore.GetConsignmentItemsByIDs(input.ItemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения предметов заявок: %w", err)
+	}
+	if len(items) != len(input.ItemIDs) {
+		return nil, errors.New("предмет заявки не найден")
+	}
+	// лоты нумеруются в том порядке, в котором предметы перечислены в запросе
+	itemsByID := make(map[uint]models.ConsignmentItem, len(items))
+	for _, item := range items {
+		itemsByID[item.ID] = item
+	}
+	for i, id := range input.ItemIDs {
+		items[i] = itemsByID[id]
+	}
+
+	sellers := make(map[uint]uint)
+	lots := make([]models.Lot, 0, len(items))
+	for _, item := range items {
+		if item.Status != models.ConsignmentItemAccepted {
+			return nil, fmt.Errorf("предмет %d нельзя назначить на аукцион: статус «%s», требуется «%s»", item.ID, item.Status, models.ConsignmentItemAccepted)
+		}
+		sellerID, ok := sellers[item.ConsignmentID]
+		if !ok {
+			consignment, err := s.consignmentStore.GetConsignmentByID(item.ConsignmentID)
+			if err != nil || consignment == nil {
+				return nil, fmt.Errorf("не удалось получить заявку предмета %d", item.ID)
+			}
+			sellerID = consignment.SellerID
+			sellers[item.ConsignmentID] = sellerID
+		}
+
+		lot, err := s.lotService.newLotFromInput(auction, models.CreateLotInput{
+			Name:        item.Name,
+			Description: item.Description,
+			StartPrice:  *item.ProposedStartPrice,
+			Quantity:    item.Quantity,
+		}, sellerID)
+		if err != nil {
+			return nil, fmt.Errorf("предмет %d нельзя назначить на аукцион: %w", item.ID, err)
+		}
+		// предмет уже рассмотрен сотрудником, поэтому лот публикуется без модерации
+		publishLot(lot, currentUserID)
+		lots = append(lots, *lot)
+	}
+
+	if err := s.consignmentStore.AssignItemsToLots(items, lots); err != nil {
+		if strings.Contains(err.Error(), "предмет заявки") {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ошибка создания лотов: %w", err)
+	}
+
+	for i := range lots {
+		s.copyItemImagesToLot(items[i], &lots[i])
+	}
+	applyAuctionRoles(auction)
+	for i := range lots {
+		lots[i].Roles = auction.Roles
+	}
+	return lots, nil
+}
+
+// copyItemImagesToLot копирует фотографии предмета в галерею лота; первая фотография становится основной.
+// Ошибки копирования не отменяют создание лота: фотографии можно загрузить в лот повторно.
+func (s *ConsignmentService) copyItemImagesToLot(item models.ConsignmentItem, lot *models.Lot) {
+	var lotImages []models.LotImage
+	for _, img := range item.Images {
+		name, err := randomBlobName()
+		if err != nil {
+			log.Printf("Не удалось скопировать фотографию %d предмета %d в лот %d: %v", img.ID, item.ID, lot.ID, err)
+			continue
+		}
+		blobKey := fmt.Sprintf("lots/%d/%s%s", lot.ID, name, path.Ext(img.BlobKey))
+		thumbnailKey := fmt.Sprintf("lots/%d/%s_thumb.jpg", lot.ID, name)
+		if err := copyBlob(s.blobStore, img.BlobKey, blobKey); err != nil {
+			log.Printf("Не удалось скопировать фотографию %d предмета %d в лот %d: %v", img.ID, item.ID, lot.ID, err)
+			continue
+		}
+		if err := copyBlob(s.blobStore, img.ThumbnailKey, thumbnailKey); err != nil {
+			log.Printf("Не удалось скопировать миниатюру %d предмета %d в лот %d: %v", img.ID, item.ID, lot.ID, err)
+			if errDelete := s.blobStore.Delete(blobKey); errDelete != nil {
+				log.Printf("Не удалось удалить файл %s: %v", blobKey, errDelete)
+			}
+			continue
+		}
+		lotImages = append(lotImages, models.LotImage{
+			LotID:        lot.ID,
+			BlobKey:      blobKey,
+			ThumbnailKey: thumbnailKey,
+			ContentType:  img.ContentType,
+			OriginalName: img.OriginalName,
+			Size:         img.Size,
+			Width:        img.Width,
+			Height:       img.Height,
+			Position:     len(lotImages),
+			IsPrimary:    len(lotImages) == 0,
+		})
+	}
+	if len(lotImages) > 0 {
+		if err := s.lotImageStore.CreateLotImages(lotImages); err != nil {
+			log.Printf("Не удалось сохранить фотографии лота %d: %v", lot.ID, err)
+			return
+		}
+		for i := range lotImages {
+			lotImages[i].FillURLs()
+		}
+		lot.Images = lotImages
+	}
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// CreditLimitService управляет кредитными лимитами участников, обеспеченными депозитом
+type CreditLimitService struct {
+	creditLimitStore store.CreditLimitStore
+	auctionStore     store.AuctionStore
+	userStore        store.UserStore
+	notifier         Notifier
+}
+
+// NewCreditLimitService создает новый экземпляр CreditLimitService
+func NewCreditLimitService(cls store.CreditLimitStore, as store.AuctionStore, us store.UserStore, n Notifier) *CreditLimitService {
+	return &CreditLimitService{creditLimitStore: cls, auctionStore: as, userStore: us, notifier: n}
+}
+
+// SetCreditLimit устанавливает (или изменяет) лимит участника на аукционе
+func (s *CreditLimitService) SetCreditLimit(auctionID, userID uint, input models.SetCreditLimitInput, adminUserID uint, adminRole models.UserRole) (*models.CreditLimitUsage, error) {
+	if adminRole != models.RoleSystemAdmin {
+		return nil, errors.New("недостаточно прав для установки кредитного лимита")
+	}
+
+	auction, err := s.auctionStore.GetAuctionByID(auctionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения аукциона: %w", err)
+	}
+	if auction == nil {
+		return nil, errors.New("аукцион не найден")
+	}
+	if auction.Status == models.StatusCompleted {
+		return nil, errors.New("нельзя установить лимит для завершенного аукциона")
+	}
+
+	user, err := s.userStore.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	limit := models.CreditLimit{
+		UserID:      userID,
+		AuctionID:   auctionID,
+		Amount:      input.Amount,
+		SetByUserID: adminUserID,
+	}
+	if err := s.creditLimitStore.UpsertCreditLimit(&limit); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения кредитного лимита: %w", err)
+	}
+
+	stored, err := s.creditLimitStore.GetCreditLimit(userID, auctionID)
+	if err != nil || stored == nil {
+		return nil, errors.New("не удалось получить сохраненный кредитный лимит")
+	}
+	s.notify(userID, fmt.Sprintf("Кредитный лимит на аукционе «%s»", auction.NameSpecificity),
+		fmt.Sprintf("Администратор установил вам кредитный лимит %.2f на аукционе «%s».", input.Amount, auction.NameSpecificity))
+	user.PasswordHash = ""
+	stored.User = user
+	return s.withUsage(*stored)
+}
+
+// GetCreditLimitsByAuction возвращает лимиты участников аукциона вместе с их использованием
+func (s *CreditLimitService) GetCreditLimitsByAuction(auctionID uint, page, pageSize int, currentUserRole models.UserRole) ([]models.CreditLimitUsage, int64, error) {
+	if currentUserRole != models.RoleSystemAdmin {
+		return nil, 0, errors.New("недостаточно прав для просмотра кредитных лимитов")
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	limits, total, err := s.creditLimitStore.GetCreditLimitsByAuctionID(auctionID, offset, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения кредитных лимитов: %w", err)
+	}
+
+	result := make([]models.CreditLimitUsage, 0, len(limits))
+	for _, limit := range limits {
+		if limit.User != nil {
+			limit.User.PasswordHash = ""
+		}
+		usage, err := s.withUsage(limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		result = append(result, *usage)
+	}
+	return result, total, nil
+}
+
+// DeleteCreditLimit снимает ограничение с участника аукциона
+func (s *CreditLimitService) DeleteCreditLimit(auctionID, userID uint, adminRole models.UserRole) error {
+	if adminRole != models.RoleSystemAdmin {
+		return errors.New("недостаточно прав для удаления кредитного лимита")
+	}
+	if err := s.creditLimitStore.DeleteCreditLimit(userID, auctionID); err != nil {
+		return err
+	}
+	s.notify(userID, "Кредитный лимит снят",
+		fmt.Sprintf("Администратор снял ограничение кредитного лимита на аукционе %d.", auctionID))
+	return nil
+}
+
+// leadingExposure возвращает сумму лидирующих ставок участника на незавершенных лотах аукциона (кроме excludeLotID).
+// По одноединичному лоту учитывается его текущая цена, если участник лидирует; по многоединичному - ставка
+// участника за единицу, умноженная на число единиц, которые он получает при текущем распределении.
+func leadingExposure(cls store.CreditLimitStore, userID, auctionID, excludeLotID uint) (float64, error) {
+	lots, err := cls.GetOpenLotsBidByUser(userID, auctionID, excludeLotID)
+	if err != nil {
+		return 0, err
+	}
+	exposure := 0.0
+	for _, lot := range lots {
+		if lot.Quantity <= 1 {
+			if lot.HighestBidderID != nil && *lot.HighestBidderID == userID {
+				exposure += lot.CurrentPrice
+			}
+			continue
+		}
+		allocations, _ := allocateUniformPrice(lot.ID, lot.Quantity, lot.Biddings, nil)
+		for _, allocation := range allocations {
+			if allocation.UserID != userID {
+				continue
+			}
+			for _, bid := range latestBidsByBidder(lot.Biddings) {
+				if bid.UserID == userID {
+					exposure += bid.BidAmount * float64(allocation.Quantity)
+				}
+			}
+		}
+	}
+	return exposure, nil
+}
+
+func (s *CreditLimitService) withUsage(limit models.CreditLimit) (*models.CreditLimitUsage, error) {
+	used, err := leadingExposure(s.creditLimitStore, limit.UserID, limit.AuctionID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка расчета использования лимита: %w", err)
+	}
+	available := limit.Amount - used
+	if available < 0 {
+		available = 0
+	}
+	return &models.CreditLimitUsage{CreditLimit: limit, Used: used, Available: available}, nil
+}
+
+func (s *CreditLimitService) notify(userID uint, subject, message string) {
+	if err := s.notifier.Notify(userID, subject, message); err != nil {
+		log.Printf("[CreditLimitService] Не удалось уведомить пользователя %d: %v", userID, err)
+	}
+}
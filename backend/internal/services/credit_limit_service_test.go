@@ -0,0 +1,48 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"testing"
+	"time"
+)
+
+// fakeExposureStore отдает заранее заданные лоты вместо запроса к БД
+type fakeExposureStore struct {
+	store.CreditLimitStore
+	lots []models.Lot
+}
+
+func (f *fakeExposureStore) GetOpenLotsBidByUser(userID, auctionID uint, excludeLotID uint) ([]models.Lot, error) {
+	return f.lots, nil
+}
+
+func TestLeadingExposure(t *testing.T) {
+	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	leader := uint(1)
+	other := uint(2)
+	lots := []models.Lot{
+		// одноединичный лот, участник лидирует: учитывается текущая цена
+		{ID: 10, Quantity: 1, CurrentPrice: 500, HighestBidderID: &leader},
+		// одноединичный лот, лидирует другой участник
+		{ID: 11, Quantity: 1, CurrentPrice: 700, HighestBidderID: &other},
+		// партия из 10 единиц: участник получает 4 ед. по своей ставке 80
+		{ID: 12, Quantity: 10, Biddings: []models.Bid{
+			{ID: 1, LotID: 12, UserID: 2, BidAmount: 100, Quantity: 6, BidTime: base},
+			{ID: 2, LotID: 12, UserID: 1, BidAmount: 80, Quantity: 5, BidTime: base.Add(time.Second)},
+		}},
+		// партия, в распределение которой участник не попадает
+		{ID: 13, Quantity: 2, Biddings: []models.Bid{
+			{ID: 3, LotID: 13, UserID: 2, BidAmount: 100, Quantity: 2, BidTime: base},
+			{ID: 4, LotID: 13, UserID: 1, BidAmount: 90, Quantity: 2, BidTime: base.Add(time.Second)},
+		}},
+	}
+
+	exposure, err := leadingExposure(&fakeExposureStore{lots: lots}, leader, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 500.0 + 80*4; exposure != want {
+		t.Errorf("занятая сумма = %.2f, ожидалось %.2f", exposure, want)
+	}
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// eventSubscriptionBuffer - сколько событий может накопиться у медленного подписчика,
+	// после этого новые события для него отбрасываются
+	eventSubscriptionBuffer = 64
+	// eventBusReconnectDelay - пауза перед повторным подключением к шине после ошибки
+	eventBusReconnectDelay = 5 * time.Second
+)
+
+// EventPublisher публикует события торгов для подписчиков потоков
+type EventPublisher interface {
+	Publish(event models.AuctionEvent)
+}
+
+// EventBus передает события между экземплярами бэкенда (например, через Postgres LISTEN/NOTIFY).
+// Listen блокируется до ошибки или отмены контекста и передает каждое полученное сообщение в handle.
+type EventBus interface {
+	Publish(payload []byte) error
+	Listen(ctx context.Context, handle func(payload []byte)) error
+}
+
+// EventSubscription - подписка клиента на события аукциона (LotID == 0) или одного лота
+type EventSubscription struct {
+	AuctionID uint
+	LotID     uint
+	Events    chan models.AuctionEvent
+}
+
+// EventHub раздает события торгов подписчикам. С шиной событие сначала уходит в шину
+// и раздается локальным подписчикам, когда шина вернет его обратно, - так клиенты всех
+// экземпляров получают одни и те же события. Без шины события раздаются только в пределах процесса.
+type EventHub struct {
+	bus         EventBus
+	mu          sync.RWMutex
+	subscribers map[uint]map[*EventSubscription]struct{}
+}
+
+// NewEventHub создает новый экземпляр EventHub; bus может быть nil
+func NewEventHub(bus EventBus) *EventHub {
+	return &EventHub{bus: bus, subscribers: make(map[uint]map[*EventSubscription]struct{})}
+}
+
+// Start запускает прием событий из шины. При обрыве соединения подключение повторяется;
+// события, опубликованные за время обрыва, подписчики этого экземпляра не получат.
+func (h *EventHub) Start(ctx context.Context) {
+	if h.bus == nil {
+		return
+	}
+	go func() {
+		for {
+			err := h.bus.Listen(ctx, h.handlePayload)
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("[EventHub] Прием событий из шины прерван: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(eventBusReconnectDelay):
+			}
+		}
+	}()
+}
+
+// Publish отправляет событие подписчикам всех экземпляров. Если шина недоступна,
+// событие получат хотя бы подписчики текущего экземпляра.
+func (h *EventHub) Publish(event models.AuctionEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	if h.bus == nil {
+		h.dispatch(event)
+		return
+	}
+	payload, err := json.Marshal(event)
+	if err == nil {
+		err = h.bus.Publish(payload)
+	}
+	if err != nil {
+		log.Printf("[EventHub] Не удалось опубликовать событие %s аукциона %d в шину: %v", event.Type, event.AuctionID, err)
+		h.dispatch(event)
+	}
+}
+
+// Subscribe подписывает клиента на события аукциона; при lotID != 0 - только на события этого лота
+// и на смену статуса аукциона
+func (h *EventHub) Subscribe(auctionID, lotID uint) *EventSubscription {
+	subscription := &EventSubscription{
+		AuctionID: auctionID,
+		LotID:     lotID,
+		Events:    make(chan models.AuctionEvent, eventSubscriptionBuffer),
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers[auctionID] == nil {
+		h.subscribers[auctionID] = make(map[*EventSubscription]struct{})
+	}
+	h.subscribers[auctionID][subscription] = struct{}{}
+	return subscription
+}
+
+// Unsubscribe отменяет подписку и закрывает ее канал
+func (h *EventHub) Unsubscribe(subscription *EventSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	auctionSubscribers := h.subscribers[subscription.AuctionID]
+	if _, ok := auctionSubscribers[subscription]; !ok {
+		return
+	}
+	delete(auctionSubscribers, subscription)
+	if len(auctionSubscribers) == 0 {
+		delete(h.subscribers, subscription.AuctionID)
+	}
+	close(subscription.Events)
+}
+
+func (h *EventHub) handlePayload(payload []byte) {
+	var event models.AuctionEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		log.Printf("[EventHub] Некорректное событие из шины: %v", err)
+		return
+	}
+	h.dispatch(event)
+}
+
+// dispatch раздает событие подписчикам текущего экземпляра, не дожидаясь медленных клиентов
+func (h *EventHub) dispatch(event models.AuctionEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for subscription := range h.subscribers[event.AuctionID] {
+		if subscription.LotID != 0 && event.LotID != nil && *event.LotID != subscription.LotID {
+			continue
+		}
+		select {
+		case subscription.Events <- event:
+		default:
+			log.Printf("[EventHub] Подписчик аукциона %d не успевает читать события, событие %s пропущено", event.AuctionID, event.Type)
+		}
+	}
+}
+
+func sameUserID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
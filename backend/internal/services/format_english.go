@@ -0,0 +1,155 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"errors"
+	"fmt"
+)
+
+// englishFormat - классический аукцион на повышение (формат по умолчанию).
+// Лот из нескольких единиц разыгрывается по единой цене: единицы получают самые высокие ставки,
+// а все победители платят цену самой низкой выигравшей ставки.
+type englishFormat struct{}
+
+func (englishFormat) Name() models.AuctionFormatType { return models.FormatEnglish }
+
+func (englishFormat) Roles() models.AuctionRoles {
+	return models.AuctionRoles{LotOwner: "Продавец", Bidder: "Покупатель"}
+}
+
+func (englishFormat) BidderPays() bool { return true }
+
+func (englishFormat) ValidateLot(lot *models.Lot) error {
+	if lot.Quantity < 1 {
+		return errors.New("количество единиц в лоте должно быть не меньше 1")
+	}
+	return validateEstimateRange(lot, true)
+}
+
+func (f englishFormat) ValidateBid(bc *BidContext) error {
+	lot := bc.Lot
+	if lot.Quantity > 1 {
+		if bc.Quantity > lot.Quantity {
+			return fmt.Errorf("запрошено больше единиц, чем есть в лоте (доступно %d)", lot.Quantity)
+		}
+		if bc.Amount < lot.StartPrice {
+			return fmt.Errorf("ваша ставка должна быть не ниже стартовой цены за единицу (%.2f)", lot.StartPrice)
+		}
+		var otherBids []models.Bid
+		for _, b := range latestBidsByBidder(bc.Bids) {
+			if b.UserID == bc.BidderID {
+				if bc.Amount < b.BidAmount {
+					return fmt.Errorf("новая ставка не может быть ниже вашей предыдущей ставки за единицу (%.2f)", b.BidAmount)
+				}
+				continue
+			}
+			otherBids = append(otherBids, b)
+		}
+		othersAllocations, othersClearingPrice := allocateUniformPrice(lot.ID, lot.Quantity, otherBids, nil)
+		if allocatedUnits(othersAllocations) >= lot.Quantity && bc.Amount <= othersClearingPrice {
+			return fmt.Errorf("ваша ставка должна быть выше текущей цены (%.2f)", othersClearingPrice)
+		}
+	} else {
+		if bc.Quantity > 1 {
+			return errors.New("лот состоит из одной единицы, запрошенное количество должно быть равно 1")
+		}
+		if bc.Amount <= lot.CurrentPrice {
+			return fmt.Errorf("ваша ставка должна быть выше текущей цены (%.2f)", lot.CurrentPrice)
+		}
+	}
+
+	for _, otherLot := range bc.Auction.Lots {
+		if otherLot.ID == lot.ID || (otherLot.Status != models.StatusLotActive && otherLot.Status != models.StatusPending) {
+			continue
+		}
+		leading, err := f.isLeadingOnLot(&otherLot, bc.BidderID, bc.LoadBids)
+		if err != nil {
+			return fmt.Errorf("не удалось проверить правило одного предмета из-за внутренней ошибки: %w", err)
+		}
+		if leading {
+			return errors.New("вы уже лидируете в торгах за другой предмет на этом аукционе. По правилам, можно приобрести только один предмет. Сначала ваша предыдущая лидирующая ставка должна быть перебита")
+		}
+	}
+	return nil
+}
+
+func (englishFormat) ApplyBid(bc *BidContext, bid models.Bid) {
+	lot := bc.Lot
+	if lot.Quantity > 1 {
+		allocations, clearingPrice := allocateUniformPrice(lot.ID, lot.Quantity, append(bc.Bids, bid), nil)
+		lot.CurrentPrice = clearingPrice
+		topBidderID := allocations[0].UserID
+		lot.HighestBidderID = &topBidderID
+		return
+	}
+	lot.CurrentPrice = bid.BidAmount
+	highestBidderID := bid.UserID
+	lot.HighestBidderID = &highestBidderID
+}
+
+// PublicView: торги открытые, участники видят ФИО лидера и покупателей, но не их контактные данные
+func (englishFormat) PublicView(lot *models.Lot) {
+	hideBidderPersonalData(lot, true)
+}
+
+func (englishFormat) Settle(auction *models.Auction, loadBids LotBidsLoader) ([]SettlementDecision, error) {
+	var decisions []SettlementDecision
+	lotsWonByUsersOnThisAuction := make(map[uint]uint)
+
+	for _, lot := range auction.Lots {
+		if lot.Quantity > 1 && (lot.Status == models.StatusLotActive || lot.Status == models.StatusPending) {
+			bids, err := loadBids(lot.ID)
+			if err != nil {
+				return nil, fmt.Errorf("ошибка получения ставок лота ID %d: %w", lot.ID, err)
+			}
+			excluded := make(map[uint]bool, len(lotsWonByUsersOnThisAuction))
+			for buyerID := range lotsWonByUsersOnThisAuction {
+				excluded[buyerID] = true
+			}
+			allocations, clearingPrice := allocateUniformPrice(lot.ID, lot.Quantity, bids, excluded)
+			if len(allocations) == 0 {
+				markLotUnsold(&lot)
+				decisions = append(decisions, SettlementDecision{Lot: lot, Reason: "нет ставок от участников, не выигравших другие лоты"})
+				continue
+			}
+			lot.Status = models.StatusSold
+			lot.CurrentPrice = clearingPrice
+			lot.FinalPrice = &clearingPrice
+			lot.Allocations = allocations
+			for _, allocation := range allocations {
+				lotsWonByUsersOnThisAuction[allocation.UserID] = lot.ID
+			}
+			decisions = append(decisions, SettlementDecision{
+				Lot:         lot,
+				Allocations: allocations,
+				Reason: fmt.Sprintf("распределено %d из %d ед. между %d победителями по единой цене %.2f",
+					allocatedUnits(allocations), lot.Quantity, len(allocations), clearingPrice),
+			})
+			continue
+		}
+
+		if decision, changed := settleSingleUnitLot(lot, lotsWonByUsersOnThisAuction); changed {
+			decisions = append(decisions, decision)
+		}
+	}
+	return decisions, nil
+}
+
+// isLeadingOnLot проверяет, входит ли участник в число текущих победителей лота.
+// Для многоединичного лота победителями считаются все участники, получающие единицы при текущем распределении.
+func (englishFormat) isLeadingOnLot(lot *models.Lot, userID uint, loadBids LotBidsLoader) (bool, error) {
+	if lot.Quantity <= 1 {
+		return lot.HighestBidderID != nil && *lot.HighestBidderID == userID, nil
+	}
+	bids, err := loadBids(lot.ID)
+	if err != nil {
+		return false, err
+	}
+	allocations, _ := allocateUniformPrice(lot.ID, lot.Quantity, bids, nil)
+	for _, allocation := range allocations {
+		if allocation.UserID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
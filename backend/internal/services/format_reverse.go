@@ -0,0 +1,59 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"errors"
+	"fmt"
+)
+
+// reverseFormat - реверсивный (закупочный) аукцион: заказчик выставляет заявку с максимальной ценой,
+// поставщики снижают цену, побеждает самое низкое предложение. Поставщик может выиграть несколько заявок.
+type reverseFormat struct{}
+
+func (reverseFormat) Name() models.AuctionFormatType { return models.FormatReverse }
+
+func (reverseFormat) Roles() models.AuctionRoles {
+	return models.AuctionRoles{LotOwner: "Заказчик", Bidder: "Поставщик"}
+}
+
+// BidderPays: поставщики ничего не оплачивают, поэтому кредитный лимит к ним не применяется
+func (reverseFormat) BidderPays() bool { return false }
+
+func (reverseFormat) ValidateLot(lot *models.Lot) error {
+	if lot.Quantity > 1 {
+		return errors.New("многоединичные лоты не поддерживаются в реверсивном аукционе")
+	}
+	return validateEstimateRange(lot, false)
+}
+
+func (reverseFormat) ValidateBid(bc *BidContext) error {
+	if bc.Quantity > 1 {
+		return errors.New("лот состоит из одной единицы, запрошенное количество должно быть равно 1")
+	}
+	if bc.Amount >= bc.Lot.CurrentPrice {
+		return fmt.Errorf("ваше предложение должно быть ниже текущей цены (%.2f)", bc.Lot.CurrentPrice)
+	}
+	return nil
+}
+
+func (reverseFormat) ApplyBid(bc *BidContext, bid models.Bid) {
+	bc.Lot.CurrentPrice = bid.BidAmount
+	lowestBidderID := bid.UserID
+	bc.Lot.HighestBidderID = &lowestBidderID
+}
+
+// PublicView: поставщики не должны знать, с кем конкурируют, поэтому лидер и победитель
+// показываются только по ID, без ФИО
+func (reverseFormat) PublicView(lot *models.Lot) {
+	hideBidderPersonalData(lot, false)
+}
+
+func (reverseFormat) Settle(auction *models.Auction, loadBids LotBidsLoader) ([]SettlementDecision, error) {
+	var decisions []SettlementDecision
+	for _, lot := range auction.Lots {
+		if decision, changed := settleSingleUnitLot(lot, nil); changed {
+			decisions = append(decisions, decision)
+		}
+	}
+	return decisions, nil
+}
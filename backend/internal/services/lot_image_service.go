@@ -0,0 +1,367 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"auction-app/backend/internal/utils"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"mime/multipart"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+const (
+	maxImagesPerLot      = 20
+	thumbnailMaxSide     = 320
+	thumbnailJPEGQuality = 85
+)
+
+// allowedImageTypes - допустимые MIME-типы фотографий и расширения файлов для них
+var allowedImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// LotImageService управляет фотографиями лотов
+type LotImageService struct {
+	lotStore      store.LotStore
+	auctionStore  store.AuctionStore
+	lotImageStore store.LotImageStore
+	blobStore     store.BlobStore
+	maxFileSize   int64
+	// maxImagePixels - предельное произведение ширины на высоту загружаемого изображения
+	maxImagePixels int64
+}
+
+// NewLotImageService создает новый экземпляр LotImageService
+func NewLotImageService(ls store.LotStore, as store.AuctionStore, lis store.LotImageStore, bs store.BlobStore, maxFileSize, maxImagePixels int64) *LotImageService {
+	return &LotImageService{
+		lotStore:       ls,
+		auctionStore:   as,
+		lotImageStore:  lis,
+		blobStore:      bs,
+		maxFileSize:    maxFileSize,
+		maxImagePixels: maxImagePixels,
+	}
+}
+
+// getManagedLot загружает лот и проверяет, что текущий пользователь может управлять его фотографиями
+func (s *LotImageService) getManagedLot(auctionID, lotID uint, currentUserID uint, currentUserRole models.UserRole) (*models.Lot, error) {
+	lot, err := s.lotStore.GetLotByID(lotID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения лота: %w", err)
+	}
+	if lot == nil {
+		return nil, errors.New("лот не найден")
+	}
+	if lot.AuctionID != auctionID {
+		return nil, errors.New("лот не принадлежит данному аукциону")
+	}
+
+	if currentUserRole != models.RoleSystemAdmin && currentUserRole != models.RoleSeller {
+		return nil, errors.New("недостаточно прав для управления фотографиями лота (требуется роль Администратора или Продавца)")
+	}
+	if currentUserRole == models.RoleSeller && lot.SellerID != currentUserID {
+		auction, err := s.auctionStore.GetAuctionByID(auctionID)
+		if err != nil || auction == nil {
+			return nil, errors.New("не удалось проверить права менеджера аукциона")
+		}
+		if auction.CreatedByUserID != currentUserID {
+			return nil, errors.New("недостаточно прав: Продавец может управлять фотографиями только своих лотов или лотов в аукционах, которыми он управляет")
+		}
+	}
+
+	if lot.Status != models.StatusPending && lot.Status != models.StatusLotActive {
+		return nil, errors.New("изменять фотографии можно только у лота, который ожидает торгов или торгуется")
+	}
+	return lot, nil
+}
+
+// UploadLotImages сохраняет загруженные фотографии лота и создает для них миниатюры
+func (s *LotImageService) UploadLotImages(auctionID, lotID uint, files []*multipart.FileHeader, currentUserID uint, currentUserRole models.UserRole) ([]models.LotImage, error) {
+	if len(files) == 0 {
+		return nil, errors.New("не передано ни одной фотографии")
+	}
+	lot, err := s.getManagedLot(auctionID, lotID, currentUserID, currentUserRole)
+	if err != nil {
+		return nil, err
+	}
+	if len(lot.Images)+len(files) > maxImagesPerLot {
+		return nil, fmt.Errorf("превышено количество фотографий: у лота может быть не более %d фотографий", maxImagesPerLot)
+	}
+
+	hasPrimary := false
+	for _, img := range lot.Images {
+		if img.IsPrimary {
+			hasPrimary = true
+			break
+		}
+	}
+
+	images := make([]models.LotImage, 0, len(files))
+	var storedKeys []string
+	cleanup := func() {
+		for _, key := range storedKeys {
+			if err := s.blobStore.Delete(key); err != nil {
+				log.Printf("Не удалось удалить файл %s: %v", key, err)
+			}
+		}
+	}
+
+	for i, fileHeader := range files {
+		img, keys, err := s.storeImage(lotID, fileHeader)
+		storedKeys = append(storedKeys, keys...)
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		img.Position = len(lot.Images) + i
+		img.IsPrimary = !hasPrimary && i == 0
+		images = append(images, *img)
+	}
+
+	if err := s.lotImageStore.CreateLotImages(images); err != nil {
+		cleanup()
+		return nil, fmt.Errorf("ошибка сохранения фотографий: %w", err)
+	}
+	for i := range images {
+		images[i].FillURLs()
+	}
+	return images, nil
+}
+
+// storeImage проверяет один файл, сохраняет его и миниатюру в хранилище.
+// Возвращает ключи уже сохраненных файлов, чтобы при ошибке их можно было удалить.
+func (s *LotImageService) storeImage(lotID uint, fileHeader *multipart.FileHeader) (*models.LotImage, []string, error) {
+	stored, keys, err := storeImageFile(s.blobStore, s.maxFileSize, s.maxImagePixels, fmt.Sprintf("lots/%d", lotID), fileHeader)
+	if err != nil {
+		return nil, keys, err
+	}
+	return &models.LotImage{
+		LotID:        lotID,
+		BlobKey:      stored.BlobKey,
+		ThumbnailKey: stored.ThumbnailKey,
+		ContentType:  stored.ContentType,
+		OriginalName: stored.OriginalName,
+		Size:         stored.Size,
+		Width:        stored.Width,
+		Height:       stored.Height,
+	}, keys, nil
+}
+
+// storedImage описывает фотографию, сохраненную в хранилище вместе с миниатюрой
+type storedImage struct {
+	BlobKey      string
+	ThumbnailKey string
+	ContentType  string
+	OriginalName string
+	Size         int64
+	Width        int
+	Height       int
+}
+
+// storeImageFile проверяет размер, тип и разрешение загруженного изображения, создает миниатюру и сохраняет оба файла
+// в хранилище под префиксом keyPrefix. Разрешение проверяется по заголовку файла до распаковки изображения. Возвращает ключи уже сохраненных файлов, чтобы при ошибке их можно было удалить.
+func storeImageFile(blobStore store.BlobStore, maxFileSize, maxImagePixels int64, keyPrefix string, fileHeader *multipart.FileHeader) (*storedImage, []string, error) {
+	if fileHeader.Size > maxFileSize {
+		return nil, nil, fmt.Errorf("файл %s слишком большой: максимальный размер %d МБ", fileHeader.Filename, maxFileSize/(1024*1024))
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, nil, fmt.Errorf("не удалось прочитать файл %s: %w", fileHeader.Filename, err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxFileSize+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("не удалось прочитать файл %s: %w", fileHeader.Filename, err)
+	}
+	if int64(len(data)) > maxFileSize {
+		return nil, nil, fmt.Errorf("файл %s слишком большой: максимальный размер %d МБ", fileHeader.Filename, maxFileSize/(1024*1024))
+	}
+
+	mtype := mimetype.Detect(data)
+	ext, ok := allowedImageTypes[mtype.String()]
+	if !ok {
+		return nil, nil, fmt.Errorf("недопустимый тип файла %s: %s (разрешены JPEG, PNG и GIF)", fileHeader.Filename, mtype.String())
+	}
+
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("файл %s не является корректным изображением", fileHeader.Filename)
+	}
+	if imageConfig.Width <= 0 || imageConfig.Height <= 0 || int64(imageConfig.Width)*int64(imageConfig.Height) > maxImagePixels {
+		return nil, nil, fmt.Errorf("изображение %s слишком большое: %d×%d пикселей, максимум %d млн пикселей",
+			fileHeader.Filename, imageConfig.Width, imageConfig.Height, maxImagePixels/(1000*1000))
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("файл %s не является корректным изображением", fileHeader.Filename)
+	}
+	var thumb bytes.Buffer
+	if err := jpeg.Encode(&thumb, utils.MakeThumbnail(decoded, thumbnailMaxSide), &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+		return nil, nil, fmt.Errorf("ошибка создания миниатюры: %w", err)
+	}
+
+	name, err := randomBlobName()
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка генерации имени файла: %w", err)
+	}
+	blobKey := fmt.Sprintf("%s/%s%s", keyPrefix, name, ext)
+	thumbnailKey := fmt.Sprintf("%s/%s_thumb.jpg", keyPrefix, name)
+
+	var stored []string
+	if err := blobStore.Put(blobKey, bytes.NewReader(data)); err != nil {
+		return nil, stored, fmt.Errorf("ошибка сохранения файла: %w", err)
+	}
+	stored = append(stored, blobKey)
+	if err := blobStore.Put(thumbnailKey, &thumb); err != nil {
+		return nil, stored, fmt.Errorf("ошибка сохранения миниатюры: %w", err)
+	}
+	stored = append(stored, thumbnailKey)
+
+	bounds := decoded.Bounds()
+	return &storedImage{
+		BlobKey:      blobKey,
+		ThumbnailKey: thumbnailKey,
+		ContentType:  mtype.String(),
+		OriginalName: fileHeader.Filename,
+		Size:         int64(len(data)),
+		Width:        bounds.Dx(),
+		Height:       bounds.Dy(),
+	}, stored, nil
+}
+
+// copyBlob копирует файл в хранилище под новым ключом
+func copyBlob(blobStore store.BlobStore, srcKey, dstKey string) error {
+	reader, err := blobStore.Open(srcKey)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return blobStore.Put(dstKey, reader)
+}
+
+func randomBlobName() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// GetLotImages возвращает фотографии лота в порядке показа
+func (s *LotImageService) GetLotImages(lotID uint) ([]models.LotImage, error) {
+	lot, err := s.lotStore.GetLotByID(lotID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения лота: %w", err)
+	}
+	if lot == nil {
+		return nil, errors.New("лот не найден")
+	}
+	return s.lotImageStore.GetLotImages(lotID)
+}
+
+// OpenLotImage открывает файл фотографии (или ее миниатюры) для отдачи клиенту
+func (s *LotImageService) OpenLotImage(lotID, imageID uint, thumbnail bool) (io.ReadCloser, string, error) {
+	img, err := s.lotImageStore.GetLotImage(lotID, imageID)
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка получения фотографии: %w", err)
+	}
+	if img == nil {
+		return nil, "", errors.New("фотография не найдена")
+	}
+
+	key, contentType := img.BlobKey, img.ContentType
+	if thumbnail {
+		key, contentType = img.ThumbnailKey, "image/jpeg"
+	}
+	reader, err := s.blobStore.Open(key)
+	if err != nil {
+		return nil, "", err
+	}
+	return reader, contentType, nil
+}
+
+// ReorderLotImages задает новый порядок фотографий лота. Должны быть переданы все фотографии лота.
+func (s *LotImageService) ReorderLotImages(auctionID, lotID uint, input models.ReorderLotImagesInput, currentUserID uint, currentUserRole models.UserRole) ([]models.LotImage, error) {
+	lot, err := s.getManagedLot(auctionID, lotID, currentUserID, currentUserRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(input.ImageIDs) != len(lot.Images) {
+		return nil, errors.New("некорректный порядок фотографий: нужно передать все фотографии лота")
+	}
+	existing := make(map[uint]bool, len(lot.Images))
+	for _, img := range lot.Images {
+		existing[img.ID] = true
+	}
+	seen := make(map[uint]bool, len(input.ImageIDs))
+	for _, id := range input.ImageIDs {
+		if !existing[id] || seen[id] {
+			return nil, errors.New("некорректный порядок фотографий: список содержит чужие или повторяющиеся фотографии")
+		}
+		seen[id] = true
+	}
+
+	if err := s.lotImageStore.UpdateLotImagesOrder(lotID, input.ImageIDs); err != nil {
+		return nil, fmt.Errorf("ошибка изменения порядка фотографий: %w", err)
+	}
+	return s.lotImageStore.GetLotImages(lotID)
+}
+
+// SetPrimaryLotImage назначает основную фотографию лота
+func (s *LotImageService) SetPrimaryLotImage(auctionID, lotID, imageID uint, currentUserID uint, currentUserRole models.UserRole) ([]models.LotImage, error) {
+	if _, err := s.getManagedLot(auctionID, lotID, currentUserID, currentUserRole); err != nil {
+		return nil, err
+	}
+	img, err := s.lotImageStore.GetLotImage(lotID, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения фотографии: %w", err)
+	}
+	if img == nil {
+		return nil, errors.New("фотография не найдена")
+	}
+
+	if err := s.lotImageStore.SetPrimaryLotImage(lotID, imageID); err != nil {
+		return nil, fmt.Errorf("ошибка назначения основной фотографии: %w", err)
+	}
+	return s.lotImageStore.GetLotImages(lotID)
+}
+
+// DeleteLotImage удаляет фотографию лота вместе с файлами в хранилище
+func (s *LotImageService) DeleteLotImage(auctionID, lotID, imageID uint, currentUserID uint, currentUserRole models.UserRole) error {
+	if _, err := s.getManagedLot(auctionID, lotID, currentUserID, currentUserRole); err != nil {
+		return err
+	}
+	img, err := s.lotImageStore.GetLotImage(lotID, imageID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения фотографии: %w", err)
+	}
+	if img == nil {
+		return errors.New("фотография не найдена")
+	}
+
+	if err := s.lotImageStore.DeleteLotImage(lotID, imageID); err != nil {
+		return fmt.Errorf("ошибка удаления фотографии: %w", err)
+	}
+	for _, key := range []string{img.BlobKey, img.ThumbnailKey} {
+		if err := s.blobStore.Delete(key); err != nil {
+			log.Printf("Не удалось удалить файл %s: %v", key, err)
+		}
+	}
+	return nil
+}
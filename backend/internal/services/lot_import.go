@@ -0,0 +1,289 @@
+// backend/internal/services/lot_import.go
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/utils"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// maxImportRows - максимальное число лотов в одном файле импорта
+const maxImportRows = 1000
+
+// Колонки файла импорта. Помимо перечисленных допускаются колонки атрибутов вида attr.<ключ>.
+const (
+	importColumnName         = "name"
+	importColumnDescription  = "description"
+	importColumnStartPrice   = "startPrice"
+	importColumnQuantity     = "quantity"
+	importColumnEstimateLow  = "estimateLow"
+	importColumnEstimateHigh = "estimateHigh"
+	importColumnCategoryID   = "categoryId"
+)
+
+// importColumnAliases сопоставляет заголовки колонок (без учета регистра) с их каноническими названиями
+var importColumnAliases = map[string]string{
+	"name":           importColumnName,
+	"название":       importColumnName,
+	"description":    importColumnDescription,
+	"описание":       importColumnDescription,
+	"startprice":     importColumnStartPrice,
+	"стартовая цена": importColumnStartPrice,
+	"quantity":       importColumnQuantity,
+	"количество":     importColumnQuantity,
+	"estimatelow":    importColumnEstimateLow,
+	"оценка от":      importColumnEstimateLow,
+	"estimatehigh":   importColumnEstimateHigh,
+	"оценка до":      importColumnEstimateHigh,
+	"categoryid":     importColumnCategoryID,
+	"рубрика":        importColumnCategoryID,
+}
+
+// importFieldColumns связывает поля CreateLotInput с колонками файла для сообщений об ошибках
+var importFieldColumns = map[string]string{
+	"Name":         importColumnName,
+	"Description":  importColumnDescription,
+	"StartPrice":   importColumnStartPrice,
+	"Quantity":     importColumnQuantity,
+	"EstimateLow":  importColumnEstimateLow,
+	"EstimateHigh": importColumnEstimateHigh,
+	"CategoryID":   importColumnCategoryID,
+}
+
+// ImportLots создает лоты аукциона из файла CSV или XLSX. Первая строка файла - заголовки колонок.
+// Каждая строка проверяется по тем же правилам, что и при создании лота через API; при любой ошибке
+// не сохраняется ни один лот. В режиме dryRun файл только проверяется. Как и при создании по одному,
+// лоты продавца попадают в очередь модерации, а лоты администратора публикуются сразу.
+func (s *LotService) ImportLots(auctionID uint, fileName string, data []byte, sellerID uint, currentUserRole models.UserRole, dryRun bool) (*models.LotImportResult, error) {
+	auction, err := s.auctionStore.GetAuctionByID(auctionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения аукциона: %w", err)
+	}
+	if auction == nil {
+		return nil, errors.New("аукцион для добавления лотов не найден")
+	}
+	if auction.Status != models.StatusScheduled {
+		return nil, errors.New("лоты можно добавлять только в запланированные аукционы")
+	}
+
+	rows, err := utils.ReadTable(fileName, data)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка импорта: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("ошибка импорта: файл пуст")
+	}
+	columns, err := parseImportHeader(rows[0])
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.LotImportResult{DryRun: dryRun, Errors: []models.LotImportRowError{}, Lots: []models.Lot{}}
+	for i, row := range rows[1:] {
+		if isBlankImportRow(row) {
+			continue
+		}
+		result.TotalRows++
+		if result.TotalRows > maxImportRows {
+			return nil, fmt.Errorf("ошибка импорта: в файле больше %d лотов", maxImportRows)
+		}
+
+		lot, rowErrors := s.importLotRow(auction, columns, row, sellerID)
+		if len(rowErrors) > 0 {
+			result.Errors = append(result.Errors, models.LotImportRowError{Row: i + 2, Errors: rowErrors})
+			continue
+		}
+		result.Lots = append(result.Lots, *lot)
+	}
+	result.ValidRows = len(result.Lots)
+
+	if result.TotalRows == 0 {
+		return nil, errors.New("ошибка импорта: в файле нет ни одного лота")
+	}
+	if dryRun {
+		return result, nil
+	}
+	if len(result.Errors) > 0 {
+		return result, fmt.Errorf("импорт отменен: ошибки в %d из %d строк", len(result.Errors), result.TotalRows)
+	}
+	if currentUserRole == models.RoleSystemAdmin {
+		for i := range result.Lots {
+			publishLot(&result.Lots[i], sellerID)
+		}
+	}
+
+	if err := s.lotStore.CreateLots(result.Lots); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения лотов в БД: %w", err)
+	}
+	result.Imported = len(result.Lots)
+	applyAuctionRoles(auction)
+	for i := range result.Lots {
+		result.Lots[i].Roles = auction.Roles
+	}
+	return result, nil
+}
+
+// parseImportHeader возвращает канонические названия колонок в порядке их следования в файле
+func parseImportHeader(header []string) ([]string, error) {
+	columns := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, title := range header {
+		title = strings.TrimSpace(title)
+		if title == "" {
+			continue
+		}
+		column, ok := importColumnAliases[strings.ToLower(title)]
+		if !ok {
+			if !strings.HasPrefix(title, "attr.") || len(title) == len("attr.") {
+				return nil, fmt.Errorf("ошибка импорта: неизвестная колонка %q", title)
+			}
+			column = title
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("ошибка импорта: колонка %q указана дважды", title)
+		}
+		seen[column] = true
+		columns[i] = column
+	}
+	if !seen[importColumnName] || !seen[importColumnStartPrice] {
+		return nil, fmt.Errorf("ошибка импорта: обязательны колонки %s и %s", importColumnName, importColumnStartPrice)
+	}
+	return columns, nil
+}
+
+func isBlankImportRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// importLotRow разбирает строку файла в CreateLotInput, проверяет ее и собирает лот.
+// Возвращает все найденные в строке ошибки, а не только первую.
+func (s *LotService) importLotRow(auction *models.Auction, columns []string, row []string, sellerID uint) (*models.Lot, []string) {
+	var input models.CreateLotInput
+	var rowErrors []string
+
+	for i, column := range columns {
+		if column == "" || i >= len(row) {
+			continue
+		}
+		cell := strings.TrimSpace(row[i])
+		if cell == "" {
+			continue
+		}
+		switch column {
+		case importColumnName:
+			input.Name = cell
+		case importColumnDescription:
+			input.Description = cell
+		case importColumnStartPrice:
+			if value, ok := parseImportNumber(cell); ok {
+				input.StartPrice = value
+			} else {
+				rowErrors = append(rowErrors, fmt.Sprintf("%s: ожидается число", column))
+			}
+		case importColumnEstimateLow, importColumnEstimateHigh:
+			value, ok := parseImportNumber(cell)
+			if !ok {
+				rowErrors = append(rowErrors, fmt.Sprintf("%s: ожидается число", column))
+			} else if column == importColumnEstimateLow {
+				input.EstimateLow = &value
+			} else {
+				input.EstimateHigh = &value
+			}
+		case importColumnQuantity:
+			if value, err := strconv.Atoi(cell); err == nil {
+				input.Quantity = value
+			} else {
+				rowErrors = append(rowErrors, fmt.Sprintf("%s: ожидается целое число", column))
+			}
+		case importColumnCategoryID:
+			if value, err := strconv.ParseUint(cell, 10, 32); err == nil {
+				categoryID := uint(value)
+				input.CategoryID = &categoryID
+			} else {
+				rowErrors = append(rowErrors, fmt.Sprintf("%s: ожидается ID рубрики", column))
+			}
+		default:
+			if input.Attributes == nil {
+				input.Attributes = make(map[string]interface{})
+			}
+			key := strings.TrimPrefix(column, "attr.")
+			input.Attributes[key] = importAttributeValue(auction.AttributeSchema, key, cell)
+		}
+	}
+
+	if err := binding.Validator.ValidateStruct(&input); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			for _, fieldError := range validationErrors {
+				rowErrors = append(rowErrors, describeImportFieldError(fieldError))
+			}
+		} else {
+			rowErrors = append(rowErrors, err.Error())
+		}
+	}
+	if len(rowErrors) > 0 {
+		return nil, rowErrors
+	}
+
+	lot, err := s.newLotFromInput(auction, input, sellerID)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	return lot, nil
+}
+
+// parseImportNumber разбирает число, допуская пробелы между разрядами и запятую в качестве десятичного разделителя
+func parseImportNumber(cell string) (float64, bool) {
+	cell = strings.NewReplacer(" ", "", "\u00a0", "", ",", ".").Replace(cell)
+	value, err := strconv.ParseFloat(cell, 64)
+	return value, err == nil
+}
+
+// importAttributeValue приводит текст ячейки к типу логического атрибута схемы;
+// остальные типы validateLotAttributes разбирает из строки сам
+func importAttributeValue(schema *models.AttributeSchema, key, cell string) interface{} {
+	if schema == nil {
+		return cell
+	}
+	for _, field := range schema.Fields {
+		if field.Key != key || field.Type != models.AttributeTypeBoolean {
+			continue
+		}
+		switch strings.ToLower(cell) {
+		case "true", "1", "да":
+			return true
+		case "false", "0", "нет":
+			return false
+		}
+	}
+	return cell
+}
+
+func describeImportFieldError(fieldError validator.FieldError) string {
+	column, ok := importFieldColumns[fieldError.Field()]
+	if !ok {
+		column = fieldError.Field()
+	}
+	switch fieldError.Tag() {
+	case "required":
+		return fmt.Sprintf("%s: обязательное поле", column)
+	case "min":
+		return fmt.Sprintf("%s: минимальная длина %s", column, fieldError.Param())
+	case "gt":
+		return fmt.Sprintf("%s: значение должно быть больше %s", column, fieldError.Param())
+	case "gte":
+		return fmt.Sprintf("%s: значение должно быть не меньше %s", column, fieldError.Param())
+	}
+	return fmt.Sprintf("%s: не выполнено правило %s", column, fieldError.Tag())
+}
@@ -0,0 +1,160 @@
+// backend/internal/services/lot_moderation.go
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// moderationReasonTitles - описания кодов причин отклонения для уведомлений продавцу
+var moderationReasonTitles = map[models.ModerationReasonCode]string{
+	models.ReasonProhibitedItem:    "предмет запрещен к продаже",
+	models.ReasonInaccurateDetails: "описание неполное или недостоверное",
+	models.ReasonPoorImages:        "фотографии отсутствуют или низкого качества",
+	models.ReasonPricing:           "стартовая цена или оценка не соответствуют предмету",
+	models.ReasonDuplicate:         "лот дублирует уже выставленный",
+	models.ReasonBannedContent:     "недопустимые формулировки в тексте лота",
+	models.ReasonOther:             "другая причина",
+}
+
+// normalizeModerationText приводит текст к нижнему регистру и оставляет только слова, разделенные одним пробелом,
+// чтобы запрещенные слова и фразы находились целиком независимо от пунктуации
+func normalizeModerationText(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// checkBannedWords - предварительная проверка текста лота по списку запрещенных слов из настроек.
+// Лот с запрещенными словами не принимается, даже на модерацию.
+func (s *LotService) checkBannedWords(texts ...string) error {
+	if len(s.bannedWords) == 0 {
+		return nil
+	}
+	text := " " + normalizeModerationText(strings.Join(texts, " ")) + " "
+	var found []string
+	for _, word := range s.bannedWords {
+		normalized := normalizeModerationText(word)
+		if normalized != "" && strings.Contains(text, " "+normalized+" ") {
+			found = append(found, word)
+		}
+	}
+	if len(found) > 0 {
+		return fmt.Errorf("текст лота содержит запрещенные слова: %s", strings.Join(found, ", "))
+	}
+	return nil
+}
+
+// publishLot отмечает лот одобренным. Используется модератором, а также для лотов, которые создает
+// сам администратор: они не требуют проверки.
+func publishLot(lot *models.Lot, moderatorID uint) {
+	now := time.Now()
+	lot.ModerationStatus = models.ModerationApproved
+	lot.ModerationReason = ""
+	lot.ModerationComment = ""
+	lot.ModeratedByUserID = &moderatorID
+	lot.ModeratedAt = &now
+	lot.PublishedAt = &now
+}
+
+// GetModerationQueue возвращает лоты для модерации (по умолчанию ожидающие проверки). Доступно только администратору.
+func (s *LotService) GetModerationQueue(page, pageSize int, filters map[string]string, currentUserRole models.UserRole) ([]models.Lot, int64, error) {
+	if currentUserRole != models.RoleSystemAdmin {
+		return nil, 0, errors.New("недостаточно прав: очередь модерации доступна только администратору")
+	}
+	if status, ok := filters["moderationStatus"]; ok && status != "" {
+		switch models.ModerationStatus(status) {
+		case models.ModerationPending, models.ModerationApproved, models.ModerationRejected:
+		default:
+			return nil, 0, fmt.Errorf("некорректный статус модерации: %s", status)
+		}
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	} else if pageSize > 100 {
+		pageSize = 100
+	}
+	offset := (page - 1) * pageSize
+
+	lots, total, err := s.lotStore.GetLotsForModeration(offset, pageSize, filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения очереди модерации: %w", err)
+	}
+	return lots, total, nil
+}
+
+// ApproveLot одобряет лот: после этого он появляется в публичных списках и принимает ставки
+func (s *LotService) ApproveLot(lotID uint, currentUserID uint, currentUserRole models.UserRole) (*models.Lot, error) {
+	lot, err := s.getLotForModeration(lotID, currentUserRole)
+	if err != nil {
+		return nil, err
+	}
+	publishLot(lot, currentUserID)
+	if err := s.lotStore.UpdateLotModeration(lot); err != nil {
+		if strings.Contains(err.Error(), "не ожидает модерации") {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ошибка сохранения решения модерации: %w", err)
+	}
+
+	s.notifySeller(lot, fmt.Sprintf("Лот «%s» одобрен", lot.Name),
+		"Лот прошел модерацию и опубликован в каталоге аукциона.")
+	return lot, nil
+}
+
+// RejectLot отклоняет лот с кодом причины. Продавец может исправить лот, после чего он снова попадет в очередь.
+func (s *LotService) RejectLot(lotID uint, input models.RejectLotInput, currentUserID uint, currentUserRole models.UserRole) (*models.Lot, error) {
+	lot, err := s.getLotForModeration(lotID, currentUserRole)
+	if err != nil {
+		return nil, err
+	}
+	comment := strings.TrimSpace(input.Comment)
+	if input.ReasonCode == models.ReasonOther && comment == "" {
+		return nil, errors.New("укажите комментарий для причины «другая причина»")
+	}
+
+	now := time.Now()
+	lot.ModerationStatus = models.ModerationRejected
+	lot.ModerationReason = input.ReasonCode
+	lot.ModerationComment = comment
+	lot.ModeratedByUserID = &currentUserID
+	lot.ModeratedAt = &now
+	if err := s.lotStore.UpdateLotModeration(lot); err != nil {
+		if strings.Contains(err.Error(), "не ожидает модерации") {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ошибка сохранения решения модерации: %w", err)
+	}
+
+	message := "Причина: " + moderationReasonTitles[input.ReasonCode] + "."
+	if comment != "" {
+		message += " Комментарий модератора: " + comment
+	}
+	s.notifySeller(lot, fmt.Sprintf("Лот «%s» отклонен модератором", lot.Name), message)
+	return lot, nil
+}
+
+func (s *LotService) getLotForModeration(lotID uint, currentUserRole models.UserRole) (*models.Lot, error) {
+	if currentUserRole != models.RoleSystemAdmin {
+		return nil, errors.New("недостаточно прав: модерировать лоты может только администратор")
+	}
+	lot, err := s.lotStore.GetLotByID(lotID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения лота: %w", err)
+	}
+	if lot == nil {
+		return nil, errors.New("лот не найден")
+	}
+	if lot.ModerationStatus != models.ModerationPending {
+		return nil, errors.New("лот не ожидает модерации: решение по нему уже принято")
+	}
+	return lot, nil
+}
@@ -6,22 +6,76 @@ import (
 	"auction-app/backend/internal/store"
 	"errors"
 	"fmt"
+	"log"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// Отклоненные ставки сохраняются в хронологию аукциона, поэтому их число от одного пользователя ограничено:
+// иначе хронологию, по которой разбираются споры о продаже, можно засорить. Сверх лимита ставка
+// по-прежнему отклоняется, но попытка не сохраняется.
+const (
+	maxBidAttemptsPerWindow = 20
+	bidAttemptsWindow       = time.Minute
+)
+
 type LotService struct {
-	lotStore     store.LotStore
-	auctionStore store.AuctionStore
-	bidStore     store.BidStore
+	lotStore         store.LotStore
+	auctionStore     store.AuctionStore
+	bidStore         store.BidStore
+	blocklistStore   store.BlocklistStore
+	creditLimitStore store.CreditLimitStore
+	categoryStore    store.CategoryStore
+	watchlistStore   store.WatchlistStore
+	notifier         Notifier
+	outbox           OutboxWaker
+	bannedWords      []string
+}
+
+func NewLotService(ls store.LotStore, as store.AuctionStore, bs store.BidStore, bls store.BlocklistStore, cls store.CreditLimitStore, cs store.CategoryStore, ws store.WatchlistStore, n Notifier, outbox OutboxWaker, bannedWords []string) *LotService {
+	return &LotService{lotStore: ls, auctionStore: as, bidStore: bs, blocklistStore: bls, creditLimitStore: cls, categoryStore: cs, watchlistStore: ws, notifier: n, outbox: outbox, bannedWords: bannedWords}
 }
 
-func NewLotService(ls store.LotStore, as store.AuctionStore, bs store.BidStore) *LotService {
-	return &LotService{lotStore: ls, auctionStore: as, bidStore: bs}
+// fillWatcherCounts заполняет число наблюдающих за лотами. Ошибка подсчета не мешает отдать лоты,
+// поэтому она только записывается в журнал.
+func (s *LotService) fillWatcherCounts(lots []models.Lot) {
+	lotIDs := make([]uint, len(lots))
+	for i := range lots {
+		lotIDs[i] = lots[i].ID
+	}
+	counts, err := s.watchlistStore.CountWatchersByLotIDs(lotIDs)
+	if err != nil {
+		log.Printf("[LotService] Не удалось подсчитать наблюдающих за лотами: %v", err)
+		return
+	}
+	for i := range lots {
+		lots[i].WatcherCount = counts[lots[i].ID]
+	}
+}
+
+// validateLotCategory проверяет, что рубрика лота существует и входит в рубрику аукциона (если она задана)
+func (s *LotService) validateLotCategory(auction *models.Auction, categoryID uint) error {
+	if _, err := getCategory(s.categoryStore, categoryID); err != nil {
+		return err
+	}
+	if auction.CategoryID == nil {
+		return nil
+	}
+	inSubtree, err := categoryInSubtree(s.categoryStore, *auction.CategoryID, categoryID)
+	if err != nil {
+		return err
+	}
+	if !inSubtree {
+		return errors.New("рубрика лота должна совпадать с рубрикой аукциона или быть ее подрубрикой")
+	}
+	return nil
 }
 
-func (s *LotService) CreateLot(auctionID uint, input models.CreateLotInput, sellerID uint) (*models.Lot, error) {
+// CreateLot добавляет лот в запланированный аукцион. Лот продавца попадает в очередь модерации
+// и не виден покупателям до одобрения; лот администратора публикуется сразу.
+func (s *LotService) CreateLot(auctionID uint, input models.CreateLotInput, sellerID uint, currentUserRole models.UserRole) (*models.Lot, error) {
 	auction, err := s.auctionStore.GetAuctionByID(auctionID)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения аукциона: %w", err)
@@ -32,20 +86,64 @@ func (s *LotService) CreateLot(auctionID uint, input models.CreateLotInput, sell
 	if auction.Status != models.StatusScheduled {
 		return nil, errors.New("лоты можно добавлять только в запланированные аукционы")
 	}
-
-	lot := models.Lot{
-		AuctionID:    auctionID,
-		Name:         input.Name,
-		Description:  input.Description,
-		SellerID:     sellerID,
-		StartPrice:   input.StartPrice,
-		CurrentPrice: input.StartPrice,
-		Status:       models.StatusPending,
+	lot, err := s.newLotFromInput(auction, input, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	if currentUserRole == models.RoleSystemAdmin {
+		publishLot(lot, sellerID)
 	}
-	if err := s.lotStore.CreateLot(&lot); err != nil {
+	if err := s.lotStore.CreateLot(lot); err != nil {
 		return nil, fmt.Errorf("ошибка создания лота в БД: %w", err)
 	}
-	return &lot, nil
+	applyAuctionRoles(auction)
+	lot.Roles = auction.Roles
+	return lot, nil
+}
+
+// newLotFromInput проверяет данные нового лота по правилам аукциона (атрибуты, рубрика, формат торгов)
+// и запрещенным словам и собирает лот, готовый к сохранению. Новый лот ожидает модерации.
+func (s *LotService) newLotFromInput(auction *models.Auction, input models.CreateLotInput, sellerID uint) (*models.Lot, error) {
+	format, err := auctionFormatFor(auction.Format)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkBannedWords(input.Name, input.Description); err != nil {
+		return nil, err
+	}
+	quantity := input.Quantity
+	if quantity < 1 {
+		quantity = 1
+	}
+	attributes, err := validateLotAttributes(auction.AttributeSchema, input.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	if input.CategoryID != nil {
+		if err := s.validateLotCategory(auction, *input.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+
+	lot := &models.Lot{
+		AuctionID:        auction.ID,
+		Name:             input.Name,
+		Description:      input.Description,
+		SellerID:         sellerID,
+		Quantity:         quantity,
+		StartPrice:       input.StartPrice,
+		CurrentPrice:     input.StartPrice,
+		Status:           models.StatusPending,
+		Attributes:       attributes,
+		CategoryID:       input.CategoryID,
+		EstimateLow:      input.EstimateLow,
+		EstimateHigh:     input.EstimateHigh,
+		ModerationStatus: models.ModerationPending,
+	}
+	if err := format.ValidateLot(lot); err != nil {
+		return nil, err
+	}
+	return lot, nil
 }
 
 func (s *LotService) PlaceBid(auctionID uint, lotID uint, input models.PlaceBidInput, bidderID uint) (*models.Lot, error) {
@@ -56,9 +154,6 @@ func (s *LotService) PlaceBid(auctionID uint, lotID uint, input models.PlaceBidI
 	if auction == nil {
 		return nil, errors.New("аукцион не найден")
 	}
-	if auction.Status != models.StatusActive {
-		return nil, errors.New("торги по этому аукциону неактивны")
-	}
 
 	lot, err := s.lotStore.GetLotByID(lotID)
 	if err != nil {
@@ -71,47 +166,130 @@ func (s *LotService) PlaceBid(auctionID uint, lotID uint, input models.PlaceBidI
 		return nil, errors.New("лот не принадлежит указанному аукциону")
 	}
 
+	// отклоненные по правилам торгов ставки сохраняются для хронологии аукциона; до этого места
+	// ставка отклоняется без записи, чтобы в хронологию не попадали несуществующие и чужие лоты
+	reject := func(reason error) (*models.Lot, error) {
+		s.recordBidAttempt(auctionID, lotID, bidderID, input, reason)
+		return nil, reason
+	}
+	if auction.Status != models.StatusActive {
+		return reject(errors.New("торги по этому аукциону неактивны"))
+	}
+
 	if lot.Status != models.StatusLotActive && lot.Status != models.StatusPending {
-		return nil, errors.New("ставки на данный лот не принимаются (статус лота)")
+		return reject(errors.New("ставки на данный лот не принимаются (статус лота)"))
+	}
+	if lot.ModerationStatus != models.ModerationApproved {
+		return reject(errors.New("ставки на данный лот не принимаются: лот не прошел модерацию"))
 	}
 	if lot.SellerID == bidderID {
-		return nil, errors.New("вы не можете делать ставки на собственный лот")
+		return reject(errors.New("вы не можете делать ставки на собственный лот"))
+	}
+	blocked, err := s.blocklistStore.IsBidderBlocked([]uint{lot.SellerID, auction.CreatedByUserID}, bidderID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка проверки черного списка: %w", err)
+	}
+	if blocked {
+		return reject(errors.New("ставка отклонена: продавец или организатор аукциона внес вас в черный список"))
+	}
+
+	format, err := auctionFormatFor(auction.Format)
+	if err != nil {
+		return nil, err
+	}
+	quantity := input.Quantity
+	if quantity < 1 {
+		quantity = 1
+	}
+	lotBids, err := s.bidStore.GetAllBidsByLotID(lotID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения ставок лота: %w", err)
+	}
+	bidContext := &BidContext{
+		Auction:  auction,
+		Lot:      lot,
+		BidderID: bidderID,
+		Amount:   input.Amount,
+		Quantity: quantity,
+		Bids:     lotBids,
+		LoadBids: s.bidStore.GetAllBidsByLotID,
 	}
-	if input.Amount <= lot.CurrentPrice {
-		return nil, fmt.Errorf("ваша ставка должна быть выше текущей цены (%.2f)", lot.CurrentPrice)
+	if err := format.ValidateBid(bidContext); err != nil {
+		return reject(err)
 	}
 
-	allLotsInAuction, _, errLots := s.lotStore.GetLotsByAuctionID(auctionID, 0, 0)
-	if errLots == nil {
-		for _, otherLot := range allLotsInAuction {
-			if otherLot.ID != lotID &&
-				otherLot.HighestBidderID != nil && *otherLot.HighestBidderID == bidderID &&
-				(otherLot.Status == models.StatusLotActive || otherLot.Status == models.StatusPending) {
-				return nil, errors.New("вы уже лидируете в торгах за другой предмет на этом аукционе. По правилам, можно приобрести только один предмет. Сначала ваша предыдущая лидирующая ставка должна быть перебита")
+	if format.BidderPays() {
+		creditLimit, err := s.creditLimitStore.GetCreditLimit(bidderID, auctionID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка проверки кредитного лимита: %w", err)
+		}
+		if creditLimit != nil {
+			exposure, err := leadingExposure(s.creditLimitStore, bidderID, auctionID, lotID)
+			if err != nil {
+				return nil, fmt.Errorf("ошибка проверки кредитного лимита: %w", err)
+			}
+			if exposure+input.Amount*float64(quantity) > creditLimit.Amount {
+				return reject(fmt.Errorf("ставка превышает ваш кредитный лимит на этом аукционе (лимит %.2f, занято лидирующими ставками %.2f)", creditLimit.Amount, exposure))
 			}
 		}
-	} else {
-		return nil, fmt.Errorf("не удалось проверить правило одного предмета из-за внутренней ошибки: %w", errLots)
 	}
 
+	previousLeaders := leadersFromBids(lot, lotBids)
+	previousPrice, previousLeader, previousStatus := lot.CurrentPrice, lot.HighestBidderID, lot.Status
+
 	bid := models.Bid{
 		LotID:     lotID,
 		UserID:    bidderID,
 		BidAmount: input.Amount,
+		Quantity:  quantity,
+		// время ставки задается заранее: по нему упорядочиваются равные ставки при распределении единиц
+		BidTime: time.Now(),
 	}
-	if err := s.bidStore.CreateBid(&bid); err != nil {
-		return nil, fmt.Errorf("ошибка сохранения ставки в БД: %w", err)
-	}
-
-	lot.CurrentPrice = input.Amount
-	highestBidderIDCopy := bidderID
-	lot.HighestBidderID = &highestBidderIDCopy
+	format.ApplyBid(bidContext, bid)
 	if lot.Status == models.StatusPending {
 		lot.Status = models.StatusLotActive
 	}
-	if err := s.lotStore.UpdateLot(lot); err != nil {
-		return nil, fmt.Errorf("ошибка обновления лота после ставки: %w", err)
+	// Ставка, новое состояние лота и событие bid.placed фиксируются одной транзакцией
+	err = s.bidStore.PlaceBid(&bid, lot, func() ([]models.OutboxEvent, error) {
+		event, err := newOutboxEvent(models.DomainBidPlaced, "lot", lot.ID, models.BidPlacedPayload{
+			AuctionID:               auction.ID,
+			OrganizerID:             auction.CreatedByUserID,
+			LotID:                   lot.ID,
+			LotNumber:               lot.LotNumber,
+			LotName:                 lot.Name,
+			SellerID:                lot.SellerID,
+			BidID:                   bid.ID,
+			BidderID:                bid.UserID,
+			Amount:                  bid.BidAmount,
+			Quantity:                bid.Quantity,
+			PlacedAt:                bid.BidTime,
+			CurrentPrice:            lot.CurrentPrice,
+			PreviousPrice:           previousPrice,
+			HighestBidderID:         lot.HighestBidderID,
+			PreviousHighestBidderID: previousLeader,
+			LotStatus:               lot.Status,
+			PreviousLotStatus:       previousStatus,
+			PreviousLeaders:         previousLeaders,
+			CurrentLeaders:          leadersFromBids(lot, append(lotBids, bid)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []models.OutboxEvent{event}, nil
+	})
+	if err != nil {
+		// лот успели снять с торгов, закрыть или перебить, пока ставка проверялась: такие отказы
+		// решают спор о продаже, поэтому они тоже сохраняются в хронологию
+		if strings.Contains(err.Error(), "не принимаются (статус лота)") || strings.Contains(err.Error(), "состояние лота изменилось") {
+			return reject(err)
+		}
+		return nil, fmt.Errorf("ошибка сохранения ставки в БД: %w", err)
 	}
+	s.outbox.Wake()
+	format.PublicView(lot)
+
+	applyAuctionRoles(auction)
+	lot.Roles = auction.Roles
 	return lot, nil
 }
 
@@ -168,10 +346,51 @@ func (s *LotService) UpdateLotDetails(lotID uint, auctionID uint, input models.U
 		lot.StartPrice = *input.StartPrice
 		lot.CurrentPrice = *input.StartPrice
 	}
+	if input.Quantity != nil {
+		lot.Quantity = *input.Quantity
+	}
+	if input.Attributes != nil {
+		attributes, err := validateLotAttributes(auction.AttributeSchema, input.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		lot.Attributes = attributes
+	}
+	if input.CategoryID != nil {
+		if err := s.validateLotCategory(auction, *input.CategoryID); err != nil {
+			return nil, err
+		}
+		lot.CategoryID = input.CategoryID
+	}
+	if input.EstimateLow != nil {
+		lot.EstimateLow = input.EstimateLow
+	}
+	if input.EstimateHigh != nil {
+		lot.EstimateHigh = input.EstimateHigh
+	}
+	format, err := auctionFormatFor(auction.Format)
+	if err != nil {
+		return nil, err
+	}
+	if err := format.ValidateLot(lot); err != nil {
+		return nil, err
+	}
+	if err := s.checkBannedWords(lot.Name, lot.Description); err != nil {
+		return nil, err
+	}
+	// Измененный продавцом лот снова проходит модерацию, в том числе после отклонения
+	if currentUserRole != models.RoleSystemAdmin {
+		lot.ModerationStatus = models.ModerationPending
+		lot.ModerationReason = ""
+		lot.ModerationComment = ""
+	}
 
 	if err := s.lotStore.UpdateLot(lot); err != nil {
 		return nil, fmt.Errorf("ошибка обновления лота в БД: %w", err)
 	}
+	format.PublicView(lot)
+	applyAuctionRoles(auction)
+	lot.Roles = auction.Roles
 	return lot, nil
 }
 
@@ -213,10 +432,208 @@ func (s *LotService) DeleteLot(lotID uint, auctionID uint, currentUserID uint, c
 		return errors.New("удалять лот можно только если он ожидает торгов и по нему нет ставок")
 	}
 
-	return s.lotStore.DeleteLot(lotID)
+	if err := s.lotStore.DeleteLot(lotID); err != nil {
+		return err
+	}
+	if lot.SellerID != currentUserID {
+		s.notifySeller(lot, fmt.Sprintf("Лот «%s» удален из аукциона «%s»", lot.Name, auction.NameSpecificity),
+			"Лот удален администратором или организатором аукциона.")
+	}
+	return nil
 }
 
-func (s *LotService) GetLotByID(lotID uint) (*models.Lot, error) {
+// ReorderLots задает новый порядок лотов запланированного аукциона и перенумеровывает их с единицы.
+// Должны быть переданы все лоты аукциона. Менять порядок может администратор или организатор аукциона.
+func (s *LotService) ReorderLots(auctionID uint, input models.ReorderLotsInput, currentUserID uint, currentUserRole models.UserRole) ([]models.Lot, error) {
+	auction, err := s.auctionStore.GetAuctionByID(auctionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения аукциона: %w", err)
+	}
+	if auction == nil {
+		return nil, errors.New("аукцион не найден")
+	}
+	if currentUserRole != models.RoleSystemAdmin && auction.CreatedByUserID != currentUserID {
+		return nil, errors.New("недостаточно прав: менять порядок лотов может администратор или организатор аукциона")
+	}
+	if auction.Status != models.StatusScheduled {
+		return nil, errors.New("менять порядок лотов можно только в запланированном аукционе")
+	}
+
+	if len(input.LotIDs) != len(auction.Lots) {
+		return nil, errors.New("некорректный порядок лотов: нужно передать все лоты аукциона")
+	}
+	existing := make(map[uint]bool, len(auction.Lots))
+	for _, lot := range auction.Lots {
+		existing[lot.ID] = true
+	}
+	seen := make(map[uint]bool, len(input.LotIDs))
+	for _, id := range input.LotIDs {
+		if !existing[id] || seen[id] {
+			return nil, errors.New("некорректный порядок лотов: список содержит чужие или повторяющиеся лоты")
+		}
+		seen[id] = true
+	}
+
+	if err := s.lotStore.ReorderLots(auctionID, input.LotIDs); err != nil {
+		if strings.Contains(err.Error(), "некорректный порядок") {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ошибка изменения порядка лотов: %w", err)
+	}
+	lots, _, err := s.lotStore.GetLotsByAuctionID(auctionID, 0, len(input.LotIDs), nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения лотов аукциона: %w", err)
+	}
+	applyAuctionRoles(auction)
+	for i := range lots {
+		lots[i].Roles = auction.Roles
+	}
+	return lots, nil
+}
+
+// WithdrawLot снимает лот с торгов по указанной причине (например, если предмет оказался краденым или поврежденным).
+// Это возможно в любой момент до продажи лота, в том числе во время торгов. Все ставки по лоту аннулируются,
+// лидеры торгов получают уведомление, а при подведении итогов аукциона лот не учитывается.
+// Снять лот может администратор или организатор аукциона.
+func (s *LotService) WithdrawLot(auctionID, lotID uint, input models.WithdrawLotInput, currentUserID uint, currentUserRole models.UserRole) (*models.Lot, error) {
+	auction, err := s.auctionStore.GetAuctionByID(auctionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения аукциона: %w", err)
+	}
+	if auction == nil {
+		return nil, errors.New("аукцион не найден")
+	}
+	if currentUserRole != models.RoleSystemAdmin && auction.CreatedByUserID != currentUserID {
+		return nil, errors.New("недостаточно прав: снять лот с торгов может администратор или организатор аукциона")
+	}
+	if auction.Status == models.StatusCompleted {
+		return nil, errors.New("снять с торгов можно только лот незавершенного аукциона")
+	}
+
+	lot, err := s.lotStore.GetLotByID(lotID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения лота: %w", err)
+	}
+	if lot == nil {
+		return nil, errors.New("лот не найден")
+	}
+	if lot.AuctionID != auctionID {
+		return nil, errors.New("лот не принадлежит данному аукциону")
+	}
+	if lot.Status != models.StatusPending && lot.Status != models.StatusLotActive {
+		return nil, errors.New("снять с торгов можно только лот, который ожидает торгов или торгуется")
+	}
+
+	leaders, err := s.leadingBidders(lot)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	lot.Status = models.StatusWithdrawn
+	lot.WithdrawalReason = strings.TrimSpace(input.Reason)
+	lot.WithdrawnAt = &now
+	lot.WithdrawnByUserID = &currentUserID
+	lot.HighestBidderID = nil
+	lot.HighestBidder = nil
+	lot.CurrentPrice = lot.StartPrice
+	event, err := newOutboxEvent(models.DomainLotWithdrawn, "lot", lot.ID, models.LotWithdrawnPayload{
+		AuctionID:   auction.ID,
+		AuctionName: auction.NameSpecificity,
+		OrganizerID: auction.CreatedByUserID,
+		LotID:       lot.ID,
+		LotNumber:   lot.LotNumber,
+		LotName:     lot.Name,
+		SellerID:    lot.SellerID,
+		Status:      lot.Status,
+		Reason:      lot.WithdrawalReason,
+		Leaders:     leaders,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.lotStore.WithdrawLot(lot, []models.OutboxEvent{event}); err != nil {
+		if strings.Contains(err.Error(), "снять с торгов можно только") {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ошибка снятия лота с торгов: %w", err)
+	}
+	s.outbox.Wake()
+
+	applyAuctionRoles(auction)
+	lot.Roles = auction.Roles
+	return lot, nil
+}
+
+// recordBidAttempt сохраняет отклоненную ставку с причиной отказа; ошибка записи не меняет ответ участнику
+func (s *LotService) recordBidAttempt(auctionID, lotID, bidderID uint, input models.PlaceBidInput, reason error) {
+	recent, err := s.bidStore.CountBidAttemptsByUserSince(bidderID, time.Now().Add(-bidAttemptsWindow))
+	if err != nil {
+		log.Printf("[LotService] Не удалось проверить число отклоненных ставок пользователя %d: %v", bidderID, err)
+		return
+	}
+	if recent >= maxBidAttemptsPerWindow {
+		log.Printf("[LotService] Отклоненная ставка пользователя %d по лоту %d не сохранена: превышен лимит %d попыток за %s",
+			bidderID, lotID, maxBidAttemptsPerWindow, bidAttemptsWindow)
+		return
+	}
+	quantity := input.Quantity
+	if quantity < 1 {
+		quantity = 1
+	}
+	attempt := models.BidAttempt{
+		AuctionID: auctionID,
+		LotID:     lotID,
+		UserID:    bidderID,
+		Amount:    input.Amount,
+		Quantity:  quantity,
+		Reason:    reason.Error(),
+	}
+	if err := s.bidStore.CreateBidAttempt(&attempt); err != nil {
+		log.Printf("[LotService] Не удалось сохранить отклоненную ставку пользователя %d по лоту %d: %v", bidderID, lotID, err)
+	}
+}
+
+// notifySeller отправляет уведомление продавцу лота; ошибка доставки не отменяет выполненное действие
+func (s *LotService) notifySeller(lot *models.Lot, subject, message string) {
+	if err := s.notifier.Notify(lot.SellerID, subject, message); err != nil {
+		log.Printf("[LotService] Не удалось уведомить продавца %d о лоте %d: %v", lot.SellerID, lot.ID, err)
+	}
+}
+
+// leadingBidders возвращает участников, лидирующих в торгах по лоту. Для многоединичного лота
+// это все участники, получающие единицы при текущем распределении.
+func (s *LotService) leadingBidders(lot *models.Lot) ([]uint, error) {
+	if lot.HighestBidderID == nil || lot.Quantity <= 1 {
+		return leadersFromBids(lot, nil), nil
+	}
+	bids, err := s.bidStore.GetAllBidsByLotID(lot.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения ставок лота: %w", err)
+	}
+	return leadersFromBids(lot, bids), nil
+}
+
+// leadersFromBids определяет лидеров лота по его действующим ставкам; для одноединичного лота
+// достаточно текущего лидера самого лота
+func leadersFromBids(lot *models.Lot, bids []models.Bid) []uint {
+	if lot.HighestBidderID == nil {
+		return nil
+	}
+	if lot.Quantity <= 1 {
+		return []uint{*lot.HighestBidderID}
+	}
+	allocations, _ := allocateUniformPrice(lot.ID, lot.Quantity, bids, nil)
+	leaders := make([]uint, 0, len(allocations))
+	for _, allocation := range allocations {
+		leaders = append(leaders, allocation.UserID)
+	}
+	return leaders
+}
+
+// GetLotByID возвращает лот для просмотра. Лот, не прошедший модерацию, виден только его продавцу
+// и администратору; остальным, в том числе анонимным посетителям (viewerID == 0), он не показывается.
+func (s *LotService) GetLotByID(lotID uint, viewerID uint, viewerRole models.UserRole) (*models.Lot, error) {
 	lot, err := s.lotStore.GetLotByID(lotID)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения лота ID %d из хранилища: %w", lotID, err)
@@ -224,11 +641,24 @@ func (s *LotService) GetLotByID(lotID uint) (*models.Lot, error) {
 	if lot == nil {
 		return nil, errors.New("лот не найден")
 	}
+	if lot.ModerationStatus != models.ModerationApproved && viewerRole != models.RoleSystemAdmin && (viewerID == 0 || lot.SellerID != viewerID) {
+		return nil, errors.New("лот не найден")
+	}
+	if counts, errCount := s.watchlistStore.CountWatchersByLotIDs([]uint{lot.ID}); errCount == nil {
+		lot.WatcherCount = counts[lot.ID]
+	}
+	if auction, errAuction := s.auctionStore.GetAuctionByID(lot.AuctionID); errAuction == nil && auction != nil {
+		if format, errFormat := auctionFormatFor(auction.Format); errFormat == nil {
+			format.PublicView(lot)
+		}
+		applyAuctionRoles(auction)
+		lot.Roles = auction.Roles
+	}
 	return lot, nil
 }
 
 func (s *LotService) GetLotsByAuctionID(auctionID uint, page, pageSize int) ([]models.Lot, int64, error) {
-	_, err := s.auctionStore.GetAuctionByID(auctionID)
+	auction, err := s.auctionStore.GetAuctionByID(auctionID)
 	if err != nil {
 		if strings.Contains(err.Error(), "не найден") || errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, 0, fmt.Errorf("аукцион с ID %d не найден", auctionID)
@@ -246,10 +676,22 @@ func (s *LotService) GetLotsByAuctionID(auctionID uint, page, pageSize int) ([]m
 	}
 	offset := (page - 1) * pageSize
 
-	lots, total, err := s.lotStore.GetLotsByAuctionID(auctionID, offset, pageSize)
+	// Покупателям показываются только лоты, прошедшие модерацию
+	lots, total, err := s.lotStore.GetLotsByAuctionID(auctionID, offset, pageSize, map[string]string{"moderationStatus": string(models.ModerationApproved)})
 	if err != nil {
 		return nil, 0, fmt.Errorf("ошибка получения лотов для аукциона ID %d: %w", auctionID, err)
 	}
+	s.fillWatcherCounts(lots)
+	if auction != nil {
+		format, errFormat := auctionFormatFor(auction.Format)
+		applyAuctionRoles(auction)
+		for i := range lots {
+			if errFormat == nil {
+				format.PublicView(&lots[i])
+			}
+			lots[i].Roles = auction.Roles
+		}
+	}
 	return lots, total, nil
 }
 
@@ -263,5 +705,32 @@ func (s *LotService) GetAllLots(page, pageSize int, filters map[string]string) (
 		pageSize = 100
 	}
 	offset := (page - 1) * pageSize
-	return s.lotStore.GetAllLots(offset, pageSize, filters)
+	publicFilters := make(map[string]string, len(filters)+1)
+	for name, value := range filters {
+		publicFilters[name] = value
+	}
+	publicFilters["moderationStatus"] = string(models.ModerationApproved)
+	lots, total, err := s.lotStore.GetAllLots(offset, pageSize, publicFilters)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.fillWatcherCounts(lots)
+
+	auctionsByID := make(map[uint]*models.Auction)
+	for i := range lots {
+		auction, seen := auctionsByID[lots[i].AuctionID]
+		if !seen {
+			auction, _ = s.auctionStore.GetAuctionByID(lots[i].AuctionID)
+			applyAuctionRoles(auction)
+			auctionsByID[lots[i].AuctionID] = auction
+		}
+		if auction == nil {
+			continue
+		}
+		if format, errFormat := auctionFormatFor(auction.Format); errFormat == nil {
+			format.PublicView(&lots[i])
+		}
+		lots[i].Roles = auction.Roles
+	}
+	return lots, total, nil
 }
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// NotificationService ведет входящие уведомления пользователей и рассылает уведомления по подключенным каналам.
+// Реализует Notifier, поэтому остальные сервисы отправляют уведомления через него.
+type NotificationService struct {
+	notificationStore store.NotificationStore
+	userStore         store.UserStore
+	channels          []NotificationChannel
+}
+
+// NewNotificationService создает новый экземпляр NotificationService с указанными каналами доставки
+func NewNotificationService(ns store.NotificationStore, us store.UserStore, channels ...NotificationChannel) *NotificationService {
+	return &NotificationService{notificationStore: ns, userStore: us, channels: channels}
+}
+
+// Notify сохраняет уведомление во входящих пользователя и отправляет его по каналам доставки.
+// Доставка идет в фоне, чтобы медленный почтовый сервер не задерживал ставки и смену статусов;
+// ошибки каналов записываются в журнал.
+func (s *NotificationService) Notify(userID uint, subject, message string) error {
+	notification := models.Notification{UserID: userID, Subject: subject, Message: message}
+	if err := s.notificationStore.CreateNotification(&notification); err != nil {
+		return fmt.Errorf("ошибка сохранения уведомления: %w", err)
+	}
+	if len(s.channels) > 0 {
+		go s.deliver(notification)
+	}
+	return nil
+}
+
+// NotifyOnce работает как Notify, но с ключом идемпотентности: повторный вызов с тем же ключом
+// (например, при повторной обработке доменного события) не создает второго уведомления и не рассылает его.
+func (s *NotificationService) NotifyOnce(key string, userID uint, subject, message string) error {
+	notification := models.Notification{UserID: userID, Subject: subject, Message: message, IdempotencyKey: &key}
+	created, err := s.notificationStore.CreateNotificationOnce(&notification)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения уведомления: %w", err)
+	}
+	if created && len(s.channels) > 0 {
+		go s.deliver(notification)
+	}
+	return nil
+}
+
+func (s *NotificationService) deliver(notification models.Notification) {
+	user, err := s.userStore.GetUserByID(notification.UserID)
+	if err != nil || user == nil {
+		log.Printf("[NotificationService] Не удалось получить получателя уведомления %d: %v", notification.ID, err)
+		return
+	}
+	for _, channel := range s.channels {
+		if err := channel.Deliver(user, &notification); err != nil {
+			log.Printf("[NotificationService] Канал %s не доставил уведомление %d пользователю %d: %v",
+				channel.Name(), notification.ID, user.ID, err)
+		}
+	}
+}
+
+// GetMyNotifications возвращает входящие пользователя (при unreadOnly - только непрочитанные)
+// и общее число непрочитанных уведомлений
+func (s *NotificationService) GetMyNotifications(userID uint, page, pageSize int, unreadOnly bool) ([]models.Notification, int64, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	notifications, total, err := s.notificationStore.GetNotificationsByUserID(userID, offset, pageSize, unreadOnly)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("ошибка получения уведомлений: %w", err)
+	}
+	unread, err := s.notificationStore.CountUnreadNotifications(userID)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("ошибка подсчета непрочитанных уведомлений: %w", err)
+	}
+	return notifications, total, unread, nil
+}
+
+// MarkRead отмечает уведомление пользователя прочитанным
+func (s *NotificationService) MarkRead(userID, notificationID uint) (*models.Notification, error) {
+	notification, err := s.notificationStore.MarkNotificationRead(userID, notificationID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("ошибка отметки уведомления: %w", err)
+	}
+	if notification == nil {
+		return nil, errors.New("уведомление не найдено")
+	}
+	return notification, nil
+}
+
+// MarkAllRead отмечает прочитанными все уведомления пользователя и возвращает их количество
+func (s *NotificationService) MarkAllRead(userID uint) (int64, error) {
+	count, err := s.notificationStore.MarkAllNotificationsRead(userID, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("ошибка отметки уведомлений: %w", err)
+	}
+	return count, nil
+}
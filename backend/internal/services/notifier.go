@@ -0,0 +1,16 @@
+// backend/internal/services/notifier.go
+package services
+
+import "auction-app/backend/internal/models"
+
+// Notifier доставляет уведомления пользователям о событиях торгов
+type Notifier interface {
+	Notify(userID uint, subject, message string) error
+}
+
+// NotificationChannel - канал доставки уведомлений (электронная почта и т.п.). Уведомление всегда
+// сохраняется во входящих пользователя, а каналы дополнительно доставляют его за пределы приложения.
+type NotificationChannel interface {
+	Name() string
+	Deliver(user *models.User, notification *models.Notification) error
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	// outboxBatchSize - сколько событий обработчик берет из outbox за раз
+	outboxBatchSize = 100
+	// outboxLease - на это время событие закрепляется за экземпляром, который его обрабатывает
+	outboxLease = 2 * time.Minute
+	// outboxRetryBaseDelay - пауза перед первой повторной обработкой, далее она удваивается
+	outboxRetryBaseDelay = 5 * time.Second
+	// outboxMaxRetryDelay - предельная пауза между повторами
+	outboxMaxRetryDelay = 30 * time.Minute
+	// outboxMaxAttempts - после стольких неудачных попыток событие помечается проваленным
+	outboxMaxAttempts = 15
+)
+
+// OutboxSubscriber - потребитель доменных событий внутри приложения (уведомления, вебхуки, поток событий и т.п.).
+// Доставка гарантируется хотя бы один раз: после сбоя событие может прийти повторно, поэтому обработчик
+// должен быть идемпотентным, используя EventKey события.
+type OutboxSubscriber interface {
+	Name() string
+	Handle(event *models.OutboxEvent) error
+}
+
+// OutboxWaker будит обработчик outbox после фиксации транзакции с новыми событиями
+type OutboxWaker interface {
+	Wake()
+}
+
+// newOutboxEvent подготавливает доменное событие для записи в outbox
+func newOutboxEvent(eventType models.DomainEventType, aggregateType string, aggregateID uint, payload interface{}) (models.OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return models.OutboxEvent{}, fmt.Errorf("ошибка формирования события %s: %w", eventType, err)
+	}
+	key, err := randomHex(16)
+	if err != nil {
+		return models.OutboxEvent{}, fmt.Errorf("ошибка создания ключа события %s: %w", eventType, err)
+	}
+	now := time.Now()
+	return models.OutboxEvent{
+		EventKey:      key,
+		EventType:     eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       string(data),
+		Status:        models.OutboxPending,
+		NextAttemptAt: now,
+		OccurredAt:    now,
+	}, nil
+}
+
+// OutboxRelay передает события из outbox подписчикам. Для каждого подписчика отдельно запоминается,
+// что событие обработано, поэтому при сбое одного из них остальные событие повторно не получают.
+type OutboxRelay struct {
+	outboxStore store.OutboxStore
+	subscribers []OutboxSubscriber
+	wakeup      chan struct{}
+}
+
+// NewOutboxRelay создает обработчик outbox с указанными подписчиками
+func NewOutboxRelay(os store.OutboxStore, subscribers ...OutboxSubscriber) *OutboxRelay {
+	return &OutboxRelay{outboxStore: os, subscribers: subscribers, wakeup: make(chan struct{}, 1)}
+}
+
+// Wake запускает обработку без ожидания очередного тика
+func (r *OutboxRelay) Wake() {
+	select {
+	case r.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// Start запускает фоновую обработку outbox: по таймеру и сразу после записи новых событий
+func (r *OutboxRelay) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := r.RunOnce(time.Now()); err != nil {
+				log.Printf("[OutboxRelay] Ошибка обработки outbox: %v", err)
+			}
+			select {
+			case <-ticker.C:
+			case <-r.wakeup:
+			}
+		}
+	}()
+}
+
+// RunOnce обрабатывает все события, время попытки которых наступило. Событие сначала закрепляется
+// за экземпляром, поэтому при нескольких экземплярах бэкенда его обрабатывает один из них.
+func (r *OutboxRelay) RunOnce(now time.Time) error {
+	for {
+		events, err := r.outboxStore.GetDueOutboxEvents(now, outboxBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range events {
+			event := &events[i]
+			claimed, err := r.outboxStore.ClaimOutboxEvent(event.ID, event.NextAttemptAt, time.Now().Add(outboxLease))
+			if err != nil {
+				return err
+			}
+			if claimed {
+				r.process(event)
+			}
+		}
+		if len(events) < outboxBatchSize {
+			return nil
+		}
+	}
+}
+
+// process передает событие подписчикам, которые его еще не обработали, и планирует повтор при ошибках
+func (r *OutboxRelay) process(event *models.OutboxEvent) {
+	consumed, err := r.outboxStore.GetOutboxConsumers(event.ID)
+	if err != nil {
+		log.Printf("[OutboxRelay] Не удалось получить отметки обработки события %d: %v", event.ID, err)
+		return
+	}
+	done := make(map[string]bool, len(consumed))
+	for _, consumer := range consumed {
+		done[consumer] = true
+	}
+
+	var failures []string
+	for _, subscriber := range r.subscribers {
+		if done[subscriber.Name()] {
+			continue
+		}
+		if err := subscriber.Handle(event); err != nil {
+			failures = append(failures, subscriber.Name()+": "+err.Error())
+			continue
+		}
+		if err := r.outboxStore.MarkOutboxConsumed(event.ID, subscriber.Name(), time.Now()); err != nil {
+			failures = append(failures, subscriber.Name()+": "+err.Error())
+		}
+	}
+
+	now := time.Now()
+	event.Attempts++
+	if len(failures) == 0 {
+		event.Status = models.OutboxProcessed
+		event.ProcessedAt = &now
+		event.LastError = ""
+	} else {
+		event.LastError = strings.Join(failures, "; ")
+		log.Printf("[OutboxRelay] Событие %d (%s) обработано не всеми подписчиками: %s", event.ID, event.EventType, event.LastError)
+		if event.Attempts >= outboxMaxAttempts {
+			event.Status = models.OutboxFailed
+		} else {
+			delay := outboxRetryBaseDelay << (event.Attempts - 1)
+			if delay > outboxMaxRetryDelay {
+				delay = outboxMaxRetryDelay
+			}
+			event.NextAttemptAt = now.Add(delay)
+		}
+	}
+	if err := r.outboxStore.UpdateOutboxEvent(event); err != nil {
+		log.Printf("[OutboxRelay] Не удалось сохранить результат обработки события %d: %v", event.ID, err)
+	}
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"fmt"
+)
+
+// notificationSubscriber превращает доменные события во входящие уведомления участников торгов.
+// Ключ уведомления строится из ключа события и получателя, поэтому повтор события не дублирует уведомления.
+type notificationSubscriber struct {
+	notifications  *NotificationService
+	watchlistStore store.WatchlistStore
+}
+
+// NewNotificationSubscriber создает подписчика outbox, отправляющего уведомления пользователям
+func NewNotificationSubscriber(ns *NotificationService, ws store.WatchlistStore) OutboxSubscriber {
+	return &notificationSubscriber{notifications: ns, watchlistStore: ws}
+}
+
+func (s *notificationSubscriber) Name() string {
+	return "notifications"
+}
+
+func (s *notificationSubscriber) Handle(event *models.OutboxEvent) error {
+	switch event.EventType {
+	case models.DomainBidPlaced:
+		var payload models.BidPlacedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		return s.notifyOutbid(event, payload)
+	case models.DomainLotWithdrawn:
+		var payload models.LotWithdrawnPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		subject := fmt.Sprintf("Лот № %d «%s» снят с торгов", payload.LotNumber, payload.LotName)
+		message := fmt.Sprintf("Организатор аукциона «%s» снял лот с торгов. Причина: %s. Ваши ставки по лоту аннулированы.",
+			payload.AuctionName, payload.Reason)
+		for _, userID := range payload.Leaders {
+			if err := s.notify(event, userID, subject, message); err != nil {
+				return err
+			}
+		}
+	case models.DomainAuctionStatusChanged:
+		var payload models.AuctionStatusChangedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		if payload.Status == models.StatusActive && payload.PreviousStatus == models.StatusScheduled {
+			return s.notifyAuctionStarted(event, payload)
+		}
+	case models.DomainLotSettled:
+		var payload models.LotSettledPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		return s.notifyLotSettled(event, payload)
+	}
+	return nil
+}
+
+func (s *notificationSubscriber) notify(event *models.OutboxEvent, userID uint, subject, message string) error {
+	return s.notifications.NotifyOnce(fmt.Sprintf("%s:%d", event.EventKey, userID), userID, subject, message)
+}
+
+// notifyOutbid уведомляет участников, которые лидировали до ставки и потеряли лидерство
+func (s *notificationSubscriber) notifyOutbid(event *models.OutboxEvent, payload models.BidPlacedPayload) error {
+	stillLeading := make(map[uint]bool, len(payload.CurrentLeaders))
+	for _, userID := range payload.CurrentLeaders {
+		stillLeading[userID] = true
+	}
+	subject := fmt.Sprintf("Вашу ставку перебили: лот № %d «%s»", payload.LotNumber, payload.LotName)
+	message := fmt.Sprintf("Текущая цена лота: %.2f. Сделайте новую ставку, чтобы вернуть лидерство.", payload.CurrentPrice)
+	for _, userID := range payload.PreviousLeaders {
+		if userID == payload.BidderID || stillLeading[userID] {
+			continue
+		}
+		if err := s.notify(event, userID, subject, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notifyAuctionStarted уведомляет пользователей, которые следят за аукционом или его лотами, о начале торгов
+func (s *notificationSubscriber) notifyAuctionStarted(event *models.OutboxEvent, payload models.AuctionStatusChangedPayload) error {
+	watcherIDs, err := s.watchlistStore.GetWatcherIDsByAuctionID(payload.AuctionID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения наблюдающих за аукционом: %w", err)
+	}
+	subject := fmt.Sprintf("Начались торги: «%s»", payload.Name)
+	message := fmt.Sprintf("Аукцион «%s» (%s), за которым вы следите, открыт для ставок.", payload.Name, payload.Location)
+	for _, userID := range watcherIDs {
+		if err := s.notify(event, userID, subject, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notifyLotSettled сообщает победителям о выигранном лоте, а продавцу - об итогах торгов по нему
+func (s *notificationSubscriber) notifyLotSettled(event *models.OutboxEvent, payload models.LotSettledPayload) error {
+	for _, allocation := range payload.Allocations {
+		err := s.notify(event, allocation.UserID,
+			fmt.Sprintf("Вы выиграли лот № %d «%s»", payload.LotNumber, payload.LotName),
+			fmt.Sprintf("Торги аукциона «%s» завершены. Вам достается единиц: %d по цене %.2f за единицу.",
+				payload.AuctionName, allocation.Quantity, allocation.Price))
+		if err != nil {
+			return err
+		}
+	}
+	if payload.Status == models.StatusSold && payload.FinalPrice != nil {
+		return s.notify(event, payload.SellerID,
+			fmt.Sprintf("Лот № %d «%s» продан", payload.LotNumber, payload.LotName),
+			fmt.Sprintf("Торги аукциона «%s» завершены, цена продажи: %.2f.", payload.AuctionName, *payload.FinalPrice))
+	}
+	if payload.Status == models.StatusUnsold {
+		return s.notify(event, payload.SellerID,
+			fmt.Sprintf("Лот № %d «%s» не продан", payload.LotNumber, payload.LotName),
+			fmt.Sprintf("Торги аукциона «%s» завершены без победителя по лоту.", payload.AuctionName))
+	}
+	return nil
+}
+
+// realtimeSubscriber передает доменные события в поток событий торгов (SSE). Клиенты могут распознать
+// повтор по полю id события, которое совпадает с ключом доменного события.
+type realtimeSubscriber struct {
+	events EventPublisher
+}
+
+// NewRealtimeSubscriber создает подписчика outbox, публикующего события в потоки аукционов и лотов
+func NewRealtimeSubscriber(events EventPublisher) OutboxSubscriber {
+	return &realtimeSubscriber{events: events}
+}
+
+func (s *realtimeSubscriber) Name() string {
+	return "realtime"
+}
+
+func (s *realtimeSubscriber) Handle(event *models.OutboxEvent) error {
+	switch event.EventType {
+	case models.DomainBidPlaced:
+		var payload models.BidPlacedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		s.publish(event, models.EventBidPlaced, payload.AuctionID, &payload.LotID, map[string]interface{}{
+			"lotNumber": payload.LotNumber,
+			"amount":    payload.Amount,
+			"quantity":  payload.Quantity,
+			"placedAt":  payload.PlacedAt,
+		})
+		if payload.CurrentPrice != payload.PreviousPrice || !sameUserID(payload.HighestBidderID, payload.PreviousHighestBidderID) {
+			s.publish(event, models.EventPriceChanged, payload.AuctionID, &payload.LotID, map[string]interface{}{
+				"lotNumber":       payload.LotNumber,
+				"currentPrice":    payload.CurrentPrice,
+				"highestBidderId": payload.HighestBidderID,
+			})
+		}
+		if payload.LotStatus != payload.PreviousLotStatus {
+			s.publish(event, models.EventLotStatusChanged, payload.AuctionID, &payload.LotID, map[string]interface{}{
+				"lotNumber": payload.LotNumber,
+				"status":    payload.LotStatus,
+			})
+		}
+	case models.DomainLotWithdrawn:
+		var payload models.LotWithdrawnPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		s.publish(event, models.EventLotStatusChanged, payload.AuctionID, &payload.LotID, map[string]interface{}{
+			"lotNumber": payload.LotNumber,
+			"status":    payload.Status,
+			"reason":    payload.Reason,
+		})
+	case models.DomainLotStatusChanged:
+		var payload models.LotStatusChangedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		s.publish(event, models.EventLotStatusChanged, payload.AuctionID, &payload.LotID, map[string]interface{}{
+			"lotNumber": payload.LotNumber,
+			"status":    payload.Status,
+		})
+	case models.DomainLotSettled:
+		var payload models.LotSettledPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		s.publish(event, models.EventLotStatusChanged, payload.AuctionID, &payload.LotID, map[string]interface{}{
+			"lotNumber":  payload.LotNumber,
+			"status":     payload.Status,
+			"finalPrice": payload.FinalPrice,
+		})
+	case models.DomainAuctionStatusChanged:
+		var payload models.AuctionStatusChangedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		s.publish(event, models.EventAuctionStatusChanged, payload.AuctionID, nil, map[string]interface{}{
+			"status":         payload.Status,
+			"previousStatus": payload.PreviousStatus,
+		})
+	}
+	return nil
+}
+
+func (s *realtimeSubscriber) publish(event *models.OutboxEvent, eventType models.AuctionEventType, auctionID uint, lotID *uint, data map[string]interface{}) {
+	s.events.Publish(models.AuctionEvent{
+		ID:         event.EventKey,
+		Type:       eventType,
+		AuctionID:  auctionID,
+		LotID:      lotID,
+		Data:       data,
+		OccurredAt: event.OccurredAt,
+	})
+}
+
+// webhookSubscriber ставит в очередь вебхуки по доменным событиям. ID события вебхука совпадает
+// с ключом доменного события, поэтому повтор события не создает повторных доставок.
+type webhookSubscriber struct {
+	webhooks WebhookDispatcher
+}
+
+// NewWebhookSubscriber создает подписчика outbox, отправляющего вебхуки внешним системам
+func NewWebhookSubscriber(webhooks WebhookDispatcher) OutboxSubscriber {
+	return &webhookSubscriber{webhooks: webhooks}
+}
+
+func (s *webhookSubscriber) Name() string {
+	return "webhooks"
+}
+
+func (s *webhookSubscriber) Handle(event *models.OutboxEvent) error {
+	switch event.EventType {
+	case models.DomainBidPlaced:
+		var payload models.BidPlacedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		return s.webhooks.Dispatch(event.EventKey, models.WebhookBidPlaced, []uint{payload.SellerID, payload.OrganizerID}, map[string]interface{}{
+			"auctionId":       payload.AuctionID,
+			"lotId":           payload.LotID,
+			"lotNumber":       payload.LotNumber,
+			"lotName":         payload.LotName,
+			"bidId":           payload.BidID,
+			"bidderId":        payload.BidderID,
+			"amount":          payload.Amount,
+			"quantity":        payload.Quantity,
+			"currentPrice":    payload.CurrentPrice,
+			"highestBidderId": payload.HighestBidderID,
+			"placedAt":        payload.PlacedAt,
+		})
+	case models.DomainLotSettled:
+		var payload models.LotSettledPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		owners := []uint{payload.SellerID, payload.OrganizerID}
+		data := map[string]interface{}{
+			"auctionId": payload.AuctionID,
+			"lotId":     payload.LotID,
+			"lotNumber": payload.LotNumber,
+			"lotName":   payload.LotName,
+		}
+		if payload.Status == models.StatusSold {
+			winners := make([]map[string]interface{}, 0, len(payload.Allocations))
+			for _, allocation := range payload.Allocations {
+				winners = append(winners, map[string]interface{}{
+					"userId":   allocation.UserID,
+					"quantity": allocation.Quantity,
+					"price":    allocation.Price,
+				})
+			}
+			data["finalPrice"] = payload.FinalPrice
+			data["winners"] = winners
+			return s.webhooks.Dispatch(event.EventKey, models.WebhookLotSold, owners, data)
+		}
+		if payload.Status == models.StatusUnsold {
+			data["reason"] = payload.Reason
+			return s.webhooks.Dispatch(event.EventKey, models.WebhookLotUnsold, owners, data)
+		}
+	case models.DomainAuctionStatusChanged:
+		var payload models.AuctionStatusChangedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		if payload.Status != models.StatusCompleted || payload.PreviousStatus != models.StatusActive {
+			return nil
+		}
+		owners := append([]uint{payload.OrganizerID}, payload.SellerIDs...)
+		return s.webhooks.Dispatch(event.EventKey, models.WebhookAuctionCompleted, owners, map[string]interface{}{
+			"auctionId":  payload.AuctionID,
+			"name":       payload.Name,
+			"soldLots":   payload.SoldLots,
+			"unsoldLots": payload.UnsoldLots,
+		})
+	}
+	return nil
+}
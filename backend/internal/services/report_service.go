@@ -9,14 +9,15 @@ import (
 
 // ReportService предоставляет методы для генерации отчетов и выполнения специфических запросов
 type ReportService struct {
-	auctionStore store.AuctionStore
-	lotStore     store.LotStore
-	userStore    store.UserStore
+	auctionStore  store.AuctionStore
+	lotStore      store.LotStore
+	userStore     store.UserStore
+	categoryStore store.CategoryStore
 }
 
 // NewReportService создает новый экземпляр ReportService
-func NewReportService(as store.AuctionStore, ls store.LotStore, us store.UserStore) *ReportService {
-	return &ReportService{auctionStore: as, lotStore: ls, userStore: us}
+func NewReportService(as store.AuctionStore, ls store.LotStore, us store.UserStore, cs store.CategoryStore) *ReportService {
+	return &ReportService{auctionStore: as, lotStore: ls, userStore: us, categoryStore: cs}
 }
 
 // GetLotWithMaxPriceDifference возвращает лот с максимальной разницей между начальной и конечной ценой, а также саму разницу
@@ -63,16 +64,23 @@ func (s *ReportService) GetMostExpensiveSoldLotInfo() (map[string]interface{}, e
 		sellerInfo.PasswordHash = ""
 	}
 
+	buyers := make([]*models.User, 0, len(lot.Allocations))
+	for _, allocation := range lot.Allocations {
+		if allocation.User != nil {
+			allocation.User.PasswordHash = ""
+			buyers = append(buyers, allocation.User)
+		}
+	}
 	var buyerInfo *models.User
-	if lot.FinalBuyer != nil {
-		buyerInfo = lot.FinalBuyer
-		buyerInfo.PasswordHash = ""
+	if len(buyers) > 0 {
+		buyerInfo = buyers[0]
 	}
 
 	return map[string]interface{}{
 		"lot":    lot,
 		"seller": sellerInfo,
 		"buyer":  buyerInfo,
+		"buyers": buyers,
 	}, nil
 }
 
@@ -140,8 +148,9 @@ func (s *ReportService) GetItemsForSaleByDateAndAuction(auctionID uint, dateStr
 	return itemsForSale, nil
 }
 
-// GetBuyersOfItemsWithSpecificity возвращает покупателей, купивших предметы заданной специфики аукциона
-func (s *ReportService) GetBuyersOfItemsWithSpecificity(specificity string, page, pageSize int) ([]models.User, int64, error) {
+// GetBuyersOfItemsWithSpecificity возвращает покупателей, купивших предметы заданной рубрики (включая подрубрики).
+// Если рубрика не указана (categoryID = 0), используется устаревший поиск по тексту специфики аукциона.
+func (s *ReportService) GetBuyersOfItemsWithSpecificity(specificity string, categoryID uint, page, pageSize int) ([]models.User, int64, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -150,15 +159,22 @@ func (s *ReportService) GetBuyersOfItemsWithSpecificity(specificity string, page
 	}
 	offset := (page - 1) * pageSize
 
-	users, total, err := s.userStore.GetBuyersByAuctionSpecificity(specificity, offset, pageSize)
+	if categoryID != 0 {
+		if _, err := getCategory(s.categoryStore, categoryID); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	users, total, err := s.userStore.GetBuyersByAuctionSpecificity(specificity, categoryID, offset, pageSize)
 	if err != nil {
 		return nil, 0, fmt.Errorf("ошибка получения покупателей по специфике из хранилища: %w", err)
 	}
 	return users, total, nil
 }
 
-// GetSellersWithSalesByAuctionSpecificity возвращает продавцов, продавших предметы на аукционах заданной специфики, с общей суммой продаж не менее minSales, отсортированных по сумме.
-func (s *ReportService) GetSellersWithSalesByAuctionSpecificity(specificity string, minSales float64, page, pageSize int) ([]models.SellerSalesReport, int64, error) {
+// GetSellersWithSalesByAuctionSpecificity возвращает продавцов, продавших предметы заданной рубрики (включая подрубрики), с общей суммой продаж не менее minSales, отсортированных по сумме.
+// Если рубрика не указана (categoryID = 0), используется устаревший поиск по тексту специфики аукциона.
+func (s *ReportService) GetSellersWithSalesByAuctionSpecificity(specificity string, categoryID uint, minSales float64, page, pageSize int) ([]models.SellerSalesReport, int64, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -170,9 +186,50 @@ func (s *ReportService) GetSellersWithSalesByAuctionSpecificity(specificity stri
 	}
 	offset := (page - 1) * pageSize
 
-	report, total, err := s.userStore.GetSellersWithSalesByAuctionSpecificity(specificity, minSales, offset, pageSize)
+	if categoryID != 0 {
+		if _, err := getCategory(s.categoryStore, categoryID); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	report, total, err := s.userStore.GetSellersWithSalesByAuctionSpecificity(specificity, categoryID, minSales, offset, pageSize)
 	if err != nil {
 		return nil, 0, fmt.Errorf("ошибка получения отчета по продажам продавцов: %w", err)
 	}
 	return report, total, nil
 }
+
+// GetEstimatePerformanceReport сравнивает цены продажи с предпродажными оценками лотов:
+// сколько лотов продано выше, в пределах и ниже оценки - по аукционам, по продавцам и в целом.
+func (s *ReportService) GetEstimatePerformanceReport(filters map[string]string) (*models.EstimatePerformanceReport, error) {
+	byAuction, err := s.lotStore.GetEstimatePerformance("auction", filters)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения отчета по оценкам в разрезе аукционов: %w", err)
+	}
+	bySeller, err := s.lotStore.GetEstimatePerformance("seller", filters)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения отчета по оценкам в разрезе продавцов: %w", err)
+	}
+
+	report := &models.EstimatePerformanceReport{
+		Summary:   models.EstimatePerformanceRow{GroupName: "Итого"},
+		ByAuction: byAuction,
+		BySeller:  bySeller,
+	}
+	if report.ByAuction == nil {
+		report.ByAuction = []models.EstimatePerformanceRow{}
+	}
+	if report.BySeller == nil {
+		report.BySeller = []models.EstimatePerformanceRow{}
+	}
+	for _, row := range byAuction {
+		report.Summary.LotsTotal += row.LotsTotal
+		report.Summary.AboveEstimate += row.AboveEstimate
+		report.Summary.WithinEstimate += row.WithinEstimate
+		report.Summary.BelowEstimate += row.BelowEstimate
+		report.Summary.HammerTotal += row.HammerTotal
+		report.Summary.EstimateLowTotal += row.EstimateLowTotal
+		report.Summary.EstimateHighTotal += row.EstimateHighTotal
+	}
+	return report, nil
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxSavedSearchesPerUser - сколько поисков может сохранить один пользователь
+	maxSavedSearchesPerUser = 20
+	// savedSearchBatchSize - сколько сохраненных поисков фоновая задача читает из БД за раз
+	savedSearchBatchSize = 100
+	// savedSearchMaxListedLots - сколько новых лотов перечисляется в одном уведомлении
+	savedSearchMaxListedLots = 10
+	// savedSearchSettleDelay - задержка окна проверки: лот, одобренный прямо перед запуском задачи,
+	// мог еще не быть зафиксирован в БД, поэтому он будет учтен при следующем запуске
+	savedSearchSettleDelay = time.Minute
+)
+
+// SavedSearchService управляет сохраненными поисками лотов и уведомляет пользователей о новых совпадениях
+type SavedSearchService struct {
+	savedSearchStore store.SavedSearchStore
+	lotStore         store.LotStore
+	notifier         Notifier
+}
+
+// NewSavedSearchService создает новый экземпляр SavedSearchService
+func NewSavedSearchService(sss store.SavedSearchStore, ls store.LotStore, n Notifier) *SavedSearchService {
+	return &SavedSearchService{savedSearchStore: sss, lotStore: ls, notifier: n}
+}
+
+// validateSearchFilters проверяет фильтры сохраненного поиска: допускаются те же параметры, что у GET /lots
+func validateSearchFilters(filters map[string]string) (models.SearchFilters, error) {
+	result := make(models.SearchFilters, len(filters))
+	for name, value := range filters {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		switch {
+		case name == "status":
+			switch models.LotStatus(value) {
+			case models.StatusPending, models.StatusLotActive, models.StatusSold, models.StatusUnsold, models.StatusWithdrawn:
+			default:
+				return nil, fmt.Errorf("некорректный фильтр status: %s", value)
+			}
+		case name == "sellerId" || name == "auctionId" || name == "categoryId":
+			if _, err := strconv.ParseUint(value, 10, 32); err != nil {
+				return nil, fmt.Errorf("некорректный фильтр %s: ожидается ID", name)
+			}
+		case name == "auctionMonth":
+			if _, err := time.Parse("2006-01", value); err != nil {
+				return nil, errors.New("некорректный фильтр auctionMonth: ожидается формат ГГГГ-ММ")
+			}
+		case strings.HasPrefix(name, "attr.") && len(name) > len("attr."):
+		case (strings.HasPrefix(name, "attrMin.") && len(name) > len("attrMin.")) ||
+			(strings.HasPrefix(name, "attrMax.") && len(name) > len("attrMax.")):
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, fmt.Errorf("некорректный фильтр %s: ожидается число", name)
+			}
+		default:
+			return nil, fmt.Errorf("некорректный фильтр %s: поддерживаются status, sellerId, auctionId, categoryId, auctionMonth и attr.<ключ>", name)
+		}
+		result[name] = value
+	}
+	if len(result) == 0 {
+		return nil, errors.New("укажите хотя бы один фильтр поиска")
+	}
+	return result, nil
+}
+
+// CreateSavedSearch сохраняет набор фильтров. Уведомления приходят только о лотах, опубликованных после сохранения.
+func (s *SavedSearchService) CreateSavedSearch(userID uint, input models.SavedSearchInput) (*models.SavedSearch, error) {
+	filters, err := validateSearchFilters(input.Filters)
+	if err != nil {
+		return nil, err
+	}
+	_, total, err := s.savedSearchStore.GetSavedSearchesByUserID(userID, 0, 1)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка проверки сохраненных поисков: %w", err)
+	}
+	if total >= maxSavedSearchesPerUser {
+		return nil, fmt.Errorf("нельзя сохранить больше %d поисков", maxSavedSearchesPerUser)
+	}
+
+	search := models.SavedSearch{
+		UserID:        userID,
+		Name:          strings.TrimSpace(input.Name),
+		Filters:       filters,
+		LastCheckedAt: time.Now(),
+	}
+	if err := s.savedSearchStore.CreateSavedSearch(&search); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения поиска: %w", err)
+	}
+	return &search, nil
+}
+
+// GetMySavedSearches возвращает сохраненные поиски пользователя
+func (s *SavedSearchService) GetMySavedSearches(userID uint, page, pageSize int) ([]models.SavedSearch, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	searches, total, err := s.savedSearchStore.GetSavedSearchesByUserID(userID, offset, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения сохраненных поисков: %w", err)
+	}
+	return searches, total, nil
+}
+
+// UpdateSavedSearch меняет название и фильтры поиска. Проверка новых фильтров начинается с момента изменения.
+func (s *SavedSearchService) UpdateSavedSearch(userID, searchID uint, input models.SavedSearchInput) (*models.SavedSearch, error) {
+	filters, err := validateSearchFilters(input.Filters)
+	if err != nil {
+		return nil, err
+	}
+	search, err := s.savedSearchStore.GetSavedSearchByID(searchID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения сохраненного поиска: %w", err)
+	}
+	if search == nil || search.UserID != userID {
+		return nil, errors.New("сохраненный поиск не найден")
+	}
+
+	search.Name = strings.TrimSpace(input.Name)
+	search.Filters = filters
+	search.LastCheckedAt = time.Now()
+	if err := s.savedSearchStore.UpdateSavedSearch(search); err != nil {
+		return nil, fmt.Errorf("ошибка обновления сохраненного поиска: %w", err)
+	}
+	return search, nil
+}
+
+// DeleteSavedSearch удаляет сохраненный поиск пользователя
+func (s *SavedSearchService) DeleteSavedSearch(userID, searchID uint) error {
+	return s.savedSearchStore.DeleteSavedSearch(userID, searchID)
+}
+
+// StartAlertJob запускает фоновую проверку сохраненных поисков с заданным интервалом
+func (s *SavedSearchService) StartAlertJob(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := s.RunAlerts(time.Now()); err != nil {
+				log.Printf("[SavedSearchService] Ошибка проверки сохраненных поисков: %v", err)
+			}
+		}
+	}()
+}
+
+// RunAlerts проверяет все сохраненные поиски по лотам, опубликованным с момента предыдущей проверки,
+// и отправляет владельцам уведомления о совпадениях. Ошибка по одному поиску не останавливает остальные.
+func (s *SavedSearchService) RunAlerts(now time.Time) error {
+	// в БД время хранится с точностью до микросекунд, граница окна должна совпадать с сохраненной
+	checkUntil := now.Add(-savedSearchSettleDelay).Truncate(time.Microsecond)
+	var afterID uint
+	for {
+		searches, err := s.savedSearchStore.GetSavedSearchesBatch(afterID, savedSearchBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(searches) == 0 {
+			return nil
+		}
+		for _, search := range searches {
+			afterID = search.ID
+			if err := s.checkSavedSearch(search, checkUntil); err != nil {
+				log.Printf("[SavedSearchService] Не удалось проверить сохраненный поиск %d: %v", search.ID, err)
+			}
+		}
+	}
+}
+
+// checkSavedSearch ищет лоты, опубликованные в окне (LastCheckedAt, checkUntil], и сдвигает окно.
+// Если уведомление не удалось доставить, окно не сдвигается и лоты будут найдены при следующей проверке.
+func (s *SavedSearchService) checkSavedSearch(search models.SavedSearch, checkUntil time.Time) error {
+	if !checkUntil.After(search.LastCheckedAt) {
+		return nil
+	}
+	filters := make(map[string]string, len(search.Filters)+3)
+	for name, value := range search.Filters {
+		filters[name] = value
+	}
+	filters["moderationStatus"] = string(models.ModerationApproved)
+	filters["publishedAfter"] = search.LastCheckedAt.Format(time.RFC3339Nano)
+	filters["publishedBefore"] = checkUntil.Format(time.RFC3339Nano)
+
+	lots, total, err := s.lotStore.GetAllLots(0, savedSearchMaxListedLots, filters)
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		subject := fmt.Sprintf("Новые лоты по поиску «%s»: %d", search.Name, total)
+		lines := make([]string, 0, len(lots)+1)
+		for _, lot := range lots {
+			lines = append(lines, fmt.Sprintf("Лот № %d «%s» (аукцион %d, лот %d), текущая цена %.2f",
+				lot.LotNumber, lot.Name, lot.AuctionID, lot.ID, lot.CurrentPrice))
+		}
+		if total > int64(len(lots)) {
+			lines = append(lines, fmt.Sprintf("и еще %d", total-int64(len(lots))))
+		}
+		if err := s.notifier.Notify(search.UserID, subject, strings.Join(lines, "\n")); err != nil {
+			return err
+		}
+	}
+	return s.savedSearchStore.UpdateLastCheckedAt(search.ID, checkUntil)
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// smtpChannel отправляет уведомления письмом на адрес пользователя. Для разработки подойдет
+// локальный тестовый SMTP-сервер (например, MailHog): без логина письма отправляются без авторизации.
+type smtpChannel struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     mail.Address
+}
+
+// NewSMTPChannel создает канал доставки уведомлений по электронной почте
+func NewSMTPChannel(host string, port int, username, password, from string) (NotificationChannel, error) {
+	fromAddress, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("некорректный адрес отправителя %q: %w", from, err)
+	}
+	return &smtpChannel{
+		addr:     net.JoinHostPort(host, strconv.Itoa(port)),
+		host:     host,
+		username: username,
+		password: password,
+		from:     *fromAddress,
+	}, nil
+}
+
+func (c *smtpChannel) Name() string {
+	return "smtp"
+}
+
+func (c *smtpChannel) Deliver(user *models.User, notification *models.Notification) error {
+	if user.Email == "" {
+		return nil
+	}
+	var auth smtp.Auth
+	if c.username != "" {
+		auth = smtp.PlainAuth("", c.username, c.password, c.host)
+	}
+	to := mail.Address{Name: user.FullName, Address: user.Email}
+	message, err := buildEmailMessage(c.from, to, notification)
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(c.addr, auth, c.from.Address, []string{to.Address}, message)
+}
+
+// buildEmailMessage собирает текстовое письмо в UTF-8: заголовки кодируются по RFC 2047, тело - quoted-printable
+func buildEmailMessage(from, to mail.Address, notification *models.Notification) ([]byte, error) {
+	var buf bytes.Buffer
+	sentAt := notification.CreatedAt
+	if sentAt.IsZero() {
+		sentAt = time.Now()
+	}
+	fmt.Fprintf(&buf, "From: %s\r\n", from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", sentAt.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	body := quotedprintable.NewWriter(&buf)
+	if _, err := body.Write([]byte(notification.Message)); err != nil {
+		return nil, err
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
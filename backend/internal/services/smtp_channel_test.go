@@ -0,0 +1,168 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"bufio"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// receivedMail - письмо, принятое тестовым SMTP-сервером
+type receivedMail struct {
+	from string
+	to   []string
+	data string
+}
+
+// startFakeSMTPServer принимает одно SMTP-соединение на 127.0.0.1 и возвращает адрес сервера
+// и канал, в который будет передано полученное письмо
+func startFakeSMTPServer(t *testing.T) (string, <-chan receivedMail) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("не удалось запустить тестовый SMTP-сервер: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan receivedMail, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		tp := textproto.NewConn(conn)
+		var msg receivedMail
+		tp.PrintfLine("220 localhost ESMTP test")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				tp.PrintfLine("250 localhost")
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				msg.from = line[len("MAIL FROM:"):]
+				tp.PrintfLine("250 OK")
+			case strings.HasPrefix(command, "RCPT TO:"):
+				msg.to = append(msg.to, line[len("RCPT TO:"):])
+				tp.PrintfLine("250 OK")
+			case command == "DATA":
+				tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				data, err := io.ReadAll(tp.DotReader())
+				if err != nil {
+					return
+				}
+				msg.data = string(data)
+				tp.PrintfLine("250 OK")
+			case command == "QUIT":
+				tp.PrintfLine("221 Bye")
+				received <- msg
+				return
+			default:
+				tp.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+	return listener.Addr().String(), received
+}
+
+func TestSMTPChannelDeliver(t *testing.T) {
+	addr, received := startFakeSMTPServer(t)
+	host, portStr, _ := net.SplitHostPort(addr)
+	port, _ := strconv.Atoi(portStr)
+
+	channel, err := NewSMTPChannel(host, port, "", "", "Аукцион <noreply@auction.local>")
+	if err != nil {
+		t.Fatalf("NewSMTPChannel: %v", err)
+	}
+	user := &models.User{ID: 7, FullName: "Иван Петров", Email: "ivan@example.com"}
+	notification := &models.Notification{
+		Subject:   "Ваша ставка перебита",
+		Message:   "По лоту №3 «Монета 1913 г.» предложена цена выше вашей: 1500.00 ₽",
+		CreatedAt: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	if err := channel.Deliver(user, notification); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	var msg receivedMail
+	select {
+	case msg = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("тестовый SMTP-сервер не получил письмо")
+	}
+
+	if msg.from != "<noreply@auction.local>" {
+		t.Errorf("MAIL FROM = %q, ожидалось <noreply@auction.local>", msg.from)
+	}
+	if len(msg.to) != 1 || msg.to[0] != "<ivan@example.com>" {
+		t.Errorf("RCPT TO = %q, ожидалось [<ivan@example.com>]", msg.to)
+	}
+
+	parsed, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(msg.data)))
+	if err != nil {
+		t.Fatalf("не удалось разобрать письмо: %v", err)
+	}
+	for _, header := range []string{"From", "To", "Subject"} {
+		if raw := parsed.Header.Get(header); strings.ContainsAny(raw, "АаИиВв") {
+			t.Errorf("заголовок %s содержит некодированный текст: %q", header, raw)
+		}
+	}
+
+	decoder := new(mime.WordDecoder)
+	subject, err := decoder.DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != notification.Subject {
+		t.Errorf("Subject = %q (ошибка %v), ожидалось %q", subject, err, notification.Subject)
+	}
+	from, err := parsed.Header.AddressList("From")
+	if err != nil || len(from) != 1 || from[0].Name != "Аукцион" || from[0].Address != "noreply@auction.local" {
+		t.Errorf("From = %v (ошибка %v)", from, err)
+	}
+	to, err := parsed.Header.AddressList("To")
+	if err != nil || len(to) != 1 || to[0].Name != user.FullName || to[0].Address != user.Email {
+		t.Errorf("To = %v (ошибка %v)", to, err)
+	}
+	if got := parsed.Header.Get("Date"); got != notification.CreatedAt.Format(time.RFC1123Z) {
+		t.Errorf("Date = %q, ожидалось %q", got, notification.CreatedAt.Format(time.RFC1123Z))
+	}
+	if got := parsed.Header.Get("Content-Type"); got != "text/plain; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if got := parsed.Header.Get("Content-Transfer-Encoding"); got != "quoted-printable" {
+		t.Errorf("Content-Transfer-Encoding = %q", got)
+	}
+
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if err != nil {
+		t.Fatalf("не удалось декодировать тело письма: %v", err)
+	}
+	// SMTP-клиент завершает данные переводом строки, если письмо им не оканчивается
+	if strings.TrimSuffix(string(body), "\n") != notification.Message {
+		t.Errorf("тело письма = %q, ожидалось %q", body, notification.Message)
+	}
+}
+
+func TestSMTPChannelSkipsUserWithoutEmail(t *testing.T) {
+	// Адрес заведомо недоступен: если канал попытается подключиться, Deliver вернет ошибку
+	channel, err := NewSMTPChannel("127.0.0.1", 1, "", "", "noreply@auction.local")
+	if err != nil {
+		t.Fatalf("NewSMTPChannel: %v", err)
+	}
+	if err := channel.Deliver(&models.User{ID: 1}, &models.Notification{Subject: "Тема", Message: "Текст"}); err != nil {
+		t.Errorf("для пользователя без email письмо не должно отправляться, получена ошибка %v", err)
+	}
+}
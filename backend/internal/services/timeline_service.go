@@ -0,0 +1,372 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// timelineDomainEvents - доменные события outbox, из которых строится хронология аукциона.
+// Ставки берутся из таблицы ставок: там есть и аннулированные ставки.
+var timelineDomainEvents = []models.DomainEventType{
+	models.DomainAuctionStatusChanged,
+	models.DomainLotStatusChanged,
+	models.DomainLotWithdrawn,
+	models.DomainLotSettled,
+}
+
+// TimelineService восстанавливает ход торгов аукциона для разбора спорных продаж
+type TimelineService struct {
+	auctionStore store.AuctionStore
+	bidStore     store.BidStore
+	outboxStore  store.OutboxStore
+}
+
+// NewTimelineService создает новый экземпляр TimelineService
+func NewTimelineService(as store.AuctionStore, bs store.BidStore, os store.OutboxStore) *TimelineService {
+	return &TimelineService{auctionStore: as, bidStore: bs, outboxStore: os}
+}
+
+// GetAuctionTimeline возвращает упорядоченную по времени хронологию аукциона: смены статусов, выход лотов
+// в торги, все ставки (в том числе аннулированные и отклоненные с причиной) и итоги по лотам.
+// При replay ставки проигрываются заново по правилам формата аукциона, а результат сравнивается
+// с сохраненными значениями лотов. Хронология доступна администратору и организатору аукциона.
+func (s *TimelineService) GetAuctionTimeline(auctionID uint, replay bool, currentUserID uint, currentUserRole models.UserRole) (*models.AuctionTimeline, error) {
+	auction, err := s.auctionStore.GetAuctionByID(auctionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения аукциона: %w", err)
+	}
+	if auction == nil {
+		return nil, errors.New("аукцион не найден")
+	}
+	if currentUserRole != models.RoleSystemAdmin && auction.CreatedByUserID != currentUserID {
+		return nil, errors.New("недостаточно прав: хронология аукциона доступна администратору и организатору аукциона")
+	}
+
+	lotIDs := make([]uint, 0, len(auction.Lots))
+	lotNumbers := make(map[uint]int, len(auction.Lots))
+	for _, lot := range auction.Lots {
+		lotIDs = append(lotIDs, lot.ID)
+		lotNumbers[lot.ID] = lot.LotNumber
+	}
+	domainEvents, err := s.outboxStore.GetOutboxEventsForAuction(auctionID, lotIDs, timelineDomainEvents)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения событий аукциона: %w", err)
+	}
+	bids, err := s.bidStore.GetBidHistoryByLotIDs(lotIDs)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения ставок аукциона: %w", err)
+	}
+	attempts, err := s.bidStore.GetBidAttemptsByAuctionID(auctionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения отклоненных ставок аукциона: %w", err)
+	}
+
+	organizerID := auction.CreatedByUserID
+	events := []models.TimelineEvent{{
+		At:     auction.CreatedAt,
+		Type:   models.TimelineAuctionCreated,
+		UserID: &organizerID,
+		Data:   map[string]interface{}{"name": auction.NameSpecificity, "format": auction.Format},
+	}}
+	withdrawalRecorded := make(map[uint]bool)
+	for i := range domainEvents {
+		event, err := timelineEventFromDomain(&domainEvents[i], lotNumbers)
+		if err != nil {
+			return nil, err
+		}
+		if event.Type == models.TimelineLotWithdrawn {
+			withdrawalRecorded[*event.LotID] = true
+		}
+		events = append(events, event)
+	}
+	// снятия лотов, сделанные до появления outbox, восстанавливаются по полям лота
+	for _, lot := range auction.Lots {
+		if lot.WithdrawnAt == nil || withdrawalRecorded[lot.ID] {
+			continue
+		}
+		lotID := lot.ID
+		events = append(events, models.TimelineEvent{
+			At:        *lot.WithdrawnAt,
+			Type:      models.TimelineLotWithdrawn,
+			LotID:     &lotID,
+			LotNumber: lot.LotNumber,
+			UserID:    lot.WithdrawnByUserID,
+			Data:      map[string]interface{}{"reason": lot.WithdrawalReason},
+		})
+	}
+	for _, bid := range bids {
+		lotID, userID := bid.LotID, bid.UserID
+		events = append(events, models.TimelineEvent{
+			At:        bid.BidTime,
+			Type:      models.TimelineBidPlaced,
+			LotID:     &lotID,
+			LotNumber: lotNumbers[lotID],
+			UserID:    &userID,
+			Data: map[string]interface{}{
+				"bidId":    bid.ID,
+				"amount":   bid.BidAmount,
+				"quantity": bid.Quantity,
+				"voided":   bid.Voided,
+			},
+		})
+	}
+	for _, attempt := range attempts {
+		lotID, userID := attempt.LotID, attempt.UserID
+		events = append(events, models.TimelineEvent{
+			At:        attempt.CreatedAt,
+			Type:      models.TimelineBidRejected,
+			LotID:     &lotID,
+			LotNumber: lotNumbers[lotID],
+			UserID:    &userID,
+			Data: map[string]interface{}{
+				"attemptId": attempt.ID,
+				"amount":    attempt.Amount,
+				"quantity":  attempt.Quantity,
+				"reason":    attempt.Reason,
+			},
+		})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].At.Before(events[j].At)
+	})
+
+	timeline := &models.AuctionTimeline{AuctionID: auction.ID, Status: auction.Status, Events: events}
+	if replay {
+		timeline.Replay, err = replayAuction(auction, bids)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return timeline, nil
+}
+
+// timelineEventFromDomain переводит доменное событие из outbox в событие хронологии
+func timelineEventFromDomain(event *models.OutboxEvent, lotNumbers map[uint]int) (models.TimelineEvent, error) {
+	entry := models.TimelineEvent{At: event.OccurredAt}
+	switch event.EventType {
+	case models.DomainAuctionStatusChanged:
+		var payload models.AuctionStatusChangedPayload
+		if err := event.Decode(&payload); err != nil {
+			return entry, fmt.Errorf("ошибка разбора события %s: %w", event.EventKey, err)
+		}
+		entry.Type = models.TimelineAuctionStatusChanged
+		entry.Data = map[string]interface{}{"status": payload.Status, "previousStatus": payload.PreviousStatus}
+		if payload.Status == models.StatusCompleted {
+			entry.Data["soldLots"] = payload.SoldLots
+			entry.Data["unsoldLots"] = payload.UnsoldLots
+		}
+		return entry, nil
+	case models.DomainLotStatusChanged:
+		var payload models.LotStatusChangedPayload
+		if err := event.Decode(&payload); err != nil {
+			return entry, fmt.Errorf("ошибка разбора события %s: %w", event.EventKey, err)
+		}
+		entry.Type = models.TimelineLotStatusChanged
+		if payload.Status == models.StatusLotActive {
+			entry.Type = models.TimelineLotOpened
+		}
+		entry.LotID = &payload.LotID
+		entry.Data = map[string]interface{}{"status": payload.Status, "previousStatus": payload.PreviousStatus}
+	case models.DomainLotWithdrawn:
+		var payload models.LotWithdrawnPayload
+		if err := event.Decode(&payload); err != nil {
+			return entry, fmt.Errorf("ошибка разбора события %s: %w", event.EventKey, err)
+		}
+		entry.Type = models.TimelineLotWithdrawn
+		entry.LotID = &payload.LotID
+		entry.Data = map[string]interface{}{"reason": payload.Reason, "leaders": payload.Leaders}
+	case models.DomainLotSettled:
+		var payload models.LotSettledPayload
+		if err := event.Decode(&payload); err != nil {
+			return entry, fmt.Errorf("ошибка разбора события %s: %w", event.EventKey, err)
+		}
+		entry.Type = models.TimelineLotSettled
+		entry.LotID = &payload.LotID
+		entry.Data = map[string]interface{}{
+			"status":      payload.Status,
+			"finalPrice":  payload.FinalPrice,
+			"allocations": payload.Allocations,
+			"reason":      payload.Reason,
+		}
+	default:
+		return entry, fmt.Errorf("событие %s типа %s не входит в хронологию", event.EventKey, event.EventType)
+	}
+	entry.LotNumber = lotNumbers[*entry.LotID]
+	return entry, nil
+}
+
+// replayAuction заново проигрывает действующие ставки по правилам формата аукциона, начиная со стартовых цен,
+// а для завершенного аукциона повторно подводит итоги, и сравнивает результат с сохраненными лотами.
+// Статус лота сравнивается только для завершенного аукциона: до подведения итогов он зависит от модерации.
+func replayAuction(auction *models.Auction, bids []models.Bid) ([]models.LotReplayResult, error) {
+	format, err := auctionFormatFor(auction.Format)
+	if err != nil {
+		return nil, err
+	}
+	bidsByLot := make(map[uint][]models.Bid)
+	for _, bid := range bids {
+		if !bid.Voided {
+			bidsByLot[bid.LotID] = append(bidsByLot[bid.LotID], bid)
+		}
+	}
+
+	replayed := *auction
+	replayed.Lots = make([]models.Lot, len(auction.Lots))
+	for i, stored := range auction.Lots {
+		lot := stored
+		lot.CurrentPrice = lot.StartPrice
+		lot.HighestBidderID = nil
+		lot.HighestBidder = nil
+		lot.FinalPrice = nil
+		lot.Allocations = nil
+		if lot.Status != models.StatusWithdrawn {
+			lot.Status = models.StatusLotActive
+		}
+		replayed.Lots[i] = lot
+	}
+	loadBids := func(lotID uint) ([]models.Bid, error) {
+		return bidsByLot[lotID], nil
+	}
+	for i := range replayed.Lots {
+		lot := &replayed.Lots[i]
+		var previous []models.Bid
+		for _, bid := range bidsByLot[lot.ID] {
+			format.ApplyBid(&BidContext{
+				Auction:  &replayed,
+				Lot:      lot,
+				BidderID: bid.UserID,
+				Amount:   bid.BidAmount,
+				Quantity: bid.Quantity,
+				Bids:     previous,
+				LoadBids: loadBids,
+			}, bid)
+			previous = append(previous, bid)
+		}
+	}
+
+	completed := auction.Status == models.StatusCompleted
+	if completed {
+		decisions, err := format.Settle(&replayed, loadBids)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка повторного подведения итогов торгов: %w", err)
+		}
+		settled := make(map[uint]models.Lot, len(decisions))
+		for _, decision := range decisions {
+			settled[decision.Lot.ID] = decision.Lot
+		}
+		for i := range replayed.Lots {
+			if lot, ok := settled[replayed.Lots[i].ID]; ok {
+				replayed.Lots[i] = lot
+			}
+		}
+	}
+
+	results := make([]models.LotReplayResult, 0, len(auction.Lots))
+	for i, stored := range auction.Lots {
+		result := models.LotReplayResult{
+			LotID:     stored.ID,
+			LotNumber: stored.LotNumber,
+			Stored:    lotOutcome(&stored),
+			Replayed:  lotOutcome(&replayed.Lots[i]),
+		}
+		result.Differences = compareLotOutcomes(result.Stored, result.Replayed, completed)
+		result.Matches = len(result.Differences) == 0
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func lotOutcome(lot *models.Lot) models.LotOutcome {
+	return models.LotOutcome{
+		Status:          lot.Status,
+		CurrentPrice:    lot.CurrentPrice,
+		HighestBidderID: lot.HighestBidderID,
+		FinalPrice:      lot.FinalPrice,
+		Allocations:     allocationOutcomes(lot.Allocations),
+	}
+}
+
+// allocationOutcomes приводит распределения лота к виду для сравнения, упорядочивая их по покупателю
+func allocationOutcomes(allocations []models.LotAllocation) []models.AllocationOutcome {
+	outcomes := make([]models.AllocationOutcome, 0, len(allocations))
+	for _, allocation := range allocations {
+		outcomes = append(outcomes, models.AllocationOutcome{UserID: allocation.UserID, Quantity: allocation.Quantity, Price: allocation.Price})
+	}
+	sort.Slice(outcomes, func(i, j int) bool { return outcomes[i].UserID < outcomes[j].UserID })
+	return outcomes
+}
+
+// sameAllocations сравнивает распределения единиц, упорядоченные по покупателю
+func sameAllocations(a, b []models.AllocationOutcome) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].UserID != b[i].UserID || a[i].Quantity != b[i].Quantity || !samePrice(&a[i].Price, &b[i].Price) {
+			return false
+		}
+	}
+	return true
+}
+
+func describeAllocations(allocations []models.AllocationOutcome) string {
+	if len(allocations) == 0 {
+		return "нет"
+	}
+	parts := make([]string, 0, len(allocations))
+	for _, allocation := range allocations {
+		parts = append(parts, fmt.Sprintf("пользователь %d: %d ед. по %.2f", allocation.UserID, allocation.Quantity, allocation.Price))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// compareLotOutcomes перечисляет расхождения сохраненного и проигранного состояния лота
+func compareLotOutcomes(stored, replayed models.LotOutcome, compareStatus bool) []string {
+	var differences []string
+	if compareStatus && stored.Status != replayed.Status {
+		differences = append(differences, fmt.Sprintf("статус: сохранен %s, по ставкам %s", stored.Status, replayed.Status))
+	}
+	if !samePrice(&stored.CurrentPrice, &replayed.CurrentPrice) {
+		differences = append(differences, fmt.Sprintf("текущая цена: сохранена %.2f, по ставкам %.2f", stored.CurrentPrice, replayed.CurrentPrice))
+	}
+	if !sameUserID(stored.HighestBidderID, replayed.HighestBidderID) {
+		differences = append(differences, fmt.Sprintf("лидер торгов: сохранен %s, по ставкам %s",
+			describeUserID(stored.HighestBidderID), describeUserID(replayed.HighestBidderID)))
+	}
+	if !samePrice(stored.FinalPrice, replayed.FinalPrice) {
+		differences = append(differences, fmt.Sprintf("итоговая цена: сохранена %s, по ставкам %s",
+			describePrice(stored.FinalPrice), describePrice(replayed.FinalPrice)))
+	}
+	if !sameAllocations(stored.Allocations, replayed.Allocations) {
+		differences = append(differences, fmt.Sprintf("покупатели: сохранено %s, по ставкам %s",
+			describeAllocations(stored.Allocations), describeAllocations(replayed.Allocations)))
+	}
+	return differences
+}
+
+// samePrice сравнивает цены с точностью до копейки
+func samePrice(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return math.Abs(*a-*b) < 0.005
+}
+
+func describeUserID(id *uint) string {
+	if id == nil {
+		return "нет"
+	}
+	return fmt.Sprintf("пользователь %d", *id)
+}
+
+func describePrice(price *float64) string {
+	if price == nil {
+		return "нет"
+	}
+	return fmt.Sprintf("%.2f", *price)
+}
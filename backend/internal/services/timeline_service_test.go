@@ -0,0 +1,164 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeTimelineAuctionStore отдает один заранее заданный аукцион
+type fakeTimelineAuctionStore struct {
+	store.AuctionStore
+	auction *models.Auction
+}
+
+func (f *fakeTimelineAuctionStore) GetAuctionByID(id uint) (*models.Auction, error) {
+	if f.auction == nil || f.auction.ID != id {
+		return nil, nil
+	}
+	copied := *f.auction
+	return &copied, nil
+}
+
+// fakeTimelineLotStore отдает лоты аукциона по ID
+type fakeTimelineLotStore struct {
+	store.LotStore
+	lots map[uint]models.Lot
+}
+
+func (f *fakeTimelineLotStore) GetLotByID(id uint) (*models.Lot, error) {
+	lot, ok := f.lots[id]
+	if !ok {
+		return nil, nil
+	}
+	return &lot, nil
+}
+
+// fakeTimelineBidStore хранит отклоненные попытки в памяти, а сохранение ставки завершает ошибкой placeErr,
+// как это делает хранилище, обнаружив в транзакции, что лот изменился
+type fakeTimelineBidStore struct {
+	store.BidStore
+	placeErr error
+	attempts []models.BidAttempt
+}
+
+func (f *fakeTimelineBidStore) GetAllBidsByLotID(lotID uint) ([]models.Bid, error) {
+	return nil, nil
+}
+
+func (f *fakeTimelineBidStore) GetBidHistoryByLotIDs(lotIDs []uint) ([]models.Bid, error) {
+	return nil, nil
+}
+
+func (f *fakeTimelineBidStore) PlaceBid(bid *models.Bid, lot *models.Lot, buildEvents func() ([]models.OutboxEvent, error)) error {
+	return f.placeErr
+}
+
+func (f *fakeTimelineBidStore) CountBidAttemptsByUserSince(userID uint, since time.Time) (int64, error) {
+	var count int64
+	for _, attempt := range f.attempts {
+		if attempt.UserID == userID && !attempt.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeTimelineBidStore) CreateBidAttempt(attempt *models.BidAttempt) error {
+	attempt.ID = uint(len(f.attempts) + 1)
+	attempt.CreatedAt = time.Now()
+	f.attempts = append(f.attempts, *attempt)
+	return nil
+}
+
+func (f *fakeTimelineBidStore) GetBidAttemptsByAuctionID(auctionID uint) ([]models.BidAttempt, error) {
+	var attempts []models.BidAttempt
+	for _, attempt := range f.attempts {
+		if attempt.AuctionID == auctionID {
+			attempts = append(attempts, attempt)
+		}
+	}
+	return attempts, nil
+}
+
+type fakeTimelineBlocklistStore struct{ store.BlocklistStore }
+
+func (fakeTimelineBlocklistStore) IsBidderBlocked(ownerIDs []uint, bidderID uint) (bool, error) {
+	return false, nil
+}
+
+type fakeTimelineCreditLimitStore struct{ store.CreditLimitStore }
+
+func (fakeTimelineCreditLimitStore) GetCreditLimit(userID, auctionID uint) (*models.CreditLimit, error) {
+	return nil, nil
+}
+
+type fakeTimelineOutboxStore struct{ store.OutboxStore }
+
+func (fakeTimelineOutboxStore) GetOutboxEventsForAuction(auctionID uint, lotIDs []uint, eventTypes []models.DomainEventType) ([]models.OutboxEvent, error) {
+	return nil, nil
+}
+
+func TestTimelineRecordsBidsRejectedByConcurrentLotChange(t *testing.T) {
+	const organizerID, sellerID, bidderID = uint(1), uint(2), uint(3)
+
+	tests := []struct {
+		name     string
+		placeErr error
+	}{
+		{
+			name:     "лот перебит другой ставкой",
+			placeErr: errors.New("состояние лота изменилось, пока обрабатывалась ставка: обновите данные лота и повторите ставку"),
+		},
+		{
+			name:     "лот снят с торгов",
+			placeErr: errors.New("ставки на данный лот не принимаются (статус лота)"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lot := models.Lot{
+				ID: 10, AuctionID: 1, LotNumber: 4, SellerID: sellerID, Quantity: 1,
+				StartPrice: 100, CurrentPrice: 100, Status: models.StatusLotActive, ModerationStatus: models.ModerationApproved,
+			}
+			auction := &models.Auction{ID: 1, CreatedByUserID: organizerID, Status: models.StatusActive, Format: models.FormatEnglish, Lots: []models.Lot{lot}}
+			auctionStore := &fakeTimelineAuctionStore{auction: auction}
+			bidStore := &fakeTimelineBidStore{placeErr: tt.placeErr}
+			lotService := NewLotService(&fakeTimelineLotStore{lots: map[uint]models.Lot{lot.ID: lot}}, auctionStore, bidStore,
+				fakeTimelineBlocklistStore{}, fakeTimelineCreditLimitStore{}, nil, nil, nil, nil, nil)
+
+			_, err := lotService.PlaceBid(auction.ID, lot.ID, models.PlaceBidInput{Amount: 150}, bidderID)
+			if err == nil || err.Error() != tt.placeErr.Error() {
+				t.Fatalf("PlaceBid вернул %v, ожидалась ошибка %q", err, tt.placeErr)
+			}
+
+			timeline, err := NewTimelineService(auctionStore, bidStore, fakeTimelineOutboxStore{}).
+				GetAuctionTimeline(auction.ID, false, organizerID, models.RoleSeller)
+			if err != nil {
+				t.Fatalf("GetAuctionTimeline: %v", err)
+			}
+			var rejected []models.TimelineEvent
+			for _, event := range timeline.Events {
+				if event.Type == models.TimelineBidRejected {
+					rejected = append(rejected, event)
+				}
+			}
+			if len(rejected) != 1 {
+				t.Fatalf("в хронологии %d отклоненных ставок, ожидалась 1: %+v", len(rejected), timeline.Events)
+			}
+			event := rejected[0]
+			if event.LotID == nil || *event.LotID != lot.ID || event.LotNumber != lot.LotNumber {
+				t.Errorf("лот события = %v (№%d), ожидался %d (№%d)", event.LotID, event.LotNumber, lot.ID, lot.LotNumber)
+			}
+			if event.UserID == nil || *event.UserID != bidderID {
+				t.Errorf("участник события = %v, ожидался %d", event.UserID, bidderID)
+			}
+			if event.Data["amount"] != 150.0 || event.Data["reason"] != tt.placeErr.Error() {
+				t.Errorf("данные события = %+v, ожидались сумма 150 и причина %q", event.Data, tt.placeErr)
+			}
+		})
+	}
+}
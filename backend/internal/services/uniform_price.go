@@ -0,0 +1,76 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"sort"
+)
+
+// latestBidsByBidder оставляет только последнюю ставку каждого участника:
+// новая ставка участника на многоединичный лот заменяет его предыдущую.
+func latestBidsByBidder(bids []models.Bid) []models.Bid {
+	latest := make(map[uint]models.Bid)
+	for _, bid := range bids {
+		prev, ok := latest[bid.UserID]
+		if !ok || bid.BidTime.After(prev.BidTime) || (bid.BidTime.Equal(prev.BidTime) && bid.ID > prev.ID) {
+			latest[bid.UserID] = bid
+		}
+	}
+	result := make([]models.Bid, 0, len(latest))
+	for _, bid := range latest {
+		result = append(result, bid)
+	}
+	return result
+}
+
+// allocateUniformPrice распределяет единицы лота между самыми высокими ставками
+// (при равных суммах приоритет у более ранней ставки). Последний победитель может
+// получить меньше единиц, чем запросил. Все победители платят цену самой низкой
+// выигравшей ставки, которая возвращается вторым значением. Участники из excluded
+// в распределении не участвуют.
+func allocateUniformPrice(lotID uint, quantity int, bids []models.Bid, excluded map[uint]bool) ([]models.LotAllocation, float64) {
+	ranked := latestBidsByBidder(bids)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].BidAmount != ranked[j].BidAmount {
+			return ranked[i].BidAmount > ranked[j].BidAmount
+		}
+		if !ranked[i].BidTime.Equal(ranked[j].BidTime) {
+			return ranked[i].BidTime.Before(ranked[j].BidTime)
+		}
+		return ranked[i].ID < ranked[j].ID
+	})
+
+	var allocations []models.LotAllocation
+	remaining := quantity
+	clearingPrice := 0.0
+	for _, bid := range ranked {
+		if remaining <= 0 {
+			break
+		}
+		if excluded[bid.UserID] {
+			continue
+		}
+		units := bid.Quantity
+		if units < 1 {
+			units = 1
+		}
+		if units > remaining {
+			units = remaining
+		}
+		allocations = append(allocations, models.LotAllocation{LotID: lotID, UserID: bid.UserID, Quantity: units})
+		remaining -= units
+		clearingPrice = bid.BidAmount
+	}
+	for i := range allocations {
+		allocations[i].Price = clearingPrice
+	}
+	return allocations, clearingPrice
+}
+
+// allocatedUnits возвращает суммарное количество распределенных единиц
+func allocatedUnits(allocations []models.LotAllocation) int {
+	total := 0
+	for _, a := range allocations {
+		total += a.Quantity
+	}
+	return total
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAllocateUniformPrice(t *testing.T) {
+	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	bid := func(id, userID uint, amount float64, quantity int, offset time.Duration) models.Bid {
+		return models.Bid{ID: id, LotID: 1, UserID: userID, BidAmount: amount, Quantity: quantity, BidTime: base.Add(offset)}
+	}
+
+	tests := []struct {
+		name         string
+		quantity     int
+		bids         []models.Bid
+		excluded     map[uint]bool
+		want         []models.LotAllocation
+		wantClearing float64
+	}{
+		{
+			name:         "нет ставок",
+			quantity:     5,
+			want:         nil,
+			wantClearing: 0,
+		},
+		{
+			name:     "все запросы удовлетворены, цена по самой низкой ставке",
+			quantity: 10,
+			bids: []models.Bid{
+				bid(1, 1, 120, 3, 0),
+				bid(2, 2, 100, 4, time.Second),
+			},
+			want: []models.LotAllocation{
+				{LotID: 1, UserID: 1, Quantity: 3, Price: 100},
+				{LotID: 1, UserID: 2, Quantity: 4, Price: 100},
+			},
+			wantClearing: 100,
+		},
+		{
+			name:     "последний победитель получает остаток",
+			quantity: 5,
+			bids: []models.Bid{
+				bid(1, 1, 150, 3, 0),
+				bid(2, 2, 130, 4, time.Second),
+				bid(3, 3, 110, 2, 2*time.Second),
+			},
+			want: []models.LotAllocation{
+				{LotID: 1, UserID: 1, Quantity: 3, Price: 130},
+				{LotID: 1, UserID: 2, Quantity: 2, Price: 130},
+			},
+			wantClearing: 130,
+		},
+		{
+			name:     "при равных ставках приоритет у более ранней",
+			quantity: 2,
+			bids: []models.Bid{
+				bid(2, 2, 100, 2, time.Second),
+				bid(1, 1, 100, 2, 0),
+			},
+			want: []models.LotAllocation{
+				{LotID: 1, UserID: 1, Quantity: 2, Price: 100},
+			},
+			wantClearing: 100,
+		},
+		{
+			name:     "при равных сумме и времени приоритет у меньшего ID ставки",
+			quantity: 1,
+			bids: []models.Bid{
+				bid(5, 2, 100, 1, 0),
+				bid(4, 1, 100, 1, 0),
+			},
+			want: []models.LotAllocation{
+				{LotID: 1, UserID: 1, Quantity: 1, Price: 100},
+			},
+			wantClearing: 100,
+		},
+		{
+			name:     "учитывается только последняя ставка участника",
+			quantity: 3,
+			bids: []models.Bid{
+				bid(1, 1, 200, 3, 0),
+				bid(2, 2, 150, 2, time.Second),
+				bid(3, 1, 120, 1, 2*time.Second),
+			},
+			want: []models.LotAllocation{
+				{LotID: 1, UserID: 2, Quantity: 2, Price: 120},
+				{LotID: 1, UserID: 1, Quantity: 1, Price: 120},
+			},
+			wantClearing: 120,
+		},
+		{
+			name:     "исключенный участник пропускается, единицы переходят следующим",
+			quantity: 3,
+			bids: []models.Bid{
+				bid(1, 1, 300, 2, 0),
+				bid(2, 2, 200, 2, time.Second),
+				bid(3, 3, 100, 2, 2*time.Second),
+			},
+			excluded: map[uint]bool{1: true},
+			want: []models.LotAllocation{
+				{LotID: 1, UserID: 2, Quantity: 2, Price: 100},
+				{LotID: 1, UserID: 3, Quantity: 1, Price: 100},
+			},
+			wantClearing: 100,
+		},
+		{
+			name:     "все участники исключены",
+			quantity: 2,
+			bids: []models.Bid{
+				bid(1, 1, 300, 1, 0),
+			},
+			excluded:     map[uint]bool{1: true},
+			want:         nil,
+			wantClearing: 0,
+		},
+		{
+			name:     "ставка без количества считается ставкой на одну единицу",
+			quantity: 2,
+			bids: []models.Bid{
+				bid(1, 1, 50, 0, 0),
+			},
+			want: []models.LotAllocation{
+				{LotID: 1, UserID: 1, Quantity: 1, Price: 50},
+			},
+			wantClearing: 50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, clearing := allocateUniformPrice(1, tt.quantity, tt.bids, tt.excluded)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("распределение = %+v, ожидалось %+v", got, tt.want)
+			}
+			if clearing != tt.wantClearing {
+				t.Errorf("цена = %.2f, ожидалось %.2f", clearing, tt.wantClearing)
+			}
+		})
+	}
+}
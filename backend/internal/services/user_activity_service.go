@@ -4,23 +4,34 @@ import (
 	"auction-app/backend/internal/models"
 	"auction-app/backend/internal/store"
 	"fmt"
+	"strconv"
+	"time"
 )
 
 type UserActivityService struct {
-	lotStore     store.LotStore
-	auctionStore store.AuctionStore
+	lotStore       store.LotStore
+	auctionStore   store.AuctionStore
+	watchlistStore store.WatchlistStore
 }
 
-func NewUserActivityService(ls store.LotStore, as store.AuctionStore) *UserActivityService {
-	return &UserActivityService{lotStore: ls, auctionStore: as}
+func NewUserActivityService(ls store.LotStore, as store.AuctionStore, ws store.WatchlistStore) *UserActivityService {
+	return &UserActivityService{lotStore: ls, auctionStore: as, watchlistStore: ws}
 }
 
 type UserActivityOutput struct {
 	LeadingBids []LotWithAuctionInfo `json:"leadingBids"`
 	WonLots     []LotWithAuctionInfo `json:"wonLots"`
+	WatchedLots []WatchedLotInfo     `json:"watchedLots"`
 	Pagination  map[string]int64     `json:"pagination,omitempty"`
 }
 
+// WatchedLotInfo - лот из списка наблюдения с текущей ценой (currentPrice лота) и временем до начала торгов
+type WatchedLotInfo struct {
+	LotWithAuctionInfo
+	AuctionStartsAt      *time.Time `json:"auctionStartsAt,omitempty"`
+	TimeRemainingSeconds *int64     `json:"timeRemainingSeconds,omitempty"` // до начала торгов; не заполняется, если торги уже начались
+}
+
 type LotWithAuctionInfo struct {
 	models.Lot
 	AuctionID     uint   `json:"auctionId"`
@@ -47,32 +58,103 @@ func (s *UserActivityService) GetMyActivity(userID uint, page, pageSize int) (*U
 		return nil, fmt.Errorf("ошибка получения выигранных лотов: %w", errWon)
 	}
 
+	watchedLotsModels, _, errWatched := s.watchlistStore.GetWatchedLotsByUserID(userID, offset, pageSize)
+	if errWatched != nil {
+		return nil, fmt.Errorf("ошибка получения лотов из списка наблюдения: %w", errWatched)
+	}
+
+	auctions, err := s.auctionsForLots(leadingLotsModels, wonLotsModels, watchedLotsModels)
+	if err != nil {
+		return nil, err
+	}
+
 	output := &UserActivityOutput{
 		LeadingBids: []LotWithAuctionInfo{},
 		WonLots:     []LotWithAuctionInfo{},
+		WatchedLots: []WatchedLotInfo{},
 	}
 
 	for _, lot := range leadingLotsModels {
-		auction, _ := s.auctionStore.GetAuctionByID(lot.AuctionID)
-		output.LeadingBids = append(output.LeadingBids, LotWithAuctionInfo{
-			Lot: lot, AuctionID: lot.AuctionID,
-			AuctionName: auction.NameSpecificity, AuctionStatus: string(auction.Status),
-		})
+		if auction, ok := auctions[lot.AuctionID]; ok {
+			output.LeadingBids = append(output.LeadingBids, lotWithAuctionInfo(lot, auction))
+		}
 	}
 	_ = totalLeading
 
 	for _, lot := range wonLotsModels {
-		auction, _ := s.auctionStore.GetAuctionByID(lot.AuctionID)
-		output.WonLots = append(output.WonLots, LotWithAuctionInfo{
-			Lot: lot, AuctionID: lot.AuctionID,
-			AuctionName: auction.NameSpecificity, AuctionStatus: string(auction.Status),
-		})
+		if auction, ok := auctions[lot.AuctionID]; ok {
+			output.WonLots = append(output.WonLots, lotWithAuctionInfo(lot, auction))
+		}
 	}
 	_ = totalWon
 
+	now := time.Now()
+	for _, lot := range watchedLotsModels {
+		auction, ok := auctions[lot.AuctionID]
+		if !ok {
+			continue
+		}
+		info := WatchedLotInfo{LotWithAuctionInfo: lotWithAuctionInfo(lot, auction)}
+		if startsAt, ok := auctionStartTime(auction); ok {
+			info.AuctionStartsAt = &startsAt
+			if auction.Status == models.StatusScheduled {
+				remaining := int64(startsAt.Sub(now).Seconds())
+				if remaining < 0 {
+					remaining = 0
+				}
+				info.TimeRemainingSeconds = &remaining
+			}
+		}
+		output.WatchedLots = append(output.WatchedLots, info)
+	}
+
 	return output, nil
 }
 
+// auctionsForLots загружает одним запросом аукционы, к которым относятся лоты, и возвращает их по ID
+func (s *UserActivityService) auctionsForLots(lotLists ...[]models.Lot) (map[uint]*models.Auction, error) {
+	seen := make(map[uint]bool)
+	var ids []uint
+	for _, lots := range lotLists {
+		for _, lot := range lots {
+			if !seen[lot.AuctionID] {
+				seen[lot.AuctionID] = true
+				ids = append(ids, lot.AuctionID)
+			}
+		}
+	}
+	auctionModels, err := s.auctionStore.GetAuctionsByIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения аукционов лотов: %w", err)
+	}
+	auctions := make(map[uint]*models.Auction, len(auctionModels))
+	for i := range auctionModels {
+		auctions[auctionModels[i].ID] = &auctionModels[i]
+	}
+	return auctions, nil
+}
+
+func lotWithAuctionInfo(lot models.Lot, auction *models.Auction) LotWithAuctionInfo {
+	return LotWithAuctionInfo{
+		Lot: lot, AuctionID: lot.AuctionID,
+		AuctionName: auction.NameSpecificity, AuctionStatus: string(auction.Status),
+	}
+}
+
+// auctionStartTime собирает время начала торгов из даты и времени аукциона (ЧЧ:ММ, местное время сервера)
+func auctionStartTime(auction *models.Auction) (time.Time, bool) {
+	if len(auction.AuctionTime) != 5 || auction.AuctionTime[2] != ':' {
+		return time.Time{}, false
+	}
+	hours, errHours := strconv.Atoi(auction.AuctionTime[:2])
+	minutes, errMinutes := strconv.Atoi(auction.AuctionTime[3:])
+	if errHours != nil || errMinutes != nil {
+		return time.Time{}, false
+	}
+	year, month, day := auction.AuctionDate.Date()
+	return time.Date(year, month, day, hours, minutes, 0, 0, time.Local), true
+}
+
 func (s *UserActivityService) GetMyListings(sellerID uint, page, pageSize int) ([]LotWithAuctionInfo, int64, error) {
 	if page < 1 {
 		page = 1
@@ -87,13 +169,15 @@ func (s *UserActivityService) GetMyListings(sellerID uint, page, pageSize int) (
 		return nil, 0, fmt.Errorf("ошибка получения лотов продавца: %w", err)
 	}
 
+	auctions, err := s.auctionsForLots(lotsModels)
+	if err != nil {
+		return nil, 0, err
+	}
 	var resultListings []LotWithAuctionInfo
 	for _, lot := range lotsModels {
-		auction, _ := s.auctionStore.GetAuctionByID(lot.AuctionID)
-		resultListings = append(resultListings, LotWithAuctionInfo{
-			Lot: lot, AuctionID: lot.AuctionID,
-			AuctionName: auction.NameSpecificity, AuctionStatus: string(auction.Status),
-		})
+		if auction, ok := auctions[lot.AuctionID]; ok {
+			resultListings = append(resultListings, lotWithAuctionInfo(lot, auction))
+		}
 	}
 
 	return resultListings, total, nil
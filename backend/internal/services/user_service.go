@@ -7,14 +7,22 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"strings"
+	"time"
 )
 
+// sessionRevokedByAdmin - причина отзыва сессий пользователя администратором
+const sessionRevokedByAdmin = "завершены администратором"
+
 type UserService struct {
-	userStore store.UserStore
+	userStore    store.UserStore
+	sessionStore store.SessionStore
+	notifier     Notifier
 }
 
-func NewUserService(us store.UserStore) *UserService {
-	return &UserService{userStore: us}
+func NewUserService(us store.UserStore, ss store.SessionStore, n Notifier) *UserService {
+	return &UserService{userStore: us, sessionStore: ss, notifier: n}
 }
 
 func (s *UserService) GetAllUsers(page, pageSize int, roleFilter string) ([]models.User, int64, error) {
@@ -38,7 +46,7 @@ func (s *UserService) GetAllUsers(page, pageSize int, roleFilter string) ([]mode
 	return users, total, nil
 }
 
-func (s *UserService) UpdateUserStatus(userID uint, newStatus bool, adminUserID uint, adminRole models.UserRole) (*models.User, error) {
+func (s *UserService) UpdateUserStatus(userID uint, newStatus bool, adminUserID uint, adminRole models.UserRole, clientIP string) (*models.User, error) {
 	if adminRole != models.RoleSystemAdmin {
 		return nil, errors.New("недостаточно прав для изменения статуса пользователя")
 	}
@@ -57,15 +65,37 @@ func (s *UserService) UpdateUserStatus(userID uint, newStatus bool, adminUserID
 		return nil, errors.New("нельзя изменить статус другого системного администратора")
 	}
 
+	previousStatus := user.IsActive
 	user.IsActive = newStatus
-	if err := s.userStore.UpdateUser(user); err != nil {
+	err = s.userStore.UpdateUserWithAudit(user, &models.AuditLogEntry{
+		ActorID:    adminUserID,
+		ActorRole:  adminRole,
+		Action:     models.AuditUserStatusChanged,
+		TargetType: "user",
+		TargetID:   user.ID,
+		Changes:    auditDiff(map[string]interface{}{"isActive": previousStatus}, map[string]interface{}{"isActive": newStatus}),
+		IPAddress:  clientIP,
+	})
+	if err != nil {
 		return nil, fmt.Errorf("ошибка обновления статуса пользователя: %w", err)
 	}
+	if !newStatus {
+		// запросы заблокированного пользователя отклоняются и без этого, но после разблокировки
+		// старые сессии не должны снова заработать
+		if _, err := s.sessionStore.RevokeUserSessions(user.ID, sessionRevokedInactive, time.Now(), nil); err != nil {
+			log.Printf("[UserService] Не удалось завершить сессии заблокированного пользователя %d: %v", user.ID, err)
+		}
+	}
+	if newStatus {
+		s.notify(user.ID, "Учетная запись разблокирована", "Администратор восстановил доступ к вашей учетной записи.")
+	} else {
+		s.notify(user.ID, "Учетная запись заблокирована", "Администратор заблокировал вашу учетную запись. Для уточнения причин обратитесь в поддержку.")
+	}
 	user.PasswordHash = ""
 	return user, nil
 }
 
-func (s *UserService) UpdateUserAvailableRoles(userID uint, roles []string, adminUserID uint, adminRole models.UserRole) (*models.User, error) {
+func (s *UserService) UpdateUserAvailableRoles(userID uint, roles []string, adminUserID uint, adminRole models.UserRole, clientIP string) (*models.User, error) {
 	if adminRole != models.RoleSystemAdmin {
 		return nil, errors.New("недостаточно прав для изменения ролей пользователя")
 	}
@@ -99,11 +129,83 @@ func (s *UserService) UpdateUserAvailableRoles(userID uint, roles []string, admi
 	if err != nil {
 		return nil, fmt.Errorf("ошибка сериализации ролей в JSON: %w", err)
 	}
+	previousRoles := businessRolesForAudit(user.AvailableBusinessRoles)
 	user.AvailableBusinessRoles = string(rolesJson)
 
-	if err := s.userStore.UpdateUser(user); err != nil {
+	err = s.userStore.UpdateUserWithAudit(user, &models.AuditLogEntry{
+		ActorID:    adminUserID,
+		ActorRole:  adminRole,
+		Action:     models.AuditUserRolesChanged,
+		TargetType: "user",
+		TargetID:   user.ID,
+		Changes: auditDiff(
+			map[string]interface{}{"availableBusinessRoles": previousRoles},
+			map[string]interface{}{"availableBusinessRoles": businessRolesForAudit(user.AvailableBusinessRoles)},
+		),
+		IPAddress: clientIP,
+	})
+	if err != nil {
 		return nil, fmt.Errorf("ошибка обновления ролей пользователя: %w", err)
 	}
+	rolesDescription := "нет доступных ролей"
+	if len(roles) > 0 {
+		rolesDescription = strings.Join(roles, ", ")
+	}
+	s.notify(user.ID, "Изменены доступные роли", "Администратор изменил ваши доступные бизнес-роли: "+rolesDescription+".")
 	user.PasswordHash = ""
 	return user, nil
 }
+
+// RevokeUserSessions завершает все сессии пользователя (только для системного администратора):
+// пользователю придется войти заново на всех устройствах
+func (s *UserService) RevokeUserSessions(userID uint, adminUserID uint, adminRole models.UserRole, clientIP string) (int64, error) {
+	if adminRole != models.RoleSystemAdmin {
+		return 0, errors.New("недостаточно прав для завершения сессий пользователя")
+	}
+
+	user, err := s.userStore.GetUserByID(userID)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+	if user == nil {
+		return 0, errors.New("пользователь не найден")
+	}
+
+	revoked, err := s.sessionStore.RevokeUserSessions(user.ID, sessionRevokedByAdmin, time.Now(), func(revoked int64) *models.AuditLogEntry {
+		return &models.AuditLogEntry{
+			ActorID:    adminUserID,
+			ActorRole:  adminRole,
+			Action:     models.AuditUserSessionsRevoked,
+			TargetType: "user",
+			TargetID:   user.ID,
+			Changes:    auditDiff(map[string]interface{}{"activeSessions": revoked}, map[string]interface{}{"activeSessions": int64(0)}),
+			IPAddress:  clientIP,
+		}
+	})
+	if err != nil {
+		return 0, fmt.Errorf("ошибка завершения сессий пользователя: %w", err)
+	}
+	return revoked, nil
+}
+
+// businessRolesForAudit разбирает сохраненный JSON-список бизнес-ролей, чтобы в журнале аудита
+// роли сравнивались как список, а не как строка; некорректное значение возвращается как есть
+func businessRolesForAudit(stored string) interface{} {
+	if stored == "" {
+		return []interface{}{}
+	}
+	var roles []interface{}
+	if err := json.Unmarshal([]byte(stored), &roles); err != nil {
+		return stored
+	}
+	if roles == nil {
+		return []interface{}{}
+	}
+	return roles
+}
+
+func (s *UserService) notify(userID uint, subject, message string) {
+	if err := s.notifier.Notify(userID, subject, message); err != nil {
+		log.Printf("[UserService] Не удалось уведомить пользователя %d: %v", userID, err)
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"errors"
+	"fmt"
+)
+
+// WatchlistService управляет списками наблюдения: пользователь может следить за лотами и аукционами, не делая ставок
+type WatchlistService struct {
+	watchlistStore store.WatchlistStore
+	lotStore       store.LotStore
+	auctionStore   store.AuctionStore
+}
+
+// NewWatchlistService создает новый экземпляр WatchlistService
+func NewWatchlistService(ws store.WatchlistStore, ls store.LotStore, as store.AuctionStore) *WatchlistService {
+	return &WatchlistService{watchlistStore: ws, lotStore: ls, auctionStore: as}
+}
+
+// AddToWatchlist добавляет лот или аукцион в список наблюдения пользователя
+func (s *WatchlistService) AddToWatchlist(userID uint, input models.AddWatchlistInput) (*models.WatchlistEntry, error) {
+	if (input.LotID == nil) == (input.AuctionID == nil) {
+		return nil, errors.New("укажите либо лот (lotId), либо аукцион (auctionId)")
+	}
+
+	entry := models.WatchlistEntry{UserID: userID, LotID: input.LotID, AuctionID: input.AuctionID}
+	if input.LotID != nil {
+		lot, err := s.lotStore.GetLotByID(*input.LotID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения лота: %w", err)
+		}
+		// лот, не прошедший модерацию, покупателям не виден
+		if lot == nil || lot.ModerationStatus != models.ModerationApproved {
+			return nil, errors.New("лот не найден")
+		}
+		entry.Lot = lot
+	} else {
+		auction, err := s.auctionStore.GetAuctionByID(*input.AuctionID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения аукциона: %w", err)
+		}
+		if auction == nil {
+			return nil, errors.New("аукцион не найден")
+		}
+		auction.Lots = nil
+		applyAuctionRoles(auction)
+		entry.Auction = auction
+	}
+
+	existing, err := s.watchlistStore.GetWatchlistEntry(userID, input.LotID, input.AuctionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка проверки списка наблюдения: %w", err)
+	}
+	if existing != nil {
+		return nil, errors.New("уже в списке наблюдения")
+	}
+
+	if err := s.watchlistStore.AddWatchlistEntry(&entry); err != nil {
+		return nil, fmt.Errorf("ошибка добавления в список наблюдения: %w", err)
+	}
+	return &entry, nil
+}
+
+// RemoveWatchedLot убирает лот из списка наблюдения пользователя
+func (s *WatchlistService) RemoveWatchedLot(userID, lotID uint) error {
+	return s.watchlistStore.RemoveWatchedLot(userID, lotID)
+}
+
+// RemoveWatchedAuction убирает аукцион из списка наблюдения пользователя
+func (s *WatchlistService) RemoveWatchedAuction(userID, auctionID uint) error {
+	return s.watchlistStore.RemoveWatchedAuction(userID, auctionID)
+}
+
+// GetMyWatchlist возвращает список наблюдения пользователя; entryType "lots" или "auctions" ограничивает выборку
+func (s *WatchlistService) GetMyWatchlist(userID uint, page, pageSize int, entryType string) ([]models.WatchlistEntry, int64, error) {
+	if entryType != "" && entryType != "lots" && entryType != "auctions" {
+		return nil, 0, errors.New("некорректный тип записей: допустимы lots и auctions")
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	} else if pageSize > 100 {
+		pageSize = 100
+	}
+	offset := (page - 1) * pageSize
+
+	entries, total, err := s.watchlistStore.GetWatchlistByUserID(userID, offset, pageSize, entryType)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения списка наблюдения: %w", err)
+	}
+	for i := range entries {
+		applyAuctionRoles(entries[i].Auction)
+	}
+	return entries, total, nil
+}
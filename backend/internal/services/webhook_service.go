@@ -0,0 +1,499 @@
+package services
+
+import (
+	"auction-app/backend/internal/models"
+	"auction-app/backend/internal/store"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// maxWebhooksPerUser - сколько подписок на вебхуки может создать один пользователь
+	maxWebhooksPerUser = 10
+	// webhookMaxAttempts - после стольких неудачных попыток доставка считается проваленной
+	webhookMaxAttempts = 8
+	// webhookRetryBaseDelay - пауза перед первой повторной попыткой, далее она удваивается
+	webhookRetryBaseDelay = 30 * time.Second
+	// webhookRequestTimeout - сколько ждать ответа получателя
+	webhookRequestTimeout = 10 * time.Second
+	// webhookDeliveryLease - на это время доставка закрепляется за экземпляром, который ее отправляет
+	webhookDeliveryLease = time.Minute
+	// webhookDeliveryBatchSize - сколько доставок фоновая задача берет за раз
+	webhookDeliveryBatchSize = 50
+	// webhookDeliveryWorkers - сколько запросов к получателям отправляется одновременно
+	webhookDeliveryWorkers = 4
+)
+
+// WebhookDispatcher ставит события торгов в очередь на отправку вебхуками. eventID - ключ идемпотентности
+// события: повторный вызов с тем же ключом не создает новых доставок. ownerIDs - пользователи,
+// которых касается событие (продавец лота, организатор аукциона); подписки администраторов получают все события.
+type WebhookDispatcher interface {
+	Dispatch(eventID string, eventType models.WebhookEventType, ownerIDs []uint, data map[string]interface{}) error
+}
+
+// webhookPayload - тело запроса к получателю вебхука
+type webhookPayload struct {
+	ID         string                  `json:"id"`
+	Event      models.WebhookEventType `json:"event"`
+	OccurredAt time.Time               `json:"occurredAt"`
+	Data       map[string]interface{}  `json:"data"`
+}
+
+// WebhookService управляет подписками на вебхуки и доставляет события внешним системам
+type WebhookService struct {
+	webhookStore store.WebhookStore
+	client       *http.Client
+	wakeup       chan struct{}
+}
+
+// NewWebhookService создает новый экземпляр WebhookService
+func NewWebhookService(ws store.WebhookStore) *WebhookService {
+	return &WebhookService{
+		webhookStore: ws,
+		client: &http.Client{
+			Timeout:   webhookRequestTimeout,
+			Transport: newWebhookTransport(),
+			// перенаправления не выполняются: ответ 3xx считается неудачной попыткой
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		wakeup: make(chan struct{}, 1),
+	}
+}
+
+// signWebhookPayload вычисляет подпись запроса: HMAC-SHA256 от строки "<timestamp>.<тело запроса>"
+func signWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func randomHex(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// errWebhookInternalAddress - адрес получателя вебхука находится во внутренней сети сервера
+var errWebhookInternalAddress = errors.New("некорректный адрес вебхука: он указывает на внутреннюю сеть, разрешены только публичные адреса")
+
+// sharedAddressSpace - диапазон 100.64.0.0/10 (RFC 6598), используемый провайдерами и облаками внутри своих сетей
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// isInternalIP сообщает, относится ли адрес к сети, в которую вебхуки отправлять нельзя: loopback,
+// частные диапазоны, link-local (в том числе сервис метаданных облака 169.254.169.254), multicast и 0.0.0.0
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip) || (ip.To4() != nil && ip.To4()[0] == 0)
+}
+
+// webhookDialControl проверяет адрес непосредственно перед подключением. Проверка при сохранении подписки
+// недостаточна: DNS-имя может позже начать указывать на внутренний адрес (DNS rebinding).
+func webhookDialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || isInternalIP(ip) {
+		return errWebhookInternalAddress
+	}
+	return nil
+}
+
+// newWebhookTransport создает транспорт для доставки вебхуков, который подключается только к публичным адресам.
+// Прокси из окружения не используется: иначе проверялся бы адрес прокси, а не получателя.
+func newWebhookTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   webhookRequestTimeout,
+		KeepAlive: 30 * time.Second,
+		Control:   webhookDialControl,
+	}).DialContext
+	return transport
+}
+
+// validateWebhookURL проверяет адрес получателя вебхука: протокол http или https и хост,
+// который разрешается только в публичные адреса
+func validateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return errors.New("некорректный адрес вебхука: ожидается URL с протоколом http или https")
+	}
+	ips, err := net.LookupIP(parsed.Hostname())
+	if err != nil || len(ips) == 0 {
+		return fmt.Errorf("некорректный адрес вебхука: не удалось разрешить хост %s", parsed.Hostname())
+	}
+	for _, ip := range ips {
+		if isInternalIP(ip) {
+			return errWebhookInternalAddress
+		}
+	}
+	return nil
+}
+
+func validateWebhookEvents(events []models.WebhookEventType) (models.WebhookEventList, error) {
+	supported := make(map[models.WebhookEventType]bool, len(models.WebhookEventTypes))
+	for _, eventType := range models.WebhookEventTypes {
+		supported[eventType] = true
+	}
+	seen := make(map[models.WebhookEventType]bool, len(events))
+	result := make(models.WebhookEventList, 0, len(events))
+	for _, eventType := range events {
+		if !supported[eventType] {
+			return nil, fmt.Errorf("некорректный тип события: %s (поддерживаются lot.sold, lot.unsold, bid.placed, auction.completed)", eventType)
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			result = append(result, eventType)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.New("укажите хотя бы один тип события")
+	}
+	return result, nil
+}
+
+// CreateWebhook создает подписку на вебхуки. Секрет для проверки подписи возвращается только в ответе на создание.
+func (s *WebhookService) CreateWebhook(userID uint, currentUserRole models.UserRole, input models.CreateWebhookInput) (*models.WebhookSubscription, error) {
+	if currentUserRole != models.RoleSeller && currentUserRole != models.RoleSystemAdmin {
+		return nil, errors.New("недостаточно прав: вебхуки доступны продавцам и администратору")
+	}
+	if err := validateWebhookURL(input.URL); err != nil {
+		return nil, err
+	}
+	events, err := validateWebhookEvents(input.Events)
+	if err != nil {
+		return nil, err
+	}
+	_, total, err := s.webhookStore.GetWebhookSubscriptionsByUserID(userID, 0, 1)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка проверки подписок на вебхуки: %w", err)
+	}
+	if total >= maxWebhooksPerUser {
+		return nil, fmt.Errorf("нельзя создать больше %d подписок на вебхуки", maxWebhooksPerUser)
+	}
+
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка генерации секрета вебхука: %w", err)
+	}
+	subscription := models.WebhookSubscription{
+		UserID:   userID,
+		URL:      input.URL,
+		Secret:   secret,
+		Events:   events,
+		IsActive: true,
+	}
+	if err := s.webhookStore.CreateWebhookSubscription(&subscription); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения подписки на вебхуки: %w", err)
+	}
+	return &subscription, nil
+}
+
+// GetMyWebhooks возвращает подписки пользователя на вебхуки (без секретов)
+func (s *WebhookService) GetMyWebhooks(userID uint, page, pageSize int) ([]models.WebhookSubscription, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	subscriptions, total, err := s.webhookStore.GetWebhookSubscriptionsByUserID(userID, offset, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения подписок на вебхуки: %w", err)
+	}
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+	return subscriptions, total, nil
+}
+
+// UpdateWebhook меняет адрес, набор событий или активность подписки
+func (s *WebhookService) UpdateWebhook(userID, webhookID uint, input models.UpdateWebhookInput) (*models.WebhookSubscription, error) {
+	subscription, err := s.getMyWebhook(userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if input.URL != nil {
+		if err := validateWebhookURL(*input.URL); err != nil {
+			return nil, err
+		}
+		subscription.URL = *input.URL
+	}
+	if input.Events != nil {
+		events, err := validateWebhookEvents(input.Events)
+		if err != nil {
+			return nil, err
+		}
+		subscription.Events = events
+	}
+	if input.IsActive != nil {
+		subscription.IsActive = *input.IsActive
+	}
+	if err := s.webhookStore.UpdateWebhookSubscription(subscription); err != nil {
+		return nil, fmt.Errorf("ошибка обновления подписки на вебхуки: %w", err)
+	}
+	subscription.Secret = ""
+	return subscription, nil
+}
+
+// DeleteWebhook удаляет подписку вместе с журналом доставок
+func (s *WebhookService) DeleteWebhook(userID, webhookID uint) error {
+	return s.webhookStore.DeleteWebhookSubscription(userID, webhookID)
+}
+
+// GetWebhookDeliveries возвращает журнал доставок подписки; status ограничивает выборку (pending, succeeded, failed)
+func (s *WebhookService) GetWebhookDeliveries(userID, webhookID uint, page, pageSize int, status string) ([]models.WebhookDelivery, int64, error) {
+	switch models.WebhookDeliveryStatus(status) {
+	case "", models.DeliveryPending, models.DeliverySucceeded, models.DeliveryFailed:
+	default:
+		return nil, 0, fmt.Errorf("некорректный статус доставки: %s", status)
+	}
+	if _, err := s.getMyWebhook(userID, webhookID); err != nil {
+		return nil, 0, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	deliveries, total, err := s.webhookStore.GetWebhookDeliveriesBySubscriptionID(webhookID, offset, pageSize, status)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения журнала доставок: %w", err)
+	}
+	return deliveries, total, nil
+}
+
+// Redeliver повторно отправляет событие из журнала. Создается новая доставка с тем же телом и ID события,
+// поэтому получатель может распознать повтор; исходная запись журнала не меняется.
+func (s *WebhookService) Redeliver(userID, webhookID, deliveryID uint) (*models.WebhookDelivery, error) {
+	if _, err := s.getMyWebhook(userID, webhookID); err != nil {
+		return nil, err
+	}
+	original, err := s.webhookStore.GetWebhookDeliveryByID(deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения доставки: %w", err)
+	}
+	if original == nil || original.SubscriptionID != webhookID {
+		return nil, errors.New("доставка не найдена")
+	}
+
+	now := time.Now()
+	redelivery := models.WebhookDelivery{
+		SubscriptionID: webhookID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		Status:         models.DeliveryPending,
+		NextAttemptAt:  &now,
+		RedeliveryOfID: &original.ID,
+	}
+	deliveries := []models.WebhookDelivery{redelivery}
+	if err := s.webhookStore.CreateWebhookDeliveries(deliveries); err != nil {
+		return nil, fmt.Errorf("ошибка постановки повторной доставки: %w", err)
+	}
+	s.wake()
+	return &deliveries[0], nil
+}
+
+func (s *WebhookService) getMyWebhook(userID, webhookID uint) (*models.WebhookSubscription, error) {
+	subscription, err := s.webhookStore.GetWebhookSubscriptionByID(webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения подписки на вебхуки: %w", err)
+	}
+	if subscription == nil || subscription.UserID != userID {
+		return nil, errors.New("подписка на вебхуки не найдена")
+	}
+	return subscription, nil
+}
+
+// Dispatch записывает событие в журнал доставок каждой подходящей подписки; отправка идет в фоне.
+// Доставка с тем же eventID для подписки уже есть - повторно она не создается.
+func (s *WebhookService) Dispatch(eventID string, eventType models.WebhookEventType, ownerIDs []uint, data map[string]interface{}) error {
+	subscriptions, err := s.webhookStore.GetActiveWebhooksForEvent(eventType, ownerIDs)
+	if err != nil {
+		return fmt.Errorf("ошибка получения подписок на событие %s: %w", eventType, err)
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(webhookPayload{ID: eventID, Event: eventType, OccurredAt: now, Data: data})
+	if err != nil {
+		return fmt.Errorf("ошибка формирования тела события %s: %w", eventType, err)
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			EventType:      eventType,
+			Payload:        string(payload),
+			Status:         models.DeliveryPending,
+			NextAttemptAt:  &now,
+		})
+	}
+	if err := s.webhookStore.CreateWebhookDeliveries(deliveries); err != nil {
+		return fmt.Errorf("ошибка постановки в очередь события %s: %w", eventType, err)
+	}
+	s.wake()
+	return nil
+}
+
+// wake будит фоновую задачу, чтобы новые доставки ушли без ожидания очередного тика
+func (s *WebhookService) wake() {
+	select {
+	case s.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// StartDeliveryJob запускает фоновую отправку вебхуков: по таймеру и сразу после постановки новых доставок
+func (s *WebhookService) StartDeliveryJob(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-s.wakeup:
+			}
+			if err := s.RunDeliveries(time.Now()); err != nil {
+				log.Printf("[WebhookService] Ошибка отправки вебхуков: %v", err)
+			}
+		}
+	}()
+}
+
+// RunDeliveries отправляет все доставки, время попытки которых наступило. Каждая доставка сначала
+// закрепляется за экземпляром, поэтому при нескольких экземплярах бэкенда событие не уходит дважды.
+func (s *WebhookService) RunDeliveries(now time.Time) error {
+	for {
+		deliveries, err := s.webhookStore.GetDueWebhookDeliveries(now, webhookDeliveryBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		var wg sync.WaitGroup
+		workers := make(chan struct{}, webhookDeliveryWorkers)
+		for i := range deliveries {
+			delivery := deliveries[i]
+			claimed, err := s.webhookStore.ClaimWebhookDelivery(delivery.ID, *delivery.NextAttemptAt, now.Add(webhookDeliveryLease))
+			if err != nil {
+				return err
+			}
+			if !claimed {
+				continue
+			}
+			wg.Add(1)
+			workers <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-workers }()
+				s.attemptDelivery(&delivery)
+			}()
+		}
+		wg.Wait()
+		if len(deliveries) < webhookDeliveryBatchSize {
+			return nil
+		}
+	}
+}
+
+// attemptDelivery выполняет одну попытку отправки и планирует следующую с экспоненциальной задержкой
+func (s *WebhookService) attemptDelivery(delivery *models.WebhookDelivery) {
+	subscription, err := s.webhookStore.GetWebhookSubscriptionByID(delivery.SubscriptionID)
+	if err != nil {
+		log.Printf("[WebhookService] Не удалось получить подписку %d для доставки %d: %v", delivery.SubscriptionID, delivery.ID, err)
+		return
+	}
+
+	delivery.Attempts++
+	var statusCode int
+	if subscription == nil || !subscription.IsActive {
+		err = errors.New("подписка удалена или отключена")
+		delivery.Attempts = webhookMaxAttempts
+	} else {
+		statusCode, err = s.send(subscription, delivery)
+	}
+
+	now := time.Now()
+	delivery.LastResponseCode = statusCode
+	if err == nil {
+		delivery.Status = models.DeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+	} else {
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= webhookMaxAttempts {
+			delivery.Status = models.DeliveryFailed
+			delivery.NextAttemptAt = nil
+		} else {
+			next := now.Add(webhookRetryBaseDelay << (delivery.Attempts - 1))
+			delivery.NextAttemptAt = &next
+		}
+	}
+	if err := s.webhookStore.UpdateWebhookDelivery(delivery); err != nil {
+		log.Printf("[WebhookService] Не удалось сохранить результат доставки %d: %v", delivery.ID, err)
+	}
+}
+
+// send отправляет событие получателю. Успехом считается любой ответ 2xx.
+func (s *WebhookService) send(subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequest(http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Auction-Webhooks/1.0")
+	req.Header.Set("X-Webhook-Event", string(delivery.EventType))
+	req.Header.Set("X-Webhook-Id", delivery.EventID)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", signWebhookPayload(subscription.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("получатель ответил кодом %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
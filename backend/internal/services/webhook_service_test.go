@@ -0,0 +1,61 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateWebhookURLRejectsInternalAddresses(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "https://93.184.216.34/hook", wantErr: false},
+		{url: "http://[2606:4700::1111]:8080/hook", wantErr: false},
+		{url: "ftp://93.184.216.34/hook", wantErr: true},
+		{url: "http://127.0.0.1:8080/hook", wantErr: true},
+		{url: "http://[::1]/hook", wantErr: true},
+		{url: "http://10.0.0.5/hook", wantErr: true},
+		{url: "http://172.16.3.4/hook", wantErr: true},
+		{url: "http://192.168.1.1/hook", wantErr: true},
+		{url: "http://169.254.169.254/latest/meta-data/", wantErr: true},
+		{url: "http://100.64.1.1/hook", wantErr: true},
+		{url: "http://0.0.0.0:8080/hook", wantErr: true},
+		{url: "http://[fd00::1]/hook", wantErr: true},
+		{url: "http://[::ffff:127.0.0.1]/hook", wantErr: true},
+		{url: "http://localhost:8080/hook", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := validateWebhookURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateWebhookURL(%q) = %v, ожидалась ошибка: %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Подписка могла быть создана на публичное имя, которое затем стало указывать на внутренний адрес,
+// поэтому клиент доставки сам отказывается подключаться к таким адресам
+func TestWebhookClientRefusesInternalAddresses(t *testing.T) {
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+
+	service := NewWebhookService(nil)
+	resp, err := service.client.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("запрос к 127.0.0.1 должен быть отклонен")
+	}
+	if !errors.Is(err, errWebhookInternalAddress) {
+		t.Errorf("ошибка = %v, ожидалась errWebhookInternalAddress", err)
+	}
+	if requested {
+		t.Error("запрос дошел до внутреннего сервера")
+	}
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"auction-app/backend/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type gormAttributeSchemaStore struct {
+	db *gorm.DB
+}
+
+func NewGormAttributeSchemaStore(db *gorm.DB) AttributeSchemaStore {
+	return &gormAttributeSchemaStore{db: db}
+}
+
+func (s *gormAttributeSchemaStore) CreateAttributeSchema(schema *models.AttributeSchema) error {
+	return s.db.Create(schema).Error
+}
+
+func (s *gormAttributeSchemaStore) GetAllAttributeSchemas() ([]models.AttributeSchema, error) {
+	var schemas []models.AttributeSchema
+	err := s.db.Order("name ASC").Find(&schemas).Error
+	return schemas, err
+}
+
+func (s *gormAttributeSchemaStore) GetAttributeSchemaByID(id uint) (*models.AttributeSchema, error) {
+	var schema models.AttributeSchema
+	err := s.db.First(&schema, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &schema, nil
+}
+
+func (s *gormAttributeSchemaStore) GetAttributeSchemaByName(name string) (*models.AttributeSchema, error) {
+	var schema models.AttributeSchema
+	err := s.db.Where("name = ?", name).First(&schema).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &schema, nil
+}
+
+func (s *gormAttributeSchemaStore) UpdateAttributeSchema(schema *models.AttributeSchema) error {
+	return s.db.Save(schema).Error
+}
+
+func (s *gormAttributeSchemaStore) DeleteAttributeSchema(id uint) error {
+	return s.db.Delete(&models.AttributeSchema{}, id).Error
+}
+
+// CountAuctionsUsingSchema возвращает количество аукционов, к которым привязана схема
+func (s *gormAttributeSchemaStore) CountAuctionsUsingSchema(id uint) (int64, error) {
+	var count int64
+	err := s.db.Model(&models.Auction{}).Where("attribute_schema_id = ?", id).Count(&count).Error
+	return count, err
+}
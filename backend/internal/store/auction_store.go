@@ -3,6 +3,7 @@ package store
 import (
 	"auction-app/backend/internal/models"
 	"errors"
+	"strconv"
 	"strings"
 
 	"gorm.io/gorm"
@@ -16,19 +17,25 @@ func NewGormAuctionStore(db *gorm.DB) AuctionStore {
 	return &gormAuctionStore{db: db}
 }
 
-func (s *gormAuctionStore) FindAuctionsBySpecificity(specificityQuery string, offset, limit int) ([]models.Auction, int64, error) {
+func (s *gormAuctionStore) FindAuctionsBySpecificity(specificityQuery string, categoryID uint, offset, limit int) ([]models.Auction, int64, error) {
 	var auctions []models.Auction
 	var total int64
-	query := "%" + strings.ToLower(specificityQuery) + "%"
 
-	dbQueryTotal := s.db.Model(&models.Auction{}).Where("LOWER(name_specificity) LIKE ?", query)
-	if err := dbQueryTotal.Count(&total).Error; err != nil {
+	queryBuilder := s.db.Model(&models.Auction{})
+	if categoryID != 0 {
+		queryBuilder = queryBuilder.Where("category_id IN (?)", categorySubtreeIDs(s.db, categoryID))
+	}
+	if specificityQuery != "" {
+		queryBuilder = queryBuilder.Where("LOWER(name_specificity) LIKE ?", "%"+strings.ToLower(specificityQuery)+"%")
+	}
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	dbQueryFind := s.db.Where("LOWER(name_specificity) LIKE ?", query).
-		Order("auction_date DESC").Offset(offset).Limit(limit).
-		Preload("Lots")
+	// Поиск публичный, поэтому в ответ попадают только лоты, прошедшие модерацию
+	dbQueryFind := queryBuilder.Order("auction_date DESC").Offset(offset).Limit(limit).
+		Preload("Lots", "moderation_status = ?", models.ModerationApproved).Preload("Category")
 
 	if err := dbQueryFind.Find(&auctions).Error; err != nil {
 		return nil, 0, err
@@ -55,6 +62,12 @@ func (s *gormAuctionStore) GetAllAuctions(offset, limit int, filters map[string]
 	if dateTo, ok := filters["dateTo"]; ok && dateTo != "" {
 		queryBuilder = queryBuilder.Where("auction_date <= ?", dateTo)
 	}
+	if categoryID, ok := filters["categoryId"]; ok && categoryID != "" {
+		cID, err := strconv.ParseUint(categoryID, 10, 32)
+		if err == nil {
+			queryBuilder = queryBuilder.Where("category_id IN (?)", categorySubtreeIDs(s.db, uint(cID)))
+		}
+	}
 
 	if err := queryBuilder.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -66,7 +79,22 @@ func (s *gormAuctionStore) GetAllAuctions(offset, limit int, filters map[string]
 
 func (s *gormAuctionStore) GetAuctionByID(id uint) (*models.Auction, error) {
 	var auction models.Auction
-	err := s.db.Preload("Lots").Preload("User").First(&auction, id).Error
+	err := s.db.Preload("Lots").Preload("Lots.Allocations").Preload("User").Preload("Category").Preload("AttributeSchema").First(&auction, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &auction, nil
+}
+
+// GetPublicAuctionByID возвращает аукцион для публичного просмотра: в него загружаются только лоты,
+// прошедшие модерацию. Лоты на модерации и отклоненные видны продавцу и администратору в других разделах.
+func (s *gormAuctionStore) GetPublicAuctionByID(id uint) (*models.Auction, error) {
+	var auction models.Auction
+	err := s.db.Preload("Lots", "moderation_status = ?", models.ModerationApproved).Preload("Lots.Allocations").
+		Preload("User").Preload("Category").Preload("AttributeSchema").First(&auction, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -76,47 +104,70 @@ func (s *gormAuctionStore) GetAuctionByID(id uint) (*models.Auction, error) {
 	return &auction, nil
 }
 
+// GetAuctionsByIDs возвращает аукционы с указанными ID без лотов и связанных данных
+func (s *gormAuctionStore) GetAuctionsByIDs(ids []uint) ([]models.Auction, error) {
+	var auctions []models.Auction
+	if len(ids) == 0 {
+		return auctions, nil
+	}
+	err := s.db.Where("id IN ?", ids).Find(&auctions).Error
+	return auctions, err
+}
+
 func (s *gormAuctionStore) UpdateAuction(auction *models.Auction) error {
 	return s.db.Save(auction).Error
 }
 
-// UpdateAuctionStatus обновляет статус аукциона и его лотов в одной транзакции
-func (s *gormAuctionStore) UpdateAuctionStatus(id uint, status models.AuctionStatus, lotsToUpdate []models.Lot) error {
+// UpdateAuctionStatus меняет статус аукциона, обновляет лоты и сохраняет распределения единиц,
+// а также записывает доменные события в outbox и запись журнала аудита - все в одной транзакции
+func (s *gormAuctionStore) UpdateAuctionStatus(id uint, status models.AuctionStatus, lotsToUpdate []models.Lot, allocations []models.LotAllocation, events []models.OutboxEvent, auditEntry *models.AuditLogEntry) error {
 	return s.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Model(&models.Auction{}).Where("id = ?", id).Update("status", status).Error; err != nil {
 			return err
 		}
 
 		for _, lot := range lotsToUpdate {
+			lot.Allocations = nil
 			if err := tx.Save(&lot).Error; err != nil {
 				return err
 			}
 		}
-		return nil
+		if len(allocations) > 0 {
+			if err := tx.Create(&allocations).Error; err != nil {
+				return err
+			}
+		}
+		if err := insertOutboxEvents(tx, events); err != nil {
+			return err
+		}
+		return insertAuditLogEntry(tx, auditEntry)
 	})
 }
 
-func (s *gormAuctionStore) DeleteAuction(id uint) error {
-	var count int64
-	s.db.Model(&models.Lot{}).Where("auction_id = ? AND status IN (?, ?)", id, models.StatusLotActive, models.StatusPending).Count(&count)
-	if count > 0 {
-		return errors.New("нельзя удалить аукцион, на котором есть активные или ожидающие торгов лоты")
-	}
-	var auction models.Auction
-	if err := s.db.First(&auction, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("аукцион не найден")
+// DeleteAuction удаляет аукцион и записывает в журнал аудита запись об удалении в одной транзакции
+func (s *gormAuctionStore) DeleteAuction(id uint, auditEntry *models.AuditLogEntry) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		tx.Model(&models.Lot{}).Where("auction_id = ? AND status IN (?, ?)", id, models.StatusLotActive, models.StatusPending).Count(&count)
+		if count > 0 {
+			return errors.New("нельзя удалить аукцион, на котором есть активные или ожидающие торгов лоты")
+		}
+		var auction models.Auction
+		if err := tx.First(&auction, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("аукцион не найден")
+			}
+			return err
+		}
+		if auction.Status != models.StatusCompleted && auction.Status != models.StatusScheduled {
+			return errors.New("нельзя удалить аукцион, который идет или не был корректно завершен (и имеет активные лоты)")
 		}
-		return err
-	}
-	if auction.Status != models.StatusCompleted && auction.Status != models.StatusScheduled {
-		return errors.New("нельзя удалить аукцион, который идет или не был корректно завершен (и имеет активные лоты)")
-	}
 
-	if err := s.db.Delete(&models.Auction{}, id).Error; err != nil {
-		return err
-	}
-	return nil
+		if err := tx.Delete(&models.Auction{}, id).Error; err != nil {
+			return err
+		}
+		return insertAuditLogEntry(tx, auditEntry)
+	})
 }
 
 // GetAuctionWithMostSoldLots находит аукцион с наибольшим количеством проданных лотов
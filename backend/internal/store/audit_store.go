@@ -0,0 +1,89 @@
+package store
+
+import (
+	"auction-app/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gormAuditStore struct {
+	db *gorm.DB
+}
+
+func NewGormAuditStore(db *gorm.DB) AuditStore {
+	return &gormAuditStore{db: db}
+}
+
+// insertAuditLogEntry добавляет запись в журнал аудита внутри транзакции самого действия: действие
+// без записи в журнале не сохраняется, а ошибка записи отменяет действие. entry == nil означает, что действие не журналируется.
+func insertAuditLogEntry(tx *gorm.DB, entry *models.AuditLogEntry) error {
+	if entry == nil {
+		return nil
+	}
+	return tx.Create(entry).Error
+}
+
+// auditLogQuery применяет фильтры журнала: actorId, action, targetType, targetId и интервал from/to (RFC 3339)
+func (s *gormAuditStore) auditLogQuery(filters map[string]string) *gorm.DB {
+	queryBuilder := s.db.Model(&models.AuditLogEntry{})
+	if actorID, ok := filters["actorId"]; ok && actorID != "" {
+		queryBuilder = queryBuilder.Where("actor_id = ?", actorID)
+	}
+	if action, ok := filters["action"]; ok && action != "" {
+		queryBuilder = queryBuilder.Where("action = ?", action)
+	}
+	if targetType, ok := filters["targetType"]; ok && targetType != "" {
+		queryBuilder = queryBuilder.Where("target_type = ?", targetType)
+	}
+	if targetID, ok := filters["targetId"]; ok && targetID != "" {
+		queryBuilder = queryBuilder.Where("target_id = ?", targetID)
+	}
+	if from, ok := filters["from"]; ok && from != "" {
+		if t, err := time.Parse(time.RFC3339, from); err == nil {
+			queryBuilder = queryBuilder.Where("created_at >= ?", t)
+		}
+	}
+	if to, ok := filters["to"]; ok && to != "" {
+		if t, err := time.Parse(time.RFC3339, to); err == nil {
+			queryBuilder = queryBuilder.Where("created_at < ?", t)
+		}
+	}
+	return queryBuilder
+}
+
+// GetAuditLogEntries возвращает страницу журнала аудита, начиная с последних записей
+func (s *gormAuditStore) GetAuditLogEntries(offset, limit int, filters map[string]string) ([]models.AuditLogEntry, int64, error) {
+	var entries []models.AuditLogEntry
+	var total int64
+	queryBuilder := s.auditLogQuery(filters)
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := queryBuilder.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&entries).Error
+	return entries, total, err
+}
+
+// ForEachAuditLogEntryBatch передает в handle все записи журнала, подходящие под фильтры, порциями
+// по batchSize в хронологическом порядке, чтобы выгрузка не загружала весь журнал в память
+func (s *gormAuditStore) ForEachAuditLogEntryBatch(filters map[string]string, batchSize int, handle func(entries []models.AuditLogEntry) error) error {
+	var afterID uint
+	for {
+		var entries []models.AuditLogEntry
+		err := s.auditLogQuery(filters).Where("id > ?", afterID).Order("id ASC").Limit(batchSize).Find(&entries).Error
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		if err := handle(entries); err != nil {
+			return err
+		}
+		if len(entries) < batchSize {
+			return nil
+		}
+		afterID = entries[len(entries)-1].ID
+	}
+}
@@ -2,8 +2,11 @@ package store
 
 import (
 	"auction-app/backend/internal/models"
+	"errors"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type gormBidStore struct {
@@ -18,6 +21,36 @@ func (s *gormBidStore) CreateBid(bid *models.Bid) error {
 	return s.db.Create(bid).Error
 }
 
+// PlaceBid в одной транзакции сохраняет ставку, обновленный лот и доменные события. buildEvents вызывается
+// после сохранения ставки, чтобы события могли ссылаться на ее ID.
+// Ставка проверялась по прочитанному ранее состоянию лота, поэтому строка лота блокируется и сверяется
+// с ним по updated_at: если лот успел снять с торгов или перебить другой участник, ставка не сохраняется.
+func (s *gormBidStore) PlaceBid(bid *models.Bid, lot *models.Lot, buildEvents func() ([]models.OutboxEvent, error)) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var current models.Lot
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "status", "updated_at").First(&current, lot.ID).Error; err != nil {
+			return err
+		}
+		if current.Status != models.StatusLotActive && current.Status != models.StatusPending {
+			return errors.New("ставки на данный лот не принимаются (статус лота)")
+		}
+		if !current.UpdatedAt.Equal(lot.UpdatedAt) {
+			return errors.New("состояние лота изменилось, пока обрабатывалась ставка: обновите данные лота и повторите ставку")
+		}
+		if err := tx.Create(bid).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(lot).Error; err != nil {
+			return err
+		}
+		events, err := buildEvents()
+		if err != nil {
+			return err
+		}
+		return insertOutboxEvents(tx, events)
+	})
+}
+
 func (s *gormBidStore) GetBidsByLotID(lotID uint, offset, limit int) ([]models.Bid, int64, error) {
 	var bids []models.Bid
 	var total int64
@@ -32,3 +65,42 @@ func (s *gormBidStore) GetBidsByLotID(lotID uint, offset, limit int) ([]models.B
 		Find(&bids).Error
 	return bids, total, err
 }
+
+// GetAllBidsByLotID возвращает все действующие (не аннулированные) ставки по лоту в хронологическом порядке
+func (s *gormBidStore) GetAllBidsByLotID(lotID uint) ([]models.Bid, error) {
+	var bids []models.Bid
+	err := s.db.Where("lot_id = ? AND voided = ?", lotID, false).
+		Order("bid_time ASC, id ASC").
+		Find(&bids).Error
+	return bids, err
+}
+
+// GetBidHistoryByLotIDs возвращает все ставки по лотам, включая аннулированные, в хронологическом порядке
+func (s *gormBidStore) GetBidHistoryByLotIDs(lotIDs []uint) ([]models.Bid, error) {
+	var bids []models.Bid
+	if len(lotIDs) == 0 {
+		return bids, nil
+	}
+	err := s.db.Where("lot_id IN ?", lotIDs).
+		Order("bid_time ASC, id ASC").
+		Find(&bids).Error
+	return bids, err
+}
+
+func (s *gormBidStore) CreateBidAttempt(attempt *models.BidAttempt) error {
+	return s.db.Create(attempt).Error
+}
+
+// CountBidAttemptsByUserSince возвращает число отклоненных попыток ставок пользователя, сохраненных начиная с since
+func (s *gormBidStore) CountBidAttemptsByUserSince(userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := s.db.Model(&models.BidAttempt{}).Where("user_id = ? AND created_at >= ?", userID, since).Count(&count).Error
+	return count, err
+}
+
+// GetBidAttemptsByAuctionID возвращает отклоненные попытки ставок по аукциону в хронологическом порядке
+func (s *gormBidStore) GetBidAttemptsByAuctionID(auctionID uint) ([]models.BidAttempt, error) {
+	var attempts []models.BidAttempt
+	err := s.db.Where("auction_id = ?", auctionID).Order("created_at ASC, id ASC").Find(&attempts).Error
+	return attempts, err
+}
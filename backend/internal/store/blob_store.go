@@ -0,0 +1,84 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// localBlobStore хранит файлы в каталоге локальной файловой системы
+type localBlobStore struct {
+	root string
+}
+
+// NewLocalBlobStore создает хранилище файлов в указанном каталоге, создавая его при необходимости
+func NewLocalBlobStore(root string) (BlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory %s: %w", root, err)
+	}
+	return &localBlobStore{root: root}, nil
+}
+
+// path преобразует ключ в путь внутри корневого каталога, не допуская выхода за его пределы
+func (s *localBlobStore) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + filepath.FromSlash(key))
+	if cleaned == string(filepath.Separator) || strings.Contains(key, "..") {
+		return "", errors.New("некорректный ключ файла")
+	}
+	return filepath.Join(s.root, cleaned), nil
+}
+
+func (s *localBlobStore) Put(key string, r io.Reader) error {
+	fullPath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return err
+	}
+
+	// Пишем во временный файл и переименовываем, чтобы читатели не увидели недописанный файл
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), fullPath)
+}
+
+func (s *localBlobStore) Open(key string) (io.ReadCloser, error) {
+	fullPath, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(fullPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errors.New("файл не найден")
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *localBlobStore) Delete(key string) error {
+	fullPath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fullPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"auction-app/backend/internal/models"
+	"errors"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+type gormBlocklistStore struct {
+	db *gorm.DB
+}
+
+func NewGormBlocklistStore(db *gorm.DB) BlocklistStore {
+	return &gormBlocklistStore{db: db}
+}
+
+func (s *gormBlocklistStore) AddBlockedBidder(entry *models.BlockedBidder) error {
+	return s.db.Create(entry).Error
+}
+
+func (s *gormBlocklistStore) RemoveBlockedBidder(ownerID, blockedUserID uint) error {
+	result := s.db.Where("owner_id = ? AND blocked_user_id = ?", ownerID, blockedUserID).Delete(&models.BlockedBidder{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("пользователь не найден в черном списке")
+	}
+	return nil
+}
+
+func (s *gormBlocklistStore) GetBlockedBidder(ownerID, blockedUserID uint) (*models.BlockedBidder, error) {
+	var entry models.BlockedBidder
+	err := s.db.Where("owner_id = ? AND blocked_user_id = ?", ownerID, blockedUserID).First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (s *gormBlocklistStore) GetBlockedBiddersByOwnerID(ownerID uint, offset, limit int) ([]models.BlockedBidder, int64, error) {
+	var entries []models.BlockedBidder
+	var total int64
+	queryBuilder := s.db.Model(&models.BlockedBidder{}).Where("owner_id = ?", ownerID)
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := queryBuilder.Order("created_at DESC").Offset(offset).Limit(limit).
+		Preload("BlockedUser").
+		Find(&entries).Error
+	return entries, total, err
+}
+
+func (s *gormBlocklistStore) GetAllBlockedBidders(offset, limit int, filters map[string]string) ([]models.BlockedBidder, int64, error) {
+	var entries []models.BlockedBidder
+	var total int64
+	queryBuilder := s.db.Model(&models.BlockedBidder{})
+
+	if ownerID, ok := filters["ownerId"]; ok && ownerID != "" {
+		oID, err := strconv.ParseUint(ownerID, 10, 32)
+		if err == nil {
+			queryBuilder = queryBuilder.Where("owner_id = ?", uint(oID))
+		}
+	}
+	if blockedUserID, ok := filters["blockedUserId"]; ok && blockedUserID != "" {
+		bID, err := strconv.ParseUint(blockedUserID, 10, 32)
+		if err == nil {
+			queryBuilder = queryBuilder.Where("blocked_user_id = ?", uint(bID))
+		}
+	}
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := queryBuilder.Order("owner_id ASC, created_at DESC").Offset(offset).Limit(limit).
+		Preload("Owner").
+		Preload("BlockedUser").
+		Find(&entries).Error
+	return entries, total, err
+}
+
+// IsBidderBlocked проверяет, внесен ли участник в черный список хотя бы одного из владельцев
+func (s *gormBlocklistStore) IsBidderBlocked(ownerIDs []uint, bidderID uint) (bool, error) {
+	if len(ownerIDs) == 0 {
+		return false, nil
+	}
+	var count int64
+	err := s.db.Model(&models.BlockedBidder{}).
+		Where("owner_id IN (?) AND blocked_user_id = ?", ownerIDs, bidderID).
+		Count(&count).Error
+	return count > 0, err
+}
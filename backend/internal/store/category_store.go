@@ -0,0 +1,104 @@
+package store
+
+import (
+	"auction-app/backend/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type gormCategoryStore struct {
+	db *gorm.DB
+}
+
+func NewGormCategoryStore(db *gorm.DB) CategoryStore {
+	return &gormCategoryStore{db: db}
+}
+
+// categorySubtreeIDs возвращает подзапрос с ID рубрики и всех ее подрубрик (рекурсивный CTE)
+func categorySubtreeIDs(db *gorm.DB, categoryID uint) *gorm.DB {
+	return db.Raw(`WITH RECURSIVE subtree AS (
+		SELECT id FROM categories WHERE id = ?
+		UNION ALL
+		SELECT c.id FROM categories c JOIN subtree ON c.parent_id = subtree.id
+	) SELECT id FROM subtree`, categoryID)
+}
+
+func (s *gormCategoryStore) CreateCategory(category *models.Category) error {
+	return s.db.Create(category).Error
+}
+
+func (s *gormCategoryStore) GetAllCategories() ([]models.Category, error) {
+	var categories []models.Category
+	err := s.db.Order("name ASC").Find(&categories).Error
+	return categories, err
+}
+
+func (s *gormCategoryStore) GetCategoryByID(id uint) (*models.Category, error) {
+	var category models.Category
+	err := s.db.Preload("AttributeSchema").First(&category, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// GetCategoryByName ищет рубрику с заданным названием среди дочерних рубрик parentID (nil - среди корневых)
+func (s *gormCategoryStore) GetCategoryByName(parentID *uint, name string) (*models.Category, error) {
+	var category models.Category
+	query := s.db.Where("LOWER(name) = LOWER(?)", name)
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+	err := query.First(&category).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// GetCategoryPath возвращает цепочку рубрик от заданной до корня (первым элементом - сама рубрика)
+func (s *gormCategoryStore) GetCategoryPath(id uint) ([]models.Category, error) {
+	var path []models.Category
+	err := s.db.Raw(`WITH RECURSIVE path AS (
+		SELECT categories.*, 0 AS depth FROM categories WHERE id = ?
+		UNION ALL
+		SELECT c.*, path.depth + 1 FROM categories c JOIN path ON c.id = path.parent_id
+	) SELECT id, name, description, parent_id, attribute_schema_id, created_at, updated_at FROM path ORDER BY depth ASC`, id).
+		Scan(&path).Error
+	return path, err
+}
+
+func (s *gormCategoryStore) GetCategorySubtreeIDs(id uint) ([]uint, error) {
+	var ids []uint
+	err := categorySubtreeIDs(s.db, id).Scan(&ids).Error
+	return ids, err
+}
+
+func (s *gormCategoryStore) UpdateCategory(category *models.Category) error {
+	return s.db.Omit("Children", "AttributeSchema").Save(category).Error
+}
+
+func (s *gormCategoryStore) DeleteCategory(id uint) error {
+	return s.db.Delete(&models.Category{}, id).Error
+}
+
+// CountCategoryUsage возвращает количество подрубрик, аукционов и лотов, ссылающихся на рубрику
+func (s *gormCategoryStore) CountCategoryUsage(id uint) (children, auctions, lots int64, err error) {
+	if err = s.db.Model(&models.Category{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+		return
+	}
+	if err = s.db.Model(&models.Auction{}).Where("category_id = ?", id).Count(&auctions).Error; err != nil {
+		return
+	}
+	err = s.db.Model(&models.Lot{}).Where("category_id = ?", id).Count(&lots).Error
+	return
+}
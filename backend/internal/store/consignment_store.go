@@ -0,0 +1,162 @@
+package store
+
+import (
+	"auction-app/backend/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormConsignmentStore struct {
+	db *gorm.DB
+}
+
+func NewGormConsignmentStore(db *gorm.DB) ConsignmentStore {
+	return &gormConsignmentStore{db: db}
+}
+
+func (s *gormConsignmentStore) CreateConsignment(consignment *models.Consignment) error {
+	return s.db.Create(consignment).Error
+}
+
+func (s *gormConsignmentStore) GetConsignmentByID(id uint) (*models.Consignment, error) {
+	var consignment models.Consignment
+	err := s.db.Preload("Seller").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Images", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC, id ASC") }).
+		First(&consignment, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &consignment, nil
+}
+
+func (s *gormConsignmentStore) GetConsignments(offset, limit int, filters map[string]string) ([]models.Consignment, int64, error) {
+	var consignments []models.Consignment
+	var total int64
+
+	queryBuilder := s.db.Model(&models.Consignment{})
+	if sellerID, ok := filters["sellerId"]; ok && sellerID != "" {
+		queryBuilder = queryBuilder.Where("seller_id = ?", sellerID)
+	}
+	if status, ok := filters["status"]; ok && status != "" {
+		queryBuilder = queryBuilder.Where("status = ?", status)
+	}
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := queryBuilder.Order("created_at DESC").Offset(offset).Limit(limit).
+		Preload("Seller").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Find(&consignments).Error
+	return consignments, total, err
+}
+
+func (s *gormConsignmentStore) GetConsignmentItemsByIDs(ids []uint) ([]models.ConsignmentItem, error) {
+	var items []models.ConsignmentItem
+	err := s.db.Where("id IN ?", ids).
+		Preload("Images", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC, id ASC") }).
+		Find(&items).Error
+	return items, err
+}
+
+// UpdateConsignmentItem сохраняет решение по предмету и пересчитывает статус заявки
+func (s *gormConsignmentStore) UpdateConsignmentItem(item *models.ConsignmentItem) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(item).Error; err != nil {
+			return err
+		}
+		return refreshConsignmentStatus(tx, item.ConsignmentID)
+	})
+}
+
+// AssignItemsToLots создает лоты для предметов заявок (лоты и предметы сопоставляются по индексу)
+// и отмечает предметы выставленными. Все изменения выполняются в одной транзакции; лоты получают
+// следующие номера в аукционе. Предметы, которые к этому моменту уже не в статусе «Принят», не назначаются.
+func (s *gormConsignmentStore) AssignItemsToLots(items []models.ConsignmentItem, lots []models.Lot) error {
+	if len(lots) == 0 {
+		return nil
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		lotNumber, err := nextLotNumber(tx, lots[0].AuctionID)
+		if err != nil {
+			return err
+		}
+		for i := range lots {
+			lots[i].LotNumber = lotNumber + i
+		}
+		if err := tx.Create(&lots).Error; err != nil {
+			return err
+		}
+
+		consignmentIDs := make(map[uint]bool)
+		for i := range items {
+			result := tx.Model(&models.ConsignmentItem{}).
+				Where("id = ? AND status = ?", items[i].ID, models.ConsignmentItemAccepted).
+				Updates(map[string]interface{}{"status": models.ConsignmentItemListed, "lot_id": lots[i].ID})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errors.New("предмет заявки уже назначен на аукцион или его статус изменился")
+			}
+			items[i].Status = models.ConsignmentItemListed
+			items[i].LotID = &lots[i].ID
+			consignmentIDs[items[i].ConsignmentID] = true
+		}
+		for consignmentID := range consignmentIDs {
+			if err := refreshConsignmentStatus(tx, consignmentID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// refreshConsignmentStatus выводит статус заявки из статусов ее предметов
+func refreshConsignmentStatus(tx *gorm.DB, consignmentID uint) error {
+	var counts struct {
+		Pending  int64
+		Accepted int64
+	}
+	err := tx.Model(&models.ConsignmentItem{}).
+		Select("SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS pending, SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS accepted",
+			models.ConsignmentItemPending, models.ConsignmentItemAccepted).
+		Where("consignment_id = ?", consignmentID).
+		Scan(&counts).Error
+	if err != nil {
+		return err
+	}
+
+	status := models.ConsignmentCompleted
+	if counts.Pending > 0 {
+		status = models.ConsignmentSubmitted
+	} else if counts.Accepted > 0 {
+		status = models.ConsignmentReviewed
+	}
+	return tx.Model(&models.Consignment{}).Where("id = ?", consignmentID).Update("status", status).Error
+}
+
+func (s *gormConsignmentStore) CreateConsignmentItemImages(images []models.ConsignmentItemImage) error {
+	if len(images) == 0 {
+		return nil
+	}
+	return s.db.Create(&images).Error
+}
+
+func (s *gormConsignmentStore) GetConsignmentItemImage(itemID, imageID uint) (*models.ConsignmentItemImage, error) {
+	var image models.ConsignmentItemImage
+	err := s.db.Where("item_id = ?", itemID).First(&image, imageID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &image, nil
+}
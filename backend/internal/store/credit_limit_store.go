@@ -0,0 +1,75 @@
+package store
+
+import (
+	"auction-app/backend/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormCreditLimitStore struct {
+	db *gorm.DB
+}
+
+func NewGormCreditLimitStore(db *gorm.DB) CreditLimitStore {
+	return &gormCreditLimitStore{db: db}
+}
+
+// UpsertCreditLimit создает лимит или обновляет сумму существующего лимита участника на аукционе
+func (s *gormCreditLimitStore) UpsertCreditLimit(limit *models.CreditLimit) error {
+	return s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "auction_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"amount", "set_by_user_id", "updated_at"}),
+	}).Create(limit).Error
+}
+
+func (s *gormCreditLimitStore) GetCreditLimit(userID, auctionID uint) (*models.CreditLimit, error) {
+	var limit models.CreditLimit
+	err := s.db.Where("user_id = ? AND auction_id = ?", userID, auctionID).First(&limit).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &limit, nil
+}
+
+func (s *gormCreditLimitStore) GetCreditLimitsByAuctionID(auctionID uint, offset, limit int) ([]models.CreditLimit, int64, error) {
+	var limits []models.CreditLimit
+	var total int64
+	queryBuilder := s.db.Model(&models.CreditLimit{}).Where("auction_id = ?", auctionID)
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := queryBuilder.Order("user_id ASC").Offset(offset).Limit(limit).
+		Preload("User").
+		Find(&limits).Error
+	return limits, total, err
+}
+
+func (s *gormCreditLimitStore) DeleteCreditLimit(userID, auctionID uint) error {
+	result := s.db.Where("user_id = ? AND auction_id = ?", userID, auctionID).Delete(&models.CreditLimit{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("кредитный лимит не найден")
+	}
+	return nil
+}
+
+// GetOpenLotsBidByUser возвращает лоты аукциона, по которым торги еще не завершены и у участника есть
+// действующая ставка, вместе со всеми действующими ставками этих лотов
+func (s *gormCreditLimitStore) GetOpenLotsBidByUser(userID, auctionID uint, excludeLotID uint) ([]models.Lot, error) {
+	var lots []models.Lot
+	userBidLotIDs := s.db.Model(&models.Bid{}).Select("lot_id").Where("user_id = ? AND voided = ?", userID, false)
+	err := s.db.Preload("Biddings", "voided = ?", false).
+		Where("auction_id = ? AND id <> ? AND status IN (?, ?) AND id IN (?)",
+			auctionID, excludeLotID, models.StatusPending, models.StatusLotActive, userBidLotIDs).
+		Find(&lots).Error
+	return lots, err
+}
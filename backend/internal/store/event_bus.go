@@ -0,0 +1,52 @@
+package store
+
+import (
+	"auction-app/backend/config"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+// auctionEventsChannel - канал PostgreSQL, через который экземпляры бэкенда обмениваются событиями торгов
+const auctionEventsChannel = "auction_events"
+
+// PostgresEventBus передает события торгов между экземплярами бэкенда через LISTEN/NOTIFY.
+// Публикация идет через общее подключение GORM, а для приема открывается отдельное соединение,
+// потому что LISTEN действует только в рамках одного соединения. Размер сообщения NOTIFY
+// ограничен 8000 байтами, поэтому через шину передаются только небольшие события.
+type PostgresEventBus struct {
+	db  *gorm.DB
+	dsn string
+}
+
+// NewPostgresEventBus создает шину событий поверх PostgreSQL
+func NewPostgresEventBus(db *gorm.DB, cfg *config.Config) *PostgresEventBus {
+	return &PostgresEventBus{db: db, dsn: postgresDSN(cfg)}
+}
+
+// Publish отправляет сообщение всем экземплярам, включая текущий
+func (b *PostgresEventBus) Publish(payload []byte) error {
+	return b.db.Exec("SELECT pg_notify(?, ?)", auctionEventsChannel, string(payload)).Error
+}
+
+// Listen подписывается на канал событий и передает сообщения в handle до ошибки соединения или отмены контекста
+func (b *PostgresEventBus) Listen(ctx context.Context, handle func(payload []byte)) error {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return fmt.Errorf("ошибка подключения к БД для приема событий: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+auctionEventsChannel); err != nil {
+		return fmt.Errorf("ошибка подписки на канал событий: %w", err)
+	}
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handle([]byte(notification.Payload))
+	}
+}
@@ -14,12 +14,15 @@ import (
 
 var DB *gorm.DB
 
-func InitDB(cfg *config.Config) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=disable TimeZone=Europe/Moscow",
+// postgresDSN формирует строку подключения к PostgreSQL из конфигурации
+func postgresDSN(cfg *config.Config) string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=disable TimeZone=Europe/Moscow",
 		cfg.DBHost, cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBPort)
+}
 
+func InitDB(cfg *config.Config) (*gorm.DB, error) {
 	var err error
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
+	DB, err = gorm.Open(postgres.Open(postgresDSN(cfg)), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
 	})
 
@@ -32,19 +35,110 @@ func InitDB(cfg *config.Config) (*gorm.DB, error) {
 
 	err = DB.AutoMigrate(
 		&models.User{},
+		&models.AttributeSchema{},
+		&models.Category{},
 		&models.Auction{},
 		&models.Lot{},
 		&models.Bid{},
+		&models.BlockedBidder{},
+		&models.CreditLimit{},
+		&models.LotAllocation{},
+		&models.LotImage{},
+		&models.Consignment{},
+		&models.ConsignmentItem{},
+		&models.ConsignmentItemImage{},
+		&models.WatchlistEntry{},
+		&models.Notification{},
+		&models.SavedSearch{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+		&models.OutboxEvent{},
+		&models.OutboxConsumption{},
+		&models.AuditLogEntry{},
+		&models.BidAttempt{},
+		&models.UserSession{},
+		&models.RefreshToken{},
 	)
 	if err != nil {
 		log.Fatalf("Failed to auto-migrate database schemas: %v", err)
 		return nil, err
 	}
+
+	if err := backfillLotAllocations(DB); err != nil {
+		log.Fatalf("Failed to backfill lot allocations: %v", err)
+		return nil, err
+	}
+	if err := ensureUniqueLotNumbers(DB); err != nil {
+		log.Fatalf("Failed to enforce unique lot numbers: %v", err)
+		return nil, err
+	}
+	if err := protectAuditLog(DB); err != nil {
+		log.Fatalf("Failed to protect audit log: %v", err)
+		return nil, err
+	}
 	log.Println("Database migration completed successfully.")
 
 	return DB, nil
 }
 
+// backfillLotAllocations создает распределения для лотов, проданных до появления многоединичных лотов,
+// по прежнему столбцу final_buyer_id и затем удаляет его: покупатели лота теперь хранятся только в распределениях
+func backfillLotAllocations(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.Lot{}, "final_buyer_id") {
+		return nil
+	}
+	err := db.Exec(`INSERT INTO lot_allocations (lot_id, user_id, quantity, price, created_at)
+		SELECT l.id, l.final_buyer_id, 1, l.final_price, l.updated_at FROM lots l
+		WHERE l.status = ? AND l.final_buyer_id IS NOT NULL AND l.final_price IS NOT NULL
+		AND NOT EXISTS (SELECT 1 FROM lot_allocations la WHERE la.lot_id = l.id)`, models.StatusSold).Error
+	if err != nil {
+		return err
+	}
+	return db.Migrator().DropColumn(&models.Lot{}, "final_buyer_id")
+}
+
+// ensureUniqueLotNumbers перенумеровывает лоты аукционов с повторяющимися номерами (их могла выдать прежняя
+// нумерация через COUNT(*)+1), а также с пропусками в запланированных аукционах, и создает уникальный индекс
+// по номеру лота в аукционе. Удаленные лоты в индекс не входят.
+func ensureUniqueLotNumbers(db *gorm.DB) error {
+	err := db.Exec(`UPDATE lots SET lot_number = numbered.rn
+		FROM (
+			SELECT id, ROW_NUMBER() OVER (PARTITION BY auction_id ORDER BY lot_number, id) AS rn
+			FROM lots
+			WHERE deleted_at IS NULL AND auction_id IN (
+				SELECT l.auction_id FROM lots l JOIN auctions a ON a.id = l.auction_id
+				WHERE l.deleted_at IS NULL
+				GROUP BY l.auction_id, a.status
+				HAVING COUNT(*) <> COUNT(DISTINCT l.lot_number) OR (a.status = ? AND MAX(l.lot_number) <> COUNT(*))
+			)
+		) AS numbered
+		WHERE lots.id = numbered.id AND lots.lot_number <> numbered.rn`, models.StatusScheduled).Error
+	if err != nil {
+		return err
+	}
+	return db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_lots_auction_lot_number
+		ON lots (auction_id, lot_number) WHERE deleted_at IS NULL`).Error
+}
+
+// protectAuditLog запрещает изменять и удалять записи журнала аудита на уровне БД,
+// чтобы журнал нельзя было исправить в обход приложения
+func protectAuditLog(db *gorm.DB) error {
+	err := db.Exec(`CREATE OR REPLACE FUNCTION audit_log_entries_immutable() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'журнал аудита нельзя изменять или удалять';
+		END;
+		$$ LANGUAGE plpgsql`).Error
+	if err != nil {
+		return err
+	}
+	if err := db.Exec(`DROP TRIGGER IF EXISTS audit_log_entries_immutable ON audit_log_entries`).Error; err != nil {
+		return err
+	}
+	return db.Exec(`CREATE TRIGGER audit_log_entries_immutable
+		BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_log_entries
+		FOR EACH STATEMENT EXECUTE FUNCTION audit_log_entries_immutable()`).Error
+}
+
 func GetDB() *gorm.DB {
 	if DB == nil {
 		log.Fatal("Database instance is not initialized. Call InitDB first.")
@@ -81,3 +175,83 @@ func SeedSystemAdmin(db *gorm.DB) {
 		log.Println("Системный администратор уже существует.")
 	}
 }
+
+// SeedAttributeSchemas создает стандартные схемы атрибутов для распространенных специфик аукционов
+func SeedAttributeSchemas(db *gorm.DB) {
+	minYear, maxYear := float64(1), float64(2100)
+	defaults := []models.AttributeSchema{
+		{
+			Name:        "Монеты",
+			Description: "Нумизматика: монеты и медали",
+			Fields: models.AttributeFields{
+				{Key: "year", Label: "Год чеканки", Type: models.AttributeTypeInteger, Required: true, Min: &minYear, Max: &maxYear},
+				{Key: "mint", Label: "Монетный двор", Type: models.AttributeTypeString, Required: true},
+				{Key: "grade", Label: "Сохранность", Type: models.AttributeTypeEnum, Required: true,
+					Options: []string{"G", "VG", "F", "VF", "XF", "AU", "UNC", "PROOF"}},
+			},
+		},
+		{
+			Name:        "Живопись",
+			Description: "Картины и графика",
+			Fields: models.AttributeFields{
+				{Key: "artist", Label: "Художник", Type: models.AttributeTypeString, Required: true},
+				{Key: "medium", Label: "Техника", Type: models.AttributeTypeString, Required: true},
+				{Key: "dimensions", Label: "Размеры (см)", Type: models.AttributeTypeString, Required: true},
+			},
+		},
+	}
+
+	for _, schema := range defaults {
+		var existing models.AttributeSchema
+		err := db.Where("name = ?", schema.Name).First(&existing).Error
+		if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+			if err := db.Create(&schema).Error; err != nil {
+				log.Printf("Ошибка создания схемы атрибутов %s: %v", schema.Name, err)
+			}
+		} else if err != nil {
+			log.Printf("Ошибка проверки существования схемы атрибутов %s: %v", schema.Name, err)
+		}
+	}
+}
+
+// SeedCategories создает базовые рубрики и связывает их со стандартными схемами атрибутов
+func SeedCategories(db *gorm.DB) {
+	var count int64
+	if err := db.Model(&models.Category{}).Count(&count).Error; err != nil {
+		log.Printf("Ошибка проверки рубрикатора: %v", err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+
+	schemaID := func(name string) *uint {
+		var schema models.AttributeSchema
+		if err := db.Where("name = ?", name).First(&schema).Error; err != nil {
+			return nil
+		}
+		return &schema.ID
+	}
+
+	roots := []struct {
+		name     string
+		children []models.Category
+	}{
+		{name: "Нумизматика", children: []models.Category{{Name: "Монеты", AttributeSchemaID: schemaID("Монеты")}}},
+		{name: "Искусство", children: []models.Category{{Name: "Живопись", AttributeSchemaID: schemaID("Живопись")}, {Name: "Графика"}}},
+		{name: "Антиквариат"},
+	}
+	for _, root := range roots {
+		parent := models.Category{Name: root.name}
+		if err := db.Create(&parent).Error; err != nil {
+			log.Printf("Ошибка создания рубрики %s: %v", root.name, err)
+			continue
+		}
+		for _, child := range root.children {
+			child.ParentID = &parent.ID
+			if err := db.Create(&child).Error; err != nil {
+				log.Printf("Ошибка создания рубрики %s: %v", child.Name, err)
+			}
+		}
+	}
+}
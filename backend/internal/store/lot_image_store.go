@@ -0,0 +1,100 @@
+package store
+
+import (
+	"auction-app/backend/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type gormLotImageStore struct {
+	db *gorm.DB
+}
+
+func NewGormLotImageStore(db *gorm.DB) LotImageStore {
+	return &gormLotImageStore{db: db}
+}
+
+func (s *gormLotImageStore) CreateLotImages(images []models.LotImage) error {
+	if len(images) == 0 {
+		return nil
+	}
+	return s.db.Create(&images).Error
+}
+
+func (s *gormLotImageStore) GetLotImages(lotID uint) ([]models.LotImage, error) {
+	var images []models.LotImage
+	err := s.db.Where("lot_id = ?", lotID).Order("position ASC, id ASC").Find(&images).Error
+	return images, err
+}
+
+func (s *gormLotImageStore) GetLotImage(lotID, imageID uint) (*models.LotImage, error) {
+	var image models.LotImage
+	err := s.db.Where("lot_id = ?", lotID).First(&image, imageID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &image, nil
+}
+
+// UpdateLotImagesOrder проставляет позиции фотографий лота в порядке переданных ID
+func (s *gormLotImageStore) UpdateLotImagesOrder(lotID uint, orderedIDs []uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for position, imageID := range orderedIDs {
+			if err := tx.Model(&models.LotImage{}).
+				Where("id = ? AND lot_id = ?", imageID, lotID).
+				Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SetPrimaryLotImage делает фотографию основной, снимая признак с остальных фотографий лота
+func (s *gormLotImageStore) SetPrimaryLotImage(lotID, imageID uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.LotImage{}).
+			Where("lot_id = ? AND is_primary = ?", lotID, true).
+			Update("is_primary", false).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.LotImage{}).
+			Where("id = ? AND lot_id = ?", imageID, lotID).
+			Update("is_primary", true).Error
+	})
+}
+
+// DeleteLotImage удаляет фотографию, перенумеровывает оставшиеся и при необходимости назначает новую основную
+func (s *gormLotImageStore) DeleteLotImage(lotID, imageID uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("lot_id = ?", lotID).Delete(&models.LotImage{}, imageID).Error; err != nil {
+			return err
+		}
+
+		var remaining []models.LotImage
+		if err := tx.Where("lot_id = ?", lotID).Order("position ASC, id ASC").Find(&remaining).Error; err != nil {
+			return err
+		}
+		hasPrimary := false
+		for _, img := range remaining {
+			if img.IsPrimary {
+				hasPrimary = true
+				break
+			}
+		}
+		for position, img := range remaining {
+			updates := map[string]interface{}{"position": position}
+			if !hasPrimary && position == 0 {
+				updates["is_primary"] = true
+			}
+			if err := tx.Model(&models.LotImage{}).Where("id = ?", img.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
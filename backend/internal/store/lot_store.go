@@ -4,8 +4,11 @@ import (
 	"auction-app/backend/internal/models"
 	"errors"
 	"strconv"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type gormLotStore struct {
@@ -16,24 +19,67 @@ func NewGormLotStore(db *gorm.DB) LotStore {
 	return &gormLotStore{db: db}
 }
 
+// CreateLot создает лот и присваивает ему следующий свободный номер в аукционе
 func (s *gormLotStore) CreateLot(lot *models.Lot) error {
-	var count int64
-	s.db.Model(&models.Lot{}).Where("auction_id = ?", lot.AuctionID).Count(&count)
-	lot.LotNumber = int(count) + 1
-	return s.db.Create(lot).Error
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		lotNumber, err := nextLotNumber(tx, lot.AuctionID)
+		if err != nil {
+			return err
+		}
+		lot.LotNumber = lotNumber
+		return tx.Create(lot).Error
+	})
+}
+
+// CreateLots создает лоты одного аукциона в одной транзакции, присваивая им номера по порядку
+func (s *gormLotStore) CreateLots(lots []models.Lot) error {
+	if len(lots) == 0 {
+		return nil
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		lotNumber, err := nextLotNumber(tx, lots[0].AuctionID)
+		if err != nil {
+			return err
+		}
+		for i := range lots {
+			lots[i].LotNumber = lotNumber + i
+		}
+		return tx.Create(&lots).Error
+	})
+}
+
+// lockAuctionLots блокирует строку аукциона до конца транзакции. Все операции, меняющие номера лотов,
+// выполняются под этой блокировкой, поэтому параллельные запросы не получат одинаковых номеров.
+func lockAuctionLots(tx *gorm.DB, auctionID uint) error {
+	var auction models.Auction
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&auction, auctionID).Error
+}
+
+// nextLotNumber блокирует нумерацию аукциона и возвращает номер для нового лота
+func nextLotNumber(tx *gorm.DB, auctionID uint) (int, error) {
+	if err := lockAuctionLots(tx, auctionID); err != nil {
+		return 0, err
+	}
+	var maxNumber int
+	err := tx.Model(&models.Lot{}).Where("auction_id = ?", auctionID).
+		Select("COALESCE(MAX(lot_number), 0)").Scan(&maxNumber).Error
+	return maxNumber + 1, err
 }
 
-func (s *gormLotStore) GetLotsByAuctionID(auctionID uint, offset, limit int) ([]models.Lot, int64, error) {
+func (s *gormLotStore) GetLotsByAuctionID(auctionID uint, offset, limit int, filters map[string]string) ([]models.Lot, int64, error) {
 	var lots []models.Lot
 	var total int64
 	queryBuilder := s.db.Model(&models.Lot{}).Where("auction_id = ?", auctionID)
+	if moderationStatus, ok := filters["moderationStatus"]; ok && moderationStatus != "" {
+		queryBuilder = queryBuilder.Where("moderation_status = ?", moderationStatus)
+	}
 
 	if err := queryBuilder.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	err := queryBuilder.Order("lot_number ASC").Offset(offset).Limit(limit).
-		Preload("User").Preload("HighestBidder").
+		Preload("User").Preload("HighestBidder").Preload("Allocations").Preload("Images", "is_primary = ?", true).
 		Find(&lots).Error
 	return lots, total, err
 }
@@ -47,7 +93,7 @@ func (s *gormLotStore) GetLotsBySellerID(sellerID uint, offset, limit int) ([]mo
 		return nil, 0, err
 	}
 	err := queryBuilder.Order("created_at DESC").Offset(offset).Limit(limit).
-		Preload("FinalBuyer").
+		Preload("Allocations.User").
 		Find(&lots).Error
 	return lots, total, err
 }
@@ -72,8 +118,9 @@ func (s *gormLotStore) GetLeadingBidsByUserID(userID uint, offset, limit int) ([
 func (s *gormLotStore) GetWonLotsByUserID(userID uint, offset, limit int) ([]models.Lot, int64, error) {
 	var lots []models.Lot
 	var total int64
+	wonLotIDs := s.db.Model(&models.LotAllocation{}).Select("lot_id").Where("user_id = ?", userID)
 	queryBuilder := s.db.Model(&models.Lot{}).
-		Where("final_buyer_id = ? AND status = ?", userID, models.StatusSold)
+		Where("id IN (?) AND status = ?", wonLotIDs, models.StatusSold)
 
 	if err := queryBuilder.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -81,13 +128,16 @@ func (s *gormLotStore) GetWonLotsByUserID(userID uint, offset, limit int) ([]mod
 
 	err := queryBuilder.Order("updated_at DESC").Offset(offset).Limit(limit).
 		Preload("User").
+		Preload("Allocations", "user_id = ?", userID).
 		Find(&lots).Error
 	return lots, total, err
 }
 
 func (s *gormLotStore) GetLotByID(id uint) (*models.Lot, error) {
 	var lot models.Lot
-	err := s.db.Preload("User").Preload("HighestBidder").Preload("FinalBuyer").First(&lot, id).Error
+	err := s.db.Preload("User").Preload("HighestBidder").Preload("Allocations.User").
+		Preload("Images", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		First(&lot, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -101,19 +151,147 @@ func (s *gormLotStore) UpdateLot(lot *models.Lot) error {
 	return s.db.Save(lot).Error
 }
 
+// DeleteLot удаляет лот и сдвигает номера следующих за ним лотов аукциона, чтобы в нумерации не было пропусков
 func (s *gormLotStore) DeleteLot(id uint) error {
-	if err := s.db.Delete(&models.Lot{}, id).Error; err != nil {
-		return err
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var lot models.Lot
+		if err := tx.Select("id", "auction_id").First(&lot, id).Error; err != nil {
+			return err
+		}
+		if err := lockAuctionLots(tx, lot.AuctionID); err != nil {
+			return err
+		}
+		// номер перечитывается под блокировкой: его могла изменить параллельная перенумерация
+		if err := tx.Select("id", "auction_id", "lot_number").First(&lot, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Lot{}, id).Error; err != nil {
+			return err
+		}
+		// Уникальный индекс проверяется для каждой строки сразу, поэтому сдвиг идет в два шага через отрицательные номера
+		following := tx.Model(&models.Lot{}).Where("auction_id = ? AND lot_number > ?", lot.AuctionID, lot.LotNumber)
+		if err := following.Update("lot_number", gorm.Expr("-lot_number")).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Lot{}).Where("auction_id = ? AND lot_number < 0", lot.AuctionID).
+			Update("lot_number", gorm.Expr("-lot_number - 1")).Error
+	})
+}
+
+// WithdrawLot снимает лот с торгов и аннулирует все ставки по нему в одной транзакции.
+// Лот должен ожидать торгов или торговаться, иначе возвращается ошибка.
+func (s *gormLotStore) WithdrawLot(lot *models.Lot, events []models.OutboxEvent) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Lot{}).
+			Where("id = ? AND status IN (?, ?)", lot.ID, models.StatusPending, models.StatusLotActive).
+			Updates(map[string]interface{}{
+				"status":               lot.Status,
+				"current_price":        lot.CurrentPrice,
+				"highest_bidder_id":    lot.HighestBidderID,
+				"withdrawal_reason":    lot.WithdrawalReason,
+				"withdrawn_at":         lot.WithdrawnAt,
+				"withdrawn_by_user_id": lot.WithdrawnByUserID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("снять с торгов можно только лот, который ожидает торгов или торгуется")
+		}
+		if err := tx.Model(&models.Bid{}).Where("lot_id = ?", lot.ID).Update("voided", true).Error; err != nil {
+			return err
+		}
+		return insertOutboxEvents(tx, events)
+	})
+}
+
+// GetLotsForModeration возвращает очередь модерации: по умолчанию лоты, ожидающие проверки, начиная с самых давних
+func (s *gormLotStore) GetLotsForModeration(offset, limit int, filters map[string]string) ([]models.Lot, int64, error) {
+	var lots []models.Lot
+	var total int64
+
+	moderationStatus := string(models.ModerationPending)
+	if status, ok := filters["moderationStatus"]; ok && status != "" {
+		moderationStatus = status
+	}
+	queryBuilder := s.db.Model(&models.Lot{}).Where("moderation_status = ?", moderationStatus)
+	if auctionID, ok := filters["auctionId"]; ok && auctionID != "" {
+		queryBuilder = queryBuilder.Where("auction_id = ?", auctionID)
+	}
+	if sellerID, ok := filters["sellerId"]; ok && sellerID != "" {
+		queryBuilder = queryBuilder.Where("seller_id = ?", sellerID)
+	}
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := queryBuilder.Order("updated_at ASC").Offset(offset).Limit(limit).
+		Preload("User").
+		Preload("Images", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Find(&lots).Error
+	return lots, total, err
+}
+
+// UpdateLotModeration сохраняет решение модератора. Решение принимается только по лоту, который ждет проверки:
+// если лот уже рассмотрен другим модератором, возвращается ошибка.
+func (s *gormLotStore) UpdateLotModeration(lot *models.Lot) error {
+	result := s.db.Model(&models.Lot{}).
+		Where("id = ? AND moderation_status = ?", lot.ID, models.ModerationPending).
+		Updates(map[string]interface{}{
+			"moderation_status":    lot.ModerationStatus,
+			"moderation_reason":    lot.ModerationReason,
+			"moderation_comment":   lot.ModerationComment,
+			"moderated_by_user_id": lot.ModeratedByUserID,
+			"moderated_at":         lot.ModeratedAt,
+			"published_at":         lot.PublishedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("лот не ожидает модерации: решение по нему уже принято")
 	}
 	return nil
 }
 
+// ReorderLots присваивает лотам аукциона номера 1..N в порядке lotIDs. Список должен содержать все лоты аукциона.
+func (s *gormLotStore) ReorderLots(auctionID uint, lotIDs []uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockAuctionLots(tx, auctionID); err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&models.Lot{}).Where("auction_id = ? AND id IN ?", auctionID, lotIDs).Count(&count).Error; err != nil {
+			return err
+		}
+		var total int64
+		if err := tx.Model(&models.Lot{}).Where("auction_id = ?", auctionID).Count(&total).Error; err != nil {
+			return err
+		}
+		if count != int64(len(lotIDs)) || total != count {
+			return errors.New("некорректный порядок лотов: состав лотов аукциона изменился, обновите список")
+		}
+
+		if err := tx.Model(&models.Lot{}).Where("auction_id = ?", auctionID).
+			Update("lot_number", gorm.Expr("-lot_number")).Error; err != nil {
+			return err
+		}
+		for i, lotID := range lotIDs {
+			if err := tx.Model(&models.Lot{}).Where("id = ? AND auction_id = ?", lotID, auctionID).
+				Update("lot_number", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (s *gormLotStore) GetLotWithMaxPriceDifference() (*models.Lot, error) {
 	var lot models.Lot
 	err := s.db.Where("status = ? AND final_price IS NOT NULL", models.StatusSold).
 		Order("(final_price - start_price) DESC").
 		Preload("User").
-		Preload("FinalBuyer").
+		Preload("Allocations.User").
 		First(&lot).Error
 
 	if err != nil {
@@ -131,7 +309,7 @@ func (s *gormLotStore) GetMostExpensiveSoldLot() (*models.Lot, error) {
 	err := s.db.Where("status = ? AND final_price IS NOT NULL", models.StatusSold).
 		Order("final_price DESC").
 		Preload("User").
-		Preload("FinalBuyer").
+		Preload("Allocations.User").
 		First(&lot).Error
 
 	if err != nil {
@@ -149,7 +327,7 @@ func (s *gormLotStore) GetTopNSoldLotsByPrice(limit int) ([]models.Lot, error) {
 		Order("final_price DESC").
 		Limit(limit).
 		Preload("User").
-		Preload("FinalBuyer").
+		Preload("Allocations.User").
 		Find(&lots).Error
 	if err != nil {
 		return nil, err
@@ -180,6 +358,10 @@ func (s *gormLotStore) GetAllLots(offset, limit int, filters map[string]string)
 		queryBuilder = queryBuilder.Where("lots.status IN (?, ?)", models.StatusPending, models.StatusLotActive)
 	}
 
+	if moderationStatus, ok := filters["moderationStatus"]; ok && moderationStatus != "" {
+		queryBuilder = queryBuilder.Where("lots.moderation_status = ?", moderationStatus)
+	}
+
 	if sellerID, ok := filters["sellerId"]; ok && sellerID != "" {
 		sID, err := strconv.ParseUint(sellerID, 10, 32)
 		if err == nil {
@@ -193,10 +375,31 @@ func (s *gormLotStore) GetAllLots(offset, limit int, filters map[string]string)
 		}
 	}
 
+	if categoryID, ok := filters["categoryId"]; ok && categoryID != "" {
+		cID, err := strconv.ParseUint(categoryID, 10, 32)
+		if err == nil {
+			queryBuilder = queryBuilder.Where("COALESCE(lots.category_id, auction.category_id) IN (?)", categorySubtreeIDs(s.db, uint(cID)))
+		}
+	}
+
 	if monthFilter, ok := filters["auctionMonth"]; ok && monthFilter != "" {
 		queryBuilder = queryBuilder.Where("to_char(auction.auction_date, 'YYYY-MM') = ?", monthFilter)
 	}
 
+	// Окно публикации (RFC 3339) используется фоновой проверкой сохраненных поисков
+	if publishedAfter, ok := filters["publishedAfter"]; ok && publishedAfter != "" {
+		if t, err := time.Parse(time.RFC3339Nano, publishedAfter); err == nil {
+			queryBuilder = queryBuilder.Where("lots.published_at > ?", t)
+		}
+	}
+	if publishedBefore, ok := filters["publishedBefore"]; ok && publishedBefore != "" {
+		if t, err := time.Parse(time.RFC3339Nano, publishedBefore); err == nil {
+			queryBuilder = queryBuilder.Where("lots.published_at <= ?", t)
+		}
+	}
+
+	queryBuilder = applyLotAttributeFilters(queryBuilder, filters)
+
 	if err := queryBuilder.Select("lots.id").Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
@@ -211,8 +414,79 @@ func (s *gormLotStore) GetAllLots(offset, limit int, filters map[string]string)
 		Limit(limit).
 		Preload("User").
 		Preload("HighestBidder").
-		Preload("FinalBuyer").
+		Preload("Allocations.User").
+		Preload("Images", "is_primary = ?", true).
 		Find(&lots).Error
 
 	return lots, total, err
 }
+
+// applyLotAttributeFilters добавляет условия по атрибутам лота из фильтров вида
+// attr.<ключ> (точное совпадение без учета регистра), attrMin.<ключ> и attrMax.<ключ> (числовой диапазон).
+// Ключ передается в запрос параметром, поэтому подстановка SQL через него невозможна.
+func applyLotAttributeFilters(queryBuilder *gorm.DB, filters map[string]string) *gorm.DB {
+	for name, value := range filters {
+		switch {
+		case strings.HasPrefix(name, "attr."):
+			key := strings.TrimPrefix(name, "attr.")
+			queryBuilder = queryBuilder.Where("LOWER(lots.attributes ->> ?) = LOWER(?)", key, value)
+		case strings.HasPrefix(name, "attrMin."), strings.HasPrefix(name, "attrMax."):
+			bound, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			key := name[strings.Index(name, ".")+1:]
+			operator := ">="
+			if strings.HasPrefix(name, "attrMax.") {
+				operator = "<="
+			}
+			queryBuilder = queryBuilder.Where(
+				"CASE WHEN jsonb_typeof(lots.attributes -> ?) = 'number' THEN (lots.attributes ->> ?)::numeric END "+operator+" ?",
+				key, key, bound)
+		}
+	}
+	return queryBuilder
+}
+
+// GetEstimatePerformance сравнивает цены продажи проданных лотов с их оценками, группируя по аукциону
+// (groupBy = "auction") или продавцу (groupBy = "seller"). Учитываются только лоты с заданной оценкой.
+func (s *gormLotStore) GetEstimatePerformance(groupBy string, filters map[string]string) ([]models.EstimatePerformanceRow, error) {
+	var rows []models.EstimatePerformanceRow
+
+	queryBuilder := s.db.Table("lots as l").
+		Joins("JOIN auctions as a ON a.id = l.auction_id").
+		Joins("JOIN users as u ON u.id = l.seller_id").
+		Where("l.deleted_at IS NULL AND l.status = ? AND l.final_price IS NOT NULL", models.StatusSold).
+		Where("l.estimate_low IS NOT NULL AND l.estimate_high IS NOT NULL")
+
+	if auctionID, ok := filters["auctionId"]; ok && auctionID != "" {
+		aID, err := strconv.ParseUint(auctionID, 10, 32)
+		if err == nil {
+			queryBuilder = queryBuilder.Where("l.auction_id = ?", uint(aID))
+		}
+	}
+	if sellerID, ok := filters["sellerId"]; ok && sellerID != "" {
+		sID, err := strconv.ParseUint(sellerID, 10, 32)
+		if err == nil {
+			queryBuilder = queryBuilder.Where("l.seller_id = ?", uint(sID))
+		}
+	}
+
+	idColumn, nameColumn := "a.id", "a.name_specificity"
+	if groupBy == "seller" {
+		idColumn, nameColumn = "u.id", "u.full_name"
+	}
+
+	err := queryBuilder.Select(idColumn + " AS group_id, " + nameColumn + ` AS group_name,
+		COUNT(*) AS lots_total,
+		SUM(CASE WHEN l.final_price > l.estimate_high THEN 1 ELSE 0 END) AS above_estimate,
+		SUM(CASE WHEN l.final_price BETWEEN l.estimate_low AND l.estimate_high THEN 1 ELSE 0 END) AS within_estimate,
+		SUM(CASE WHEN l.final_price < l.estimate_low THEN 1 ELSE 0 END) AS below_estimate,
+		SUM(l.final_price) AS hammer_total,
+		SUM(l.estimate_low) AS estimate_low_total,
+		SUM(l.estimate_high) AS estimate_high_total`).
+		Group(idColumn + ", " + nameColumn).
+		Order(idColumn + " ASC").
+		Scan(&rows).Error
+	return rows, err
+}
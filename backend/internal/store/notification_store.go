@@ -0,0 +1,80 @@
+package store
+
+import (
+	"auction-app/backend/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormNotificationStore struct {
+	db *gorm.DB
+}
+
+func NewGormNotificationStore(db *gorm.DB) NotificationStore {
+	return &gormNotificationStore{db: db}
+}
+
+func (s *gormNotificationStore) CreateNotification(notification *models.Notification) error {
+	return s.db.Create(notification).Error
+}
+
+// CreateNotificationOnce сохраняет уведомление, если уведомления с тем же ключом идемпотентности еще нет.
+// Возвращает false для повтора.
+func (s *gormNotificationStore) CreateNotificationOnce(notification *models.Notification) (bool, error) {
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(notification)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (s *gormNotificationStore) GetNotificationsByUserID(userID uint, offset, limit int, unreadOnly bool) ([]models.Notification, int64, error) {
+	var notifications []models.Notification
+	var total int64
+	queryBuilder := s.db.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		queryBuilder = queryBuilder.Where("read_at IS NULL")
+	}
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := queryBuilder.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&notifications).Error
+	return notifications, total, err
+}
+
+func (s *gormNotificationStore) CountUnreadNotifications(userID uint) (int64, error) {
+	var count int64
+	err := s.db.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// MarkNotificationRead отмечает уведомление пользователя прочитанным; повторная отметка время прочтения не меняет
+func (s *gormNotificationStore) MarkNotificationRead(userID, notificationID uint, readAt time.Time) (*models.Notification, error) {
+	var notification models.Notification
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).First(&notification, notificationID).Error; err != nil {
+			return err
+		}
+		if notification.ReadAt != nil {
+			return nil
+		}
+		notification.ReadAt = &readAt
+		return tx.Model(&notification).Update("read_at", readAt).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (s *gormNotificationStore) MarkAllNotificationsRead(userID uint, readAt time.Time) (int64, error) {
+	result := s.db.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Update("read_at", readAt)
+	return result.RowsAffected, result.Error
+}
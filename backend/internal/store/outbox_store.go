@@ -0,0 +1,82 @@
+package store
+
+import (
+	"auction-app/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormOutboxStore struct {
+	db *gorm.DB
+}
+
+func NewGormOutboxStore(db *gorm.DB) OutboxStore {
+	return &gormOutboxStore{db: db}
+}
+
+// insertOutboxEvents записывает доменные события в outbox внутри транзакции изменения состояния
+func insertOutboxEvents(tx *gorm.DB, events []models.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return tx.Create(&events).Error
+}
+
+// GetDueOutboxEvents возвращает необработанные события, время очередной попытки которых наступило, в порядке записи
+func (s *gormOutboxStore) GetDueOutboxEvents(now time.Time, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := s.db.Where("status = ? AND next_attempt_at <= ?", models.OutboxPending, now).
+		Order("id ASC").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+// ClaimOutboxEvent закрепляет событие за текущим экземпляром, переводя время следующей попытки на leaseUntil.
+// Возвращает false, если событие уже забрал другой экземпляр.
+func (s *gormOutboxStore) ClaimOutboxEvent(id uint, expectedNextAttemptAt, leaseUntil time.Time) (bool, error) {
+	result := s.db.Model(&models.OutboxEvent{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", id, models.OutboxPending, expectedNextAttemptAt).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetOutboxConsumers возвращает подписчиков, которые уже обработали событие
+func (s *gormOutboxStore) GetOutboxConsumers(eventID uint) ([]string, error) {
+	var consumers []string
+	err := s.db.Model(&models.OutboxConsumption{}).Where("event_id = ?", eventID).Pluck("consumer", &consumers).Error
+	return consumers, err
+}
+
+func (s *gormOutboxStore) MarkOutboxConsumed(eventID uint, consumer string, processedAt time.Time) error {
+	consumption := models.OutboxConsumption{EventID: eventID, Consumer: consumer, ProcessedAt: processedAt}
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&consumption).Error
+}
+
+func (s *gormOutboxStore) UpdateOutboxEvent(event *models.OutboxEvent) error {
+	return s.db.Model(&models.OutboxEvent{}).Where("id = ?", event.ID).Updates(map[string]interface{}{
+		"status":          event.Status,
+		"next_attempt_at": event.NextAttemptAt,
+		"attempts":        event.Attempts,
+		"last_error":      event.LastError,
+		"processed_at":    event.ProcessedAt,
+	}).Error
+}
+
+// GetOutboxEventsForAuction возвращает события указанных типов по аукциону и его лотам в порядке записи.
+// Обработанные события из outbox не удаляются, поэтому по ним можно восстановить ход торгов.
+func (s *gormOutboxStore) GetOutboxEventsForAuction(auctionID uint, lotIDs []uint, eventTypes []models.DomainEventType) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	queryBuilder := s.db.Where("event_type IN ?", eventTypes)
+	if len(lotIDs) > 0 {
+		queryBuilder = queryBuilder.Where("(aggregate_type = ? AND aggregate_id = ?) OR (aggregate_type = ? AND aggregate_id IN ?)",
+			"auction", auctionID, "lot", lotIDs)
+	} else {
+		queryBuilder = queryBuilder.Where("aggregate_type = ? AND aggregate_id = ?", "auction", auctionID)
+	}
+	err := queryBuilder.Order("id ASC").Find(&events).Error
+	return events, err
+}
@@ -0,0 +1,71 @@
+package store
+
+import (
+	"auction-app/backend/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gormSavedSearchStore struct {
+	db *gorm.DB
+}
+
+func NewGormSavedSearchStore(db *gorm.DB) SavedSearchStore {
+	return &gormSavedSearchStore{db: db}
+}
+
+func (s *gormSavedSearchStore) CreateSavedSearch(search *models.SavedSearch) error {
+	return s.db.Create(search).Error
+}
+
+func (s *gormSavedSearchStore) GetSavedSearchByID(id uint) (*models.SavedSearch, error) {
+	var search models.SavedSearch
+	err := s.db.First(&search, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &search, nil
+}
+
+func (s *gormSavedSearchStore) GetSavedSearchesByUserID(userID uint, offset, limit int) ([]models.SavedSearch, int64, error) {
+	var searches []models.SavedSearch
+	var total int64
+	queryBuilder := s.db.Model(&models.SavedSearch{}).Where("user_id = ?", userID)
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := queryBuilder.Order("created_at DESC").Offset(offset).Limit(limit).Find(&searches).Error
+	return searches, total, err
+}
+
+func (s *gormSavedSearchStore) UpdateSavedSearch(search *models.SavedSearch) error {
+	return s.db.Save(search).Error
+}
+
+func (s *gormSavedSearchStore) DeleteSavedSearch(userID, id uint) error {
+	result := s.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.SavedSearch{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("сохраненный поиск не найден")
+	}
+	return nil
+}
+
+// GetSavedSearchesBatch возвращает сохраненные поиски с ID больше afterID по порядку, для обхода всех поисков частями
+func (s *gormSavedSearchStore) GetSavedSearchesBatch(afterID uint, limit int) ([]models.SavedSearch, error) {
+	var searches []models.SavedSearch
+	err := s.db.Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&searches).Error
+	return searches, err
+}
+
+func (s *gormSavedSearchStore) UpdateLastCheckedAt(id uint, checkedAt time.Time) error {
+	return s.db.Model(&models.SavedSearch{}).Where("id = ?", id).Update("last_checked_at", checkedAt).Error
+}
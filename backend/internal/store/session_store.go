@@ -0,0 +1,116 @@
+package store
+
+import (
+	"auction-app/backend/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gormSessionStore struct {
+	db *gorm.DB
+}
+
+func NewGormSessionStore(db *gorm.DB) SessionStore {
+	return &gormSessionStore{db: db}
+}
+
+// CreateSession создает сессию вместе с ее первым refresh-токеном
+func (s *gormSessionStore) CreateSession(session *models.UserSession, refreshToken *models.RefreshToken) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		refreshToken.SessionID = session.ID
+		return tx.Create(refreshToken).Error
+	})
+}
+
+func (s *gormSessionStore) GetSessionByID(id uint) (*models.UserSession, error) {
+	var session models.UserSession
+	err := s.db.Where("id = ?", id).First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (s *gormSessionStore) GetRefreshTokenByHash(tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := s.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken помечает токен использованным и в той же транзакции сохраняет следующий токен сессии.
+// Возвращает false, если токен уже был использован (в том числе параллельным запросом) - тогда ничего не сохраняется.
+func (s *gormSessionStore) RotateRefreshToken(usedTokenID uint, next *models.RefreshToken, usedAt time.Time) (bool, error) {
+	rotated := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL", usedTokenID).
+			Update("used_at", usedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.UserSession{}).Where("id = ?", next.SessionID).Update("last_used_at", usedAt).Error; err != nil {
+			return err
+		}
+		rotated = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return rotated, nil
+}
+
+// UpdateSessionRole меняет активную роль действующей сессии
+func (s *gormSessionStore) UpdateSessionRole(id uint, role models.UserRole) error {
+	return s.db.Model(&models.UserSession{}).Where("id = ? AND revoked_at IS NULL", id).Update("active_role", role).Error
+}
+
+// RevokeSession отзывает сессию; уже отозванная сессия не меняется
+func (s *gormSessionStore) RevokeSession(id uint, reason string, revokedAt time.Time) error {
+	return s.db.Model(&models.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": revokedAt, "revoked_reason": reason}).Error
+}
+
+// RevokeUserSessions отзывает все действующие сессии пользователя и возвращает их число. Если задан
+// buildAuditEntry, запись журнала аудита (по числу отозванных сессий) добавляется в той же транзакции.
+func (s *gormSessionStore) RevokeUserSessions(userID uint, reason string, revokedAt time.Time, buildAuditEntry func(revoked int64) *models.AuditLogEntry) (int64, error) {
+	var revoked int64
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.UserSession{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Updates(map[string]interface{}{"revoked_at": revokedAt, "revoked_reason": reason})
+		if result.Error != nil {
+			return result.Error
+		}
+		revoked = result.RowsAffected
+		if buildAuditEntry == nil {
+			return nil
+		}
+		return insertAuditLogEntry(tx, buildAuditEntry(revoked))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return revoked, nil
+}
@@ -1,6 +1,10 @@
 package store
 
-import "auction-app/backend/internal/models"
+import (
+	"auction-app/backend/internal/models"
+	"io"
+	"time"
+)
 
 // UserStore определяет методы для работы с пользователями в хранилище
 type UserStore interface {
@@ -9,8 +13,9 @@ type UserStore interface {
 	GetUserByID(id uint) (*models.User, error)
 	GetAllUsers(offset, limit int, filters map[string]string) ([]models.User, int64, error)
 	UpdateUser(user *models.User) error
-	GetBuyersByAuctionSpecificity(specificity string, offset, limit int) ([]models.User, int64, error)
-	GetSellersWithSalesByAuctionSpecificity(specificity string, minTotalSales float64, offset, limit int) ([]models.SellerSalesReport, int64, error)
+	UpdateUserWithAudit(user *models.User, auditEntry *models.AuditLogEntry) error
+	GetBuyersByAuctionSpecificity(specificity string, categoryID uint, offset, limit int) ([]models.User, int64, error)
+	GetSellersWithSalesByAuctionSpecificity(specificity string, categoryID uint, minTotalSales float64, offset, limit int) ([]models.SellerSalesReport, int64, error)
 }
 
 // AuctionStore определяет методы для работы с аукционами
@@ -18,10 +23,12 @@ type AuctionStore interface {
 	CreateAuction(auction *models.Auction) error
 	GetAllAuctions(offset, limit int, filters map[string]string) ([]models.Auction, int64, error)
 	GetAuctionByID(id uint) (*models.Auction, error)
+	GetPublicAuctionByID(id uint) (*models.Auction, error)
+	GetAuctionsByIDs(ids []uint) ([]models.Auction, error)
 	UpdateAuction(auction *models.Auction) error
-	UpdateAuctionStatus(id uint, status models.AuctionStatus, lotsToUpdate []models.Lot) error
-	DeleteAuction(id uint) error
-	FindAuctionsBySpecificity(specificityQuery string, offset, limit int) ([]models.Auction, int64, error)
+	UpdateAuctionStatus(id uint, status models.AuctionStatus, lotsToUpdate []models.Lot, allocations []models.LotAllocation, events []models.OutboxEvent, auditEntry *models.AuditLogEntry) error
+	DeleteAuction(id uint, auditEntry *models.AuditLogEntry) error
+	FindAuctionsBySpecificity(specificityQuery string, categoryID uint, offset, limit int) ([]models.Auction, int64, error)
 	GetAuctionWithMostSoldLots() (*models.Auction, int64, error)
 	GetAuctionsWithoutSoldLots(offset, limit int) ([]models.Auction, int64, error)
 }
@@ -29,10 +36,15 @@ type AuctionStore interface {
 // LotStore определяет методы для работы с лотами
 type LotStore interface {
 	CreateLot(lot *models.Lot) error
-	GetLotsByAuctionID(auctionID uint, offset, limit int) ([]models.Lot, int64, error)
+	CreateLots(lots []models.Lot) error
+	GetLotsByAuctionID(auctionID uint, offset, limit int, filters map[string]string) ([]models.Lot, int64, error)
 	GetLotByID(id uint) (*models.Lot, error)
 	UpdateLot(lot *models.Lot) error
 	DeleteLot(id uint) error
+	ReorderLots(auctionID uint, lotIDs []uint) error
+	WithdrawLot(lot *models.Lot, events []models.OutboxEvent) error
+	GetLotsForModeration(offset, limit int, filters map[string]string) ([]models.Lot, int64, error)
+	UpdateLotModeration(lot *models.Lot) error
 	GetLotsBySellerID(sellerID uint, offset, limit int) ([]models.Lot, int64, error)
 	GetLeadingBidsByUserID(userID uint, offset, limit int) ([]models.Lot, int64, error)
 	GetWonLotsByUserID(userID uint, offset, limit int) ([]models.Lot, int64, error)
@@ -41,17 +53,188 @@ type LotStore interface {
 	GetTopNSoldLotsByPrice(limit int) ([]models.Lot, error)
 	GetActiveLotsByAuctionID(auctionID uint) ([]models.Lot, error)
 	GetAllLots(offset, limit int, filters map[string]string) ([]models.Lot, int64, error)
+	GetEstimatePerformance(groupBy string, filters map[string]string) ([]models.EstimatePerformanceRow, error)
 }
 
 // BidStore определяет методы для работы со ставками
 type BidStore interface {
 	CreateBid(bid *models.Bid) error
+	PlaceBid(bid *models.Bid, lot *models.Lot, buildEvents func() ([]models.OutboxEvent, error)) error
 	GetBidsByLotID(lotID uint, offset, limit int) ([]models.Bid, int64, error)
+	GetAllBidsByLotID(lotID uint) ([]models.Bid, error)
+	GetBidHistoryByLotIDs(lotIDs []uint) ([]models.Bid, error)
+	CreateBidAttempt(attempt *models.BidAttempt) error
+	CountBidAttemptsByUserSince(userID uint, since time.Time) (int64, error)
+	GetBidAttemptsByAuctionID(auctionID uint) ([]models.BidAttempt, error)
+}
+
+// BlocklistStore определяет методы для работы с черными списками продавцов
+type BlocklistStore interface {
+	AddBlockedBidder(entry *models.BlockedBidder) error
+	RemoveBlockedBidder(ownerID, blockedUserID uint) error
+	GetBlockedBidder(ownerID, blockedUserID uint) (*models.BlockedBidder, error)
+	GetBlockedBiddersByOwnerID(ownerID uint, offset, limit int) ([]models.BlockedBidder, int64, error)
+	GetAllBlockedBidders(offset, limit int, filters map[string]string) ([]models.BlockedBidder, int64, error)
+	IsBidderBlocked(ownerIDs []uint, bidderID uint) (bool, error)
+}
+
+// CreditLimitStore определяет методы для работы с кредитными лимитами участников
+type CreditLimitStore interface {
+	UpsertCreditLimit(limit *models.CreditLimit) error
+	GetCreditLimit(userID, auctionID uint) (*models.CreditLimit, error)
+	GetCreditLimitsByAuctionID(auctionID uint, offset, limit int) ([]models.CreditLimit, int64, error)
+	DeleteCreditLimit(userID, auctionID uint) error
+	GetOpenLotsBidByUser(userID, auctionID uint, excludeLotID uint) ([]models.Lot, error)
+}
+
+// LotImageStore определяет методы для работы с записями о фотографиях лотов
+type LotImageStore interface {
+	CreateLotImages(images []models.LotImage) error
+	GetLotImages(lotID uint) ([]models.LotImage, error)
+	GetLotImage(lotID, imageID uint) (*models.LotImage, error)
+	UpdateLotImagesOrder(lotID uint, orderedIDs []uint) error
+	SetPrimaryLotImage(lotID, imageID uint) error
+	DeleteLotImage(lotID, imageID uint) error
+}
+
+// AttributeSchemaStore определяет методы для работы со схемами атрибутов лотов
+type AttributeSchemaStore interface {
+	CreateAttributeSchema(schema *models.AttributeSchema) error
+	GetAllAttributeSchemas() ([]models.AttributeSchema, error)
+	GetAttributeSchemaByID(id uint) (*models.AttributeSchema, error)
+	GetAttributeSchemaByName(name string) (*models.AttributeSchema, error)
+	UpdateAttributeSchema(schema *models.AttributeSchema) error
+	DeleteAttributeSchema(id uint) error
+	CountAuctionsUsingSchema(id uint) (int64, error)
+}
+
+// CategoryStore определяет методы для работы с рубрикатором
+type CategoryStore interface {
+	CreateCategory(category *models.Category) error
+	GetAllCategories() ([]models.Category, error)
+	GetCategoryByID(id uint) (*models.Category, error)
+	GetCategoryByName(parentID *uint, name string) (*models.Category, error)
+	GetCategoryPath(id uint) ([]models.Category, error)
+	GetCategorySubtreeIDs(id uint) ([]uint, error)
+	UpdateCategory(category *models.Category) error
+	DeleteCategory(id uint) error
+	CountCategoryUsage(id uint) (children, auctions, lots int64, err error)
+}
+
+// BlobStore определяет методы для хранения двоичных файлов (фотографий лотов и их миниатюр)
+type BlobStore interface {
+	Put(key string, r io.Reader) error
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// ConsignmentStore определяет методы для работы с заявками на комиссию и их предметами
+type ConsignmentStore interface {
+	CreateConsignment(consignment *models.Consignment) error
+	GetConsignmentByID(id uint) (*models.Consignment, error)
+	GetConsignments(offset, limit int, filters map[string]string) ([]models.Consignment, int64, error)
+	GetConsignmentItemsByIDs(ids []uint) ([]models.ConsignmentItem, error)
+	UpdateConsignmentItem(item *models.ConsignmentItem) error
+	AssignItemsToLots(items []models.ConsignmentItem, lots []models.Lot) error
+	CreateConsignmentItemImages(images []models.ConsignmentItemImage) error
+	GetConsignmentItemImage(itemID, imageID uint) (*models.ConsignmentItemImage, error)
+}
+
+// WatchlistStore определяет методы для работы со списками наблюдения пользователей
+type WatchlistStore interface {
+	AddWatchlistEntry(entry *models.WatchlistEntry) error
+	GetWatchlistEntry(userID uint, lotID, auctionID *uint) (*models.WatchlistEntry, error)
+	RemoveWatchedLot(userID, lotID uint) error
+	RemoveWatchedAuction(userID, auctionID uint) error
+	GetWatchlistByUserID(userID uint, offset, limit int, entryType string) ([]models.WatchlistEntry, int64, error)
+	GetWatchedLotsByUserID(userID uint, offset, limit int) ([]models.Lot, int64, error)
+	CountWatchersByLotIDs(lotIDs []uint) (map[uint]int64, error)
+	GetWatcherIDsByAuctionID(auctionID uint) ([]uint, error)
+}
+
+// NotificationStore определяет методы для работы с входящими уведомлениями пользователей
+type NotificationStore interface {
+	CreateNotification(notification *models.Notification) error
+	CreateNotificationOnce(notification *models.Notification) (bool, error)
+	GetNotificationsByUserID(userID uint, offset, limit int, unreadOnly bool) ([]models.Notification, int64, error)
+	CountUnreadNotifications(userID uint) (int64, error)
+	MarkNotificationRead(userID, notificationID uint, readAt time.Time) (*models.Notification, error)
+	MarkAllNotificationsRead(userID uint, readAt time.Time) (int64, error)
+}
+
+// SavedSearchStore определяет методы для работы с сохраненными поисками лотов
+type SavedSearchStore interface {
+	CreateSavedSearch(search *models.SavedSearch) error
+	GetSavedSearchByID(id uint) (*models.SavedSearch, error)
+	GetSavedSearchesByUserID(userID uint, offset, limit int) ([]models.SavedSearch, int64, error)
+	UpdateSavedSearch(search *models.SavedSearch) error
+	DeleteSavedSearch(userID, id uint) error
+	GetSavedSearchesBatch(afterID uint, limit int) ([]models.SavedSearch, error)
+	UpdateLastCheckedAt(id uint, checkedAt time.Time) error
+}
+
+// WebhookStore определяет методы для работы с подписками на вебхуки и журналом их доставки
+type WebhookStore interface {
+	CreateWebhookSubscription(subscription *models.WebhookSubscription) error
+	GetWebhookSubscriptionByID(id uint) (*models.WebhookSubscription, error)
+	GetWebhookSubscriptionsByUserID(userID uint, offset, limit int) ([]models.WebhookSubscription, int64, error)
+	UpdateWebhookSubscription(subscription *models.WebhookSubscription) error
+	DeleteWebhookSubscription(userID, id uint) error
+	GetActiveWebhooksForEvent(eventType models.WebhookEventType, ownerIDs []uint) ([]models.WebhookSubscription, error)
+	CreateWebhookDeliveries(deliveries []models.WebhookDelivery) error
+	GetWebhookDeliveryByID(id uint) (*models.WebhookDelivery, error)
+	GetWebhookDeliveriesBySubscriptionID(subscriptionID uint, offset, limit int, status string) ([]models.WebhookDelivery, int64, error)
+	GetDueWebhookDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error)
+	ClaimWebhookDelivery(id uint, expectedNextAttemptAt, leaseUntil time.Time) (bool, error)
+	UpdateWebhookDelivery(delivery *models.WebhookDelivery) error
+}
+
+// OutboxStore определяет методы обработчика outbox. События записываются в outbox методами других
+// хранилищ в транзакции изменения состояния.
+type OutboxStore interface {
+	GetDueOutboxEvents(now time.Time, limit int) ([]models.OutboxEvent, error)
+	ClaimOutboxEvent(id uint, expectedNextAttemptAt, leaseUntil time.Time) (bool, error)
+	GetOutboxConsumers(eventID uint) ([]string, error)
+	MarkOutboxConsumed(eventID uint, consumer string, processedAt time.Time) error
+	UpdateOutboxEvent(event *models.OutboxEvent) error
+	GetOutboxEventsForAuction(auctionID uint, lotIDs []uint, eventTypes []models.DomainEventType) ([]models.OutboxEvent, error)
+}
+
+// AuditStore определяет методы для работы с журналом аудита. Журнал только дополняется,
+// поэтому методов изменения и удаления записей нет.
+type AuditStore interface {
+	GetAuditLogEntries(offset, limit int, filters map[string]string) ([]models.AuditLogEntry, int64, error)
+	ForEachAuditLogEntryBatch(filters map[string]string, batchSize int, handle func(entries []models.AuditLogEntry) error) error
+}
+
+// SessionStore определяет методы для работы с сессиями входа и refresh-токенами
+type SessionStore interface {
+	CreateSession(session *models.UserSession, refreshToken *models.RefreshToken) error
+	GetSessionByID(id uint) (*models.UserSession, error)
+	GetRefreshTokenByHash(tokenHash string) (*models.RefreshToken, error)
+	RotateRefreshToken(usedTokenID uint, next *models.RefreshToken, usedAt time.Time) (bool, error)
+	UpdateSessionRole(id uint, role models.UserRole) error
+	RevokeSession(id uint, reason string, revokedAt time.Time) error
+	RevokeUserSessions(userID uint, reason string, revokedAt time.Time, buildAuditEntry func(revoked int64) *models.AuditLogEntry) (int64, error)
 }
 
 type Store struct {
-	UserStore    UserStore
-	AuctionStore AuctionStore
-	LotStore     LotStore
-	BidStore     BidStore
+	UserStore            UserStore
+	AuctionStore         AuctionStore
+	LotStore             LotStore
+	BidStore             BidStore
+	BlocklistStore       BlocklistStore
+	CreditLimitStore     CreditLimitStore
+	LotImageStore        LotImageStore
+	BlobStore            BlobStore
+	AttributeSchemaStore AttributeSchemaStore
+	CategoryStore        CategoryStore
+	ConsignmentStore     ConsignmentStore
+	WatchlistStore       WatchlistStore
+	NotificationStore    NotificationStore
+	SavedSearchStore     SavedSearchStore
+	WebhookStore         WebhookStore
+	OutboxStore          OutboxStore
+	AuditStore           AuditStore
+	SessionStore         SessionStore
 }
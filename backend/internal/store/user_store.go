@@ -89,23 +89,44 @@ func (s *gormUserStore) UpdateUser(user *models.User) error {
 	return s.db.Save(user).Error
 }
 
-func (s *gormUserStore) GetSellersWithSalesByAuctionSpecificity(specificity string, minTotalSales float64, offset, limit int) ([]models.SellerSalesReport, int64, error) {
+// UpdateUserWithAudit сохраняет изменения пользователя, сделанные администратором, вместе с записью журнала аудита
+func (s *gormUserStore) UpdateUserWithAudit(user *models.User, auditEntry *models.AuditLogEntry) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(user).Error; err != nil {
+			return err
+		}
+		return insertAuditLogEntry(tx, auditEntry)
+	})
+}
+
+func (s *gormUserStore) GetSellersWithSalesByAuctionSpecificity(specificity string, categoryID uint, minTotalSales float64, offset, limit int) ([]models.SellerSalesReport, int64, error) {
 	var results []struct {
 		SellerID   uint
 		TotalSales float64
 		LotsSold   int64
 	}
 	var totalMatchingSellers int64
-	searchTerm := "%" + strings.ToLower(specificity) + "%"
+
+	// Выручка лота считается по распределениям: для многоединичных лотов это цена за единицу, умноженная на проданное количество
+	lotRevenue := s.db.Model(&models.LotAllocation{}).
+		Select("lot_id, SUM(price * quantity) as revenue").
+		Group("lot_id")
 
 	baseAggQuery := s.db.Table("lots as l").
-		Select("l.seller_id, SUM(l.final_price) as total_sales, COUNT(l.id) as lots_sold").
+		Select("l.seller_id, SUM(al.revenue) as total_sales, COUNT(l.id) as lots_sold").
 		Joins("JOIN auctions as a ON a.id = l.auction_id").
-		Where("l.status = ? AND l.final_price IS NOT NULL AND LOWER(a.name_specificity) LIKE ?", models.StatusSold, searchTerm).
-		Group("l.seller_id")
+		Joins("JOIN (?) as al ON al.lot_id = l.id", lotRevenue).
+		Where("l.status = ? AND l.final_price IS NOT NULL", models.StatusSold)
+	if categoryID != 0 {
+		// Рубрика лота важнее рубрики аукциона; учитываются и все подрубрики
+		baseAggQuery = baseAggQuery.Where("COALESCE(l.category_id, a.category_id) IN (?)", categorySubtreeIDs(s.db, categoryID))
+	} else {
+		baseAggQuery = baseAggQuery.Where("LOWER(a.name_specificity) LIKE ?", "%"+strings.ToLower(specificity)+"%")
+	}
+	baseAggQuery = baseAggQuery.Group("l.seller_id")
 
 	var allSellerAggregates []struct{ TotalSales float64 }
-	if err := baseAggQuery.Having("SUM(l.final_price) >= ?", minTotalSales).Scan(&allSellerAggregates).Error; err != nil {
+	if err := baseAggQuery.Having("SUM(al.revenue) >= ?", minTotalSales).Scan(&allSellerAggregates).Error; err != nil {
 		return nil, 0, fmt.Errorf("ошибка подсчета продавцов: %w", err)
 	}
 	totalMatchingSellers = int64(len(allSellerAggregates))
@@ -114,7 +135,7 @@ func (s *gormUserStore) GetSellersWithSalesByAuctionSpecificity(specificity stri
 		return []models.SellerSalesReport{}, 0, nil
 	}
 
-	err := baseAggQuery.Having("SUM(l.final_price) >= ?", minTotalSales).
+	err := baseAggQuery.Having("SUM(al.revenue) >= ?", minTotalSales).
 		Order("total_sales DESC").
 		Offset(offset).
 		Limit(limit).
@@ -156,17 +177,22 @@ func (s *gormUserStore) GetSellersWithSalesByAuctionSpecificity(specificity stri
 	return finalReport, totalMatchingSellers, nil
 }
 
-func (s *gormUserStore) GetBuyersByAuctionSpecificity(specificity string, offset, limit int) ([]models.User, int64, error) {
+func (s *gormUserStore) GetBuyersByAuctionSpecificity(specificity string, categoryID uint, offset, limit int) ([]models.User, int64, error) {
 	var users []models.User
 	var total int64
-	searchTerm := "%" + strings.ToLower(specificity) + "%"
-
-	subQueryAuctions := s.db.Model(&models.Auction{}).Select("id").Where("LOWER(name_specificity) LIKE ?", searchTerm)
 
 	baseQuery := s.db.Model(&models.User{}).Distinct("users.id").
-		Joins("JOIN lots ON lots.final_buyer_id = users.id").
+		Joins("JOIN lot_allocations ON lot_allocations.user_id = users.id").
+		Joins("JOIN lots ON lots.id = lot_allocations.lot_id").
 		Joins("JOIN auctions ON auctions.id = lots.auction_id").
-		Where("lots.status = ? AND lots.final_buyer_id IS NOT NULL AND auctions.id IN (?)", models.StatusSold, subQueryAuctions)
+		Where("lots.status = ?", models.StatusSold)
+	if categoryID != 0 {
+		baseQuery = baseQuery.Where("COALESCE(lots.category_id, auctions.category_id) IN (?)", categorySubtreeIDs(s.db, categoryID))
+	} else {
+		searchTerm := "%" + strings.ToLower(specificity) + "%"
+		subQueryAuctions := s.db.Model(&models.Auction{}).Select("id").Where("LOWER(name_specificity) LIKE ?", searchTerm)
+		baseQuery = baseQuery.Where("auctions.id IN (?)", subQueryAuctions)
+	}
 
 	if err := baseQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"auction-app/backend/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type gormWatchlistStore struct {
+	db *gorm.DB
+}
+
+func NewGormWatchlistStore(db *gorm.DB) WatchlistStore {
+	return &gormWatchlistStore{db: db}
+}
+
+func (s *gormWatchlistStore) AddWatchlistEntry(entry *models.WatchlistEntry) error {
+	return s.db.Create(entry).Error
+}
+
+// GetWatchlistEntry ищет запись пользователя по лоту или аукциону (передается одно из значений)
+func (s *gormWatchlistStore) GetWatchlistEntry(userID uint, lotID, auctionID *uint) (*models.WatchlistEntry, error) {
+	var entry models.WatchlistEntry
+	queryBuilder := s.db.Where("user_id = ?", userID)
+	if lotID != nil {
+		queryBuilder = queryBuilder.Where("lot_id = ?", *lotID)
+	} else {
+		queryBuilder = queryBuilder.Where("auction_id = ?", auctionID)
+	}
+	err := queryBuilder.First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (s *gormWatchlistStore) RemoveWatchedLot(userID, lotID uint) error {
+	result := s.db.Where("user_id = ? AND lot_id = ?", userID, lotID).Delete(&models.WatchlistEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("лот не найден в списке наблюдения")
+	}
+	return nil
+}
+
+func (s *gormWatchlistStore) RemoveWatchedAuction(userID, auctionID uint) error {
+	result := s.db.Where("user_id = ? AND auction_id = ?", userID, auctionID).Delete(&models.WatchlistEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("аукцион не найден в списке наблюдения")
+	}
+	return nil
+}
+
+// GetWatchlistByUserID возвращает список наблюдения пользователя; entryType "lots" или "auctions" ограничивает выборку
+func (s *gormWatchlistStore) GetWatchlistByUserID(userID uint, offset, limit int, entryType string) ([]models.WatchlistEntry, int64, error) {
+	var entries []models.WatchlistEntry
+	var total int64
+	queryBuilder := s.db.Model(&models.WatchlistEntry{}).Where("user_id = ?", userID)
+	switch entryType {
+	case "lots":
+		queryBuilder = queryBuilder.Where("lot_id IS NOT NULL")
+	case "auctions":
+		queryBuilder = queryBuilder.Where("auction_id IS NOT NULL")
+	}
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := queryBuilder.Order("created_at DESC").Offset(offset).Limit(limit).
+		Preload("Lot").Preload("Lot.Images", "is_primary = ?", true).
+		Preload("Auction").
+		Find(&entries).Error
+	return entries, total, err
+}
+
+// GetWatchedLotsByUserID возвращает лоты из списка наблюдения пользователя, начиная с добавленных последними
+func (s *gormWatchlistStore) GetWatchedLotsByUserID(userID uint, offset, limit int) ([]models.Lot, int64, error) {
+	var lots []models.Lot
+	var total int64
+	queryBuilder := s.db.Model(&models.Lot{}).
+		Joins("JOIN watchlist_entries ON watchlist_entries.lot_id = lots.id AND watchlist_entries.user_id = ?", userID)
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := queryBuilder.Order("watchlist_entries.created_at DESC").Offset(offset).Limit(limit).
+		Preload("User").
+		Find(&lots).Error
+	return lots, total, err
+}
+
+// CountWatchersByLotIDs возвращает число наблюдающих для каждого лота; лоты без наблюдающих в результат не попадают
+func (s *gormWatchlistStore) CountWatchersByLotIDs(lotIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64)
+	if len(lotIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		LotID uint
+		Count int64
+	}
+	err := s.db.Model(&models.WatchlistEntry{}).
+		Select("lot_id, COUNT(*) AS count").
+		Where("lot_id IN ?", lotIDs).
+		Group("lot_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.LotID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetWatcherIDsByAuctionID возвращает пользователей, которые следят за аукционом или хотя бы за одним из его лотов
+func (s *gormWatchlistStore) GetWatcherIDsByAuctionID(auctionID uint) ([]uint, error) {
+	var userIDs []uint
+	auctionLotIDs := s.db.Model(&models.Lot{}).Select("id").Where("auction_id = ?", auctionID)
+	err := s.db.Model(&models.WatchlistEntry{}).
+		Distinct("user_id").
+		Where("auction_id = ? OR lot_id IN (?)", auctionID, auctionLotIDs).
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
@@ -0,0 +1,144 @@
+package store
+
+import (
+	"auction-app/backend/internal/models"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormWebhookStore struct {
+	db *gorm.DB
+}
+
+func NewGormWebhookStore(db *gorm.DB) WebhookStore {
+	return &gormWebhookStore{db: db}
+}
+
+func (s *gormWebhookStore) CreateWebhookSubscription(subscription *models.WebhookSubscription) error {
+	return s.db.Create(subscription).Error
+}
+
+func (s *gormWebhookStore) GetWebhookSubscriptionByID(id uint) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	err := s.db.First(&subscription, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (s *gormWebhookStore) GetWebhookSubscriptionsByUserID(userID uint, offset, limit int) ([]models.WebhookSubscription, int64, error) {
+	var subscriptions []models.WebhookSubscription
+	var total int64
+	queryBuilder := s.db.Model(&models.WebhookSubscription{}).Where("user_id = ?", userID)
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := queryBuilder.Order("created_at DESC").Offset(offset).Limit(limit).Find(&subscriptions).Error
+	return subscriptions, total, err
+}
+
+func (s *gormWebhookStore) UpdateWebhookSubscription(subscription *models.WebhookSubscription) error {
+	return s.db.Save(subscription).Error
+}
+
+// DeleteWebhookSubscription удаляет подписку пользователя вместе с журналом ее доставок
+func (s *gormWebhookStore) DeleteWebhookSubscription(userID, id uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", id, userID).Delete(&models.WebhookSubscription{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("подписка на вебхуки не найдена")
+		}
+		return tx.Where("subscription_id = ?", id).Delete(&models.WebhookDelivery{}).Error
+	})
+}
+
+// GetActiveWebhooksForEvent возвращает активные подписки на событие, принадлежащие указанным пользователям
+// или системным администраторам (они получают события по всем лотам и аукционам)
+func (s *gormWebhookStore) GetActiveWebhooksForEvent(eventType models.WebhookEventType, ownerIDs []uint) ([]models.WebhookSubscription, error) {
+	eventFilter, err := json.Marshal([]models.WebhookEventType{eventType})
+	if err != nil {
+		return nil, err
+	}
+	adminIDs := s.db.Model(&models.User{}).Select("id").Where("role = ?", models.RoleSystemAdmin)
+	queryBuilder := s.db.Where("is_active = ? AND events @> ?::jsonb", true, string(eventFilter))
+	if len(ownerIDs) > 0 {
+		queryBuilder = queryBuilder.Where("user_id IN (?) OR user_id IN (?)", ownerIDs, adminIDs)
+	} else {
+		queryBuilder = queryBuilder.Where("user_id IN (?)", adminIDs)
+	}
+	var subscriptions []models.WebhookSubscription
+	err = queryBuilder.Order("id ASC").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// CreateWebhookDeliveries ставит доставки в очередь. Повторная постановка того же события для подписки
+// пропускается (уникальный индекс по подписке и ID события), ручные повторы в индекс не входят.
+func (s *gormWebhookStore) CreateWebhookDeliveries(deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error
+}
+
+func (s *gormWebhookStore) GetWebhookDeliveryByID(id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := s.db.First(&delivery, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (s *gormWebhookStore) GetWebhookDeliveriesBySubscriptionID(subscriptionID uint, offset, limit int, status string) ([]models.WebhookDelivery, int64, error) {
+	var deliveries []models.WebhookDelivery
+	var total int64
+	queryBuilder := s.db.Model(&models.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		queryBuilder = queryBuilder.Where("status = ?", status)
+	}
+
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := queryBuilder.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+// GetDueWebhookDeliveries возвращает доставки, время очередной попытки которых наступило
+func (s *gormWebhookStore) GetDueWebhookDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := s.db.Where("status = ? AND next_attempt_at <= ?", models.DeliveryPending, now).
+		Order("next_attempt_at ASC, id ASC").Limit(limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+// ClaimWebhookDelivery закрепляет доставку за текущим экземпляром, переводя время следующей попытки на leaseUntil.
+// Возвращает false, если доставку уже забрал другой экземпляр (время попытки изменилось).
+func (s *gormWebhookStore) ClaimWebhookDelivery(id uint, expectedNextAttemptAt, leaseUntil time.Time) (bool, error) {
+	result := s.db.Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", id, models.DeliveryPending, expectedNextAttemptAt).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (s *gormWebhookStore) UpdateWebhookDelivery(delivery *models.WebhookDelivery) error {
+	return s.db.Save(delivery).Error
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// MakeThumbnail уменьшает изображение так, чтобы большая сторона не превышала maxSide.
+// Каждый пиксель результата - среднее значение соответствующей области исходника,
+// что дает приемлемое качество без сторонних библиотек.
+func MakeThumbnail(src image.Image, maxSide int) image.Image {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if srcW <= 0 || srcH <= 0 {
+		return src
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, srcW, srcH))
+	draw.Draw(rgba, rgba.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Over)
+
+	if srcW <= maxSide && srcH <= maxSide {
+		return rgba
+	}
+
+	dstW, dstH := maxSide, maxSide
+	if srcW >= srcH {
+		dstH = srcH * maxSide / srcW
+	} else {
+		dstW = srcW * maxSide / srcH
+	}
+	if dstW < 1 {
+		dstW = 1
+	}
+	if dstH < 1 {
+		dstH = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0, y1 := y*srcH/dstH, (y+1)*srcH/dstH
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < dstW; x++ {
+			x0, x1 := x*srcW/dstW, (x+1)*srcW/dstW
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				offset := rgba.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(rgba.Pix[offset])
+					g += uint32(rgba.Pix[offset+1])
+					b += uint32(rgba.Pix[offset+2])
+					a += uint32(rgba.Pix[offset+3])
+					offset += 4
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)})
+		}
+	}
+	return dst
+}
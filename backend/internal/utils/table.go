@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ReadTable читает табличный файл (CSV или XLSX, определяется по расширению имени файла)
+// и возвращает строки в виде срезов ячеек. Для XLSX используется первый лист книги.
+// В CSV допускаются разделители "," и ";" (последний используется Excel в русской локали).
+func ReadTable(fileName string, data []byte) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return readCSV(data)
+	case ".xlsx":
+		return readXLSX(data)
+	}
+	return nil, errors.New("неподдерживаемый формат файла: ожидается CSV или XLSX")
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	firstLine := data
+	if idx := bytes.IndexByte(data, '\n'); idx >= 0 {
+		firstLine = data[:idx]
+	}
+	reader := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения CSV: %w", err)
+	}
+	return rows, nil
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	book, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения XLSX: %w", err)
+	}
+	defer book.Close()
+
+	sheets := book.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("в книге XLSX нет ни одного листа")
+	}
+	rows, err := book.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения листа %q: %w", sheets[0], err)
+	}
+	return rows, nil
+}
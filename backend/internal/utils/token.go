@@ -9,19 +9,24 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 )
 
-// GenerateJWT генерирует новый JWT токен для пользователя
-func GenerateJWT(userID uint, userRole string, cfg *config.Config) (string, error) {
-	expirationTime := time.Now().Add(time.Duration(cfg.JWTExpiresIn) * time.Hour)
+// GenerateJWT генерирует короткоживущий access-токен сессии пользователя и возвращает его вместе с моментом истечения
+func GenerateJWT(userID uint, userRole string, sessionID uint, cfg *config.Config) (string, time.Time, error) {
+	expirationTime := time.Now().Add(time.Duration(cfg.AccessTokenTTLMinutes) * time.Minute)
 
 	claims := &jwt.MapClaims{
 		"authorized": true,
 		"user_id":    userID,
 		"role":       userRole,
+		"sid":        sessionID,
 		"exp":        expirationTime.Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(cfg.JWTSecret))
+	signed, err := token.SignedString([]byte(cfg.JWTSecret))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expirationTime, nil
 }
 
 // ValidateJWT проверяет валидность JWT токена
@@ -6,7 +6,7 @@ import Button from '../common/Button';
 import './Navbar.css';
 
 const Navbar = () => {
-    const { isAuthenticated, activeRole, logout } = useAuth();
+    const { isAuthenticated, currentUser, activeRole, logout, switchActiveRole } = useAuth();
     const navigate = useNavigate();
     const location = useLocation();
 
@@ -24,6 +24,35 @@ const Navbar = () => {
 
     const closeMobileMenu = () => setIsMenuOpen(false);
 
+    // вторая бизнес-роль, на которую можно переключиться без повторного входа
+    let switchTargetRole = null;
+    if (isAuthenticated && currentUser && (activeRole === 'buyer' || activeRole === 'seller')) {
+        try {
+            const availableRoles = typeof currentUser.availableBusinessRoles === 'string'
+                ? JSON.parse(currentUser.availableBusinessRoles)
+                : currentUser.availableBusinessRoles || [];
+            const targetRole = activeRole === 'seller' ? 'buyer' : 'seller';
+            if (Array.isArray(availableRoles) && availableRoles.includes(targetRole)) {
+                switchTargetRole = targetRole;
+            }
+        } catch (e) {
+            console.error("Ошибка парсинга availableBusinessRoles в Navbar:", e);
+        }
+    }
+
+    const handleSwitchRole = async () => {
+        closeMobileMenu();
+        try {
+            await switchActiveRole(switchTargetRole);
+            navigate('/');
+        } catch (error) {
+            console.error("Ошибка смены активной роли:", error.response?.data?.error || error.message);
+            alert(error.response?.data?.error || 'Не удалось сменить активную роль.');
+        }
+    };
+
+    const switchRoleLabel = switchTargetRole === 'seller' ? 'Режим продавца' : 'Режим покупателя';
+
     return (
         <nav className="navbar">
             <div className="navbar-container">
@@ -89,6 +118,11 @@ const Navbar = () => {
                     )}
 
                     <li className="navbar-item-mobile">
+                        {switchTargetRole && (
+                            <Button onClick={handleSwitchRole} variant="secondary" fullWidth>
+                                {switchRoleLabel}
+                            </Button>
+                        )}
                         {isAuthenticated && (
                             <Button onClick={handleLogout} variant="danger" fullWidth>
                                 Выйти
@@ -99,9 +133,16 @@ const Navbar = () => {
 
                 <div className="navbar-user-actions-desktop">
                     {isAuthenticated ? (
-                        <Button onClick={handleLogout} variant="secondary" className="logout-button">
-                            Выйти
-                        </Button>
+                        <>
+                            {switchTargetRole && (
+                                <Button onClick={handleSwitchRole} variant="secondary" className="switch-role-button">
+                                    {switchRoleLabel}
+                                </Button>
+                            )}
+                            <Button onClick={handleLogout} variant="secondary" className="logout-button">
+                                Выйти
+                            </Button>
+                        </>
                     ) : (
                         <div className="login-register-desktop">
                             <Link to="/login" className="navbar-links">Войти</Link>
@@ -1,6 +1,6 @@
 // src/context/AuthContext.js
 import React, { createContext, useState, useContext, useEffect, useCallback } from 'react';
-import { registerUser, getCurrentUser } from '../services/apiClient';
+import { registerUser, getCurrentUser, logoutUser, switchRole as switchRoleRequest } from '../services/apiClient';
 
 const AuthContext = createContext(null);
 
@@ -29,6 +29,7 @@ export const AuthProvider = ({ children }) => {
             } catch (error) {
                 console.error("[AuthContext] Ошибка проверки токена или токен невалиден:", error.response?.data?.message || error.message);
                 localStorage.removeItem('authToken');
+                localStorage.removeItem('refreshToken');
                 localStorage.removeItem('currentUser');
                 localStorage.removeItem('activeRole');
                 setCurrentUser(null);
@@ -43,10 +44,30 @@ export const AuthProvider = ({ children }) => {
         verifyAuthToken();
     }, [verifyAuthToken]);
 
-    const establishSession = (userDataFromValidation, tokenFromValidation, chosenRole) => {
+    const clearSession = useCallback(() => {
+        setAuthToken(null);
+        setCurrentUser(null);
+        setActiveRole(null);
+        setIsAuthenticated(false);
+    }, []);
+
+    // apiClient сообщает, что сессию не удалось обновить (отозвана, истекла или пользователь заблокирован)
+    useEffect(() => {
+        window.addEventListener('auth:logout', clearSession);
+        return () => window.removeEventListener('auth:logout', clearSession);
+    }, [clearSession]);
+
+    useEffect(() => {
+        const handleRefreshed = (event) => setActiveRole(event.detail.activeRole);
+        window.addEventListener('auth:refreshed', handleRefreshed);
+        return () => window.removeEventListener('auth:refreshed', handleRefreshed);
+    }, []);
+
+    const establishSession = (userDataFromValidation, tokenFromValidation, chosenRole, refreshTokenFromValidation) => {
         console.log('[AuthContext] Установка сессии. Пользователь:', userDataFromValidation, 'Выбранная роль:', chosenRole, 'Токен:', tokenFromValidation);
 
         localStorage.setItem('authToken', tokenFromValidation);
+        localStorage.setItem('refreshToken', refreshTokenFromValidation);
         localStorage.setItem('currentUser', JSON.stringify(userDataFromValidation));
         localStorage.setItem('activeRole', chosenRole);
         setAuthToken(tokenFromValidation);
@@ -56,16 +77,30 @@ export const AuthProvider = ({ children }) => {
         setLoading(false);
     };
 
-    const logout = () => {
+    const logout = async () => {
         console.log('[AuthContext] Выход из системы');
+        try {
+            await logoutUser();
+        } catch (error) {
+            console.error("[AuthContext] Ошибка завершения сессии на сервере:", error.response?.data?.error || error.message);
+        }
         localStorage.removeItem('authToken');
+        localStorage.removeItem('refreshToken');
         localStorage.removeItem('currentUser');
         localStorage.removeItem('activeRole');
 
-        setAuthToken(null);
-        setCurrentUser(null);
-        setActiveRole(null);
-        setIsAuthenticated(false);
+        clearSession();
+    };
+
+    const switchActiveRole = async (role) => {
+        const response = await switchRoleRequest(role);
+        const { token, activeRole: roleFromResponse } = response.data;
+        console.log('[AuthContext] Смена активной роли:', activeRole, '->', roleFromResponse);
+        localStorage.setItem('authToken', token);
+        localStorage.setItem('activeRole', roleFromResponse);
+        setAuthToken(token);
+        setActiveRole(roleFromResponse);
+        return roleFromResponse;
     };
 
     const processRegistration = async (registrationData) => {
@@ -87,6 +122,7 @@ export const AuthProvider = ({ children }) => {
             loading,
             establishSession,
             logout,
+            switchActiveRole,
             processRegistration
         }}>
             {children}
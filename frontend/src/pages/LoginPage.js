@@ -62,8 +62,8 @@ const LoginPage = () => {
         setIsLoading(true);
         try {
             const response = await loginUser({ email, password, role: roleToLoginWith });
-            const { token, user: userData, activeRole: roleFromResponse } = response.data;
-            establishSession(userData, token, roleFromResponse);
+            const { token, refreshToken, user: userData, activeRole: roleFromResponse } = response.data;
+            establishSession(userData, token, roleFromResponse, refreshToken);
             navigate(from, { replace: true });
         } catch (err) {
             console.error('Ошибка входа на странице LoginPage:', err.response?.data || err.message);
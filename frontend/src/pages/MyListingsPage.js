@@ -144,7 +144,7 @@ const MyListingsPage = () => {
                                     <p><strong>Стартовая цена:</strong> {lot.startPrice} руб.</p>
                                     <p><strong>Текущая цена:</strong> {lot.currentPrice} руб.</p>
                                     {lot.status === 'Продан' && lot.finalPrice && (
-                                        <p><strong>Продано за:</strong> {lot.finalPrice} руб. (Покупатель: {lot.FinalBuyer?.fullName || `ID ${lot.finalBuyerId}`})</p>
+                                        <p><strong>Продано за:</strong> {lot.finalPrice} руб. (Покупатели: {(lot.allocations || []).map(a => `${a.buyerInfo?.fullName || `ID ${a.userId}`} — ${a.quantity} ед.`).join(', ')})</p>
                                     )}
                                     {lot.highestBidderId && lot.status !== 'Продан' && (
                                         <p><strong>Лидирующая ставка от:</strong> {lot.HighestBidder?.fullName || `Участник ID ${lot.highestBidderId}`}</p>
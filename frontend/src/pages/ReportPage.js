@@ -175,7 +175,7 @@ const ReportPage = () => {
             case 'topNExpensiveLots':
                 headers = [{ label: 'ID Лота' }, { label: 'Название' }, { label: 'Продавец' }, { label: 'Покупатель' }, { label: 'Финальная цена (руб.)' }];
                 rowRenderer = (item) => (
-                    <tr key={item.id}><td>{item.id}</td><td>{item.name}</td><td>{item.User?.fullName || `ID ${item.sellerId}`}</td><td>{item.FinalBuyer?.fullName || `ID ${item.finalBuyerId}`}</td><td>{item.finalPrice}</td></tr>
+                    <tr key={item.id}><td>{item.id}</td><td>{item.name}</td><td>{item.User?.fullName || `ID ${item.sellerId}`}</td><td>{(item.allocations || []).map(a => a.buyerInfo?.fullName || `ID ${a.userId}`).join(', ')}</td><td>{item.finalPrice}</td></tr>
                 );
                 break;
             case 'itemsForSale':
@@ -230,7 +230,7 @@ const ReportPage = () => {
                     <Card title={`Самый дорогой лот #${reportData.lot.id} (${reportData.lot.name})`}>
                         <p>Продано за: <strong>{reportData.lot.finalPrice} руб.</strong></p>
                         <p>Продавец: {reportData.seller?.fullName || `ID: ${reportData.seller?.id}`}</p>
-                        <p>Покупатель: {reportData.buyer?.fullName || `ID: ${reportData.buyer?.id}`}</p>
+                        <p>Покупатели: {(reportData.buyers || []).map(b => b.fullName || `ID: ${b.id}`).join(', ') || 'нет'}</p>
                     </Card>
                 ) : <Alert message="Лот для отчета не найден." type="warning" />;
             default:
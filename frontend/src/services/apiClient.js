@@ -15,6 +15,8 @@ apiClient.interceptors.request.use(
         const token = localStorage.getItem('authToken');
         if (token) {
             config.headers['Authorization'] = `Bearer ${token}`;
+            // по нему при ответе 401 видно, не обновила ли токен другая вкладка
+            config._authToken = token;
         }
         return config;
     },
@@ -23,6 +25,109 @@ apiClient.interceptors.request.use(
     }
 );
 
+// Обновление access-токена по refresh-токену. Запрос идет мимо apiClient, чтобы не попасть
+// в перехватчик ответов; одновременные 401 ждут одного и того же обновления, так как
+// refresh-токен одноразовый и повторное его использование отзывает сессию.
+// Токены общие для всех вкладок (localStorage), поэтому обновление выполняется под межвкладочной
+// блокировкой, а внутри нее сначала проверяется, не получила ли новый токен другая вкладка.
+let refreshPromise = null;
+
+const REFRESH_LOCK_NAME = 'auth-token-refresh';
+const REFRESH_LOCK_KEY = 'authRefreshLock';
+const REFRESH_LOCK_TTL_MS = 15000;
+const REFRESH_LOCK_POLL_MS = 100;
+
+const sleep = (ms) => new Promise((resolve) => setTimeout(resolve, ms));
+
+// Запасная блокировка через localStorage для браузеров без Web Locks API. Запись с истекшим сроком
+// (вкладку закрыли во время обновления) считается свободной.
+const withStorageLock = async (callback) => {
+    const owner = `${Date.now()}-${Math.random().toString(36).slice(2)}`;
+    for (;;) {
+        const lock = JSON.parse(localStorage.getItem(REFRESH_LOCK_KEY) || 'null');
+        if (!lock || lock.expiresAt < Date.now()) {
+            localStorage.setItem(REFRESH_LOCK_KEY, JSON.stringify({ owner, expiresAt: Date.now() + REFRESH_LOCK_TTL_MS }));
+            // другая вкладка могла записать блокировку одновременно: побеждает та, чья запись осталась
+            await sleep(REFRESH_LOCK_POLL_MS);
+            if (JSON.parse(localStorage.getItem(REFRESH_LOCK_KEY) || 'null')?.owner === owner) {
+                break;
+            }
+        }
+        await sleep(REFRESH_LOCK_POLL_MS);
+    }
+    try {
+        return await callback();
+    } finally {
+        if (JSON.parse(localStorage.getItem(REFRESH_LOCK_KEY) || 'null')?.owner === owner) {
+            localStorage.removeItem(REFRESH_LOCK_KEY);
+        }
+    }
+};
+
+const withRefreshLock = (callback) => {
+    if (navigator.locks?.request) {
+        return navigator.locks.request(REFRESH_LOCK_NAME, callback);
+    }
+    return withStorageLock(callback);
+};
+
+const clearStoredSession = () => {
+    localStorage.removeItem('authToken');
+    localStorage.removeItem('refreshToken');
+    localStorage.removeItem('currentUser');
+    localStorage.removeItem('activeRole');
+    window.dispatchEvent(new Event('auth:logout'));
+};
+
+// failedToken - access-токен, с которым запрос получил 401
+const refreshAccessToken = (failedToken) => {
+    if (!refreshPromise) {
+        refreshPromise = withRefreshLock(async () => {
+            const currentToken = localStorage.getItem('authToken');
+            if (currentToken && currentToken !== failedToken) {
+                // пока вкладка ждала блокировку, другая вкладка уже обновила токены
+                window.dispatchEvent(new CustomEvent('auth:refreshed', { detail: { activeRole: localStorage.getItem('activeRole') } }));
+                return currentToken;
+            }
+            const refreshToken = localStorage.getItem('refreshToken');
+            if (!refreshToken) {
+                throw new Error('Сессия завершена в другой вкладке');
+            }
+            const response = await axios.post(`${API_BASE_URL}/auth/refresh`, { refreshToken });
+            localStorage.setItem('authToken', response.data.token);
+            localStorage.setItem('refreshToken', response.data.refreshToken);
+            localStorage.setItem('activeRole', response.data.activeRole);
+            // роль сессии могла смениться в другой вкладке
+            window.dispatchEvent(new CustomEvent('auth:refreshed', { detail: { activeRole: response.data.activeRole } }));
+            return response.data.token;
+        }).finally(() => {
+            refreshPromise = null;
+        });
+    }
+    return refreshPromise;
+};
+
+apiClient.interceptors.response.use(
+    (response) => response,
+    async (error) => {
+        const originalRequest = error.config;
+        const isAuthRequest = originalRequest?.url?.startsWith('/auth/login') || originalRequest?.url?.startsWith('/auth/refresh');
+        if (error.response?.status !== 401 || !originalRequest || originalRequest._retry || isAuthRequest || !localStorage.getItem('refreshToken')) {
+            return Promise.reject(error);
+        }
+        originalRequest._retry = true;
+        try {
+            const token = await refreshAccessToken(originalRequest._authToken);
+            originalRequest.headers['Authorization'] = `Bearer ${token}`;
+            return apiClient(originalRequest);
+        } catch (refreshError) {
+            console.error('[apiClient] Не удалось обновить сессию:', refreshError.response?.data?.error || refreshError.message);
+            clearStoredSession();
+            return Promise.reject(error);
+        }
+    }
+);
+
 // --- Auth API ---
 /**
  * Регистрирует нового пользователя
@@ -44,6 +149,28 @@ export const getCurrentUser = () => {
     return apiClient.get('/auth/me');
 };
 
+/**
+ * Меняет активную бизнес-роль текущей сессии без повторного входа.
+ * @param {string} role - Новая активная роль: 'buyer' или 'seller'
+ */
+export const switchRole = (role) => {
+    return apiClient.post('/auth/switch-role', { role });
+};
+
+/**
+ * Завершает текущую сессию на сервере.
+ */
+export const logoutUser = () => {
+    return apiClient.post('/auth/logout');
+};
+
+/**
+ * Завершает все сессии пользователя на всех устройствах.
+ */
+export const logoutAllSessions = () => {
+    return apiClient.post('/auth/logout-all');
+};
+
 
 // --- Auctions API ---
 /**
@@ -274,5 +401,13 @@ export const adminUpdateUserRoles = (userId, availableBusinessRoles) => {
     return apiClient.put(`/admin/users/${userId}/roles`, { availableBusinessRoles });
 };
 
+/**
+ * Завершает все сессии пользователя (для админа).
+ * @param {string|number} userId  
+ */
+export const adminRevokeUserSessions = (userId) => {
+    return apiClient.post(`/admin/users/${userId}/sessions/revoke`);
+};
+
 
 export default apiClient;  
\ No newline at end of file